@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+)
+
+// variantStats is the aggregated events of the users assigned to the variant
+// The search id is kept during the session, so the sessions are counted by the search id.
+type variantStats struct {
+	Variant         string `bigquery:"variant"`
+	Sessions        int64  `bigquery:"sessions"`
+	ClickedSessions int64  `bigquery:"clicked_sessions"`
+	Displays        int64  `bigquery:"displays"`
+	Clicks          int64  `bigquery:"clicks"`
+}
+
+// sessionCTR is the ratio of the sessions with any clicks
+func (s *variantStats) sessionCTR() float64 {
+	if s.Sessions == 0 {
+		return 0
+	}
+	return float64(s.ClickedSessions) / float64(s.Sessions)
+}
+
+// ctr is the clicks per display
+func (s *variantStats) ctr() float64 {
+	if s.Displays == 0 {
+		return 0
+	}
+	return float64(s.Clicks) / float64(s.Displays)
+}
+
+// fetchVariantStats aggregates display and click events of the event id per variant of the experiment
+// Events are stamped with the experiments only while the experiment is running, so the period just needs to cover it.
+func fetchVariantStats(ctx context.Context, bqClient *bigquery.Client, eventsTable string, experimentID string, eventID string, days int) ([]*variantStats, error) {
+	// the experiment id is validated not to break the JSON path, which can't be a query parameter
+	q := bqClient.Query(fmt.Sprintf(`
+WITH events AS (
+	SELECT
+		action,
+		JSON_VALUE(params, '$.searchId') AS search_id,
+		JSON_VALUE(params, '$.experiments.%[2]s') AS variant
+	FROM %[1]s
+	WHERE id = @event_id AND action IN ('DISPLAY', 'CLICK_ITEM') AND created_at >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL @days DAY)
+), sessions AS (
+	SELECT
+		variant,
+		search_id,
+		COUNTIF(action = 'DISPLAY') AS displays,
+		COUNTIF(action = 'CLICK_ITEM') AS clicks
+	FROM events
+	WHERE variant IS NOT NULL
+	GROUP BY variant, search_id
+)
+SELECT
+	variant,
+	COUNT(*) AS sessions,
+	COUNTIF(clicks > 0) AS clicked_sessions,
+	SUM(displays) AS displays,
+	SUM(clicks) AS clicks
+FROM sessions
+WHERE displays > 0
+GROUP BY variant
+ORDER BY variant
+`, fmt.Sprintf("`%s`", eventsTable), experimentID))
+	q.Parameters = []bigquery.QueryParameter{
+		{Name: "event_id", Value: eventID},
+		{Name: "days", Value: days},
+	}
+	iter, err := q.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query.Read: %w", err)
+	}
+
+	var statsList []*variantStats
+	for {
+		var stats variantStats
+		err := iter.Next(&stats)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("iter.Next: %w", err)
+		}
+		statsList = append(statsList, &stats)
+	}
+	return statsList, nil
+}
@@ -0,0 +1,17 @@
+package main
+
+import "github.com/kelseyhightower/envconfig"
+
+type config struct {
+	GCPProjectID string `envconfig:"GCP_PROJECT_ID" required:"true"`
+	// BigQueryEventsTable is the fully qualified table name of tracking events e.g. "project.dataset.events"
+	BigQueryEventsTable string `envconfig:"BIGQUERY_EVENTS_TABLE" required:"true"`
+}
+
+func newConfig() (*config, error) {
+	var cfg config
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/blendle/zapdriver"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/experiment"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+	"go.uber.org/zap"
+)
+
+// experiment_analyzer computes CTR per variant of the experiment from the tracking events in BigQuery.
+func main() {
+	experimentID := flag.String("experiment", "", "id of the experiment to analyze")
+	eventID := flag.String("event-id", gqlmodel.EventIDSearch.String(), "event id to analyze e.g. SEARCH, SIMILAR_ITEMS")
+	days := flag.Int("days", 30, "number of days of the events to analyze")
+	flag.Parse()
+
+	logger, err := zapdriver.NewProduction()
+	if err != nil {
+		panic(err)
+	}
+	if !experiment.IsValidID(*experimentID) {
+		logger.Fatal("-experiment is invalid", zap.String("experimentId", *experimentID))
+	}
+	if !gqlmodel.EventID(*eventID).IsValid() {
+		logger.Fatal("-event-id is invalid", zap.String("eventId", *eventID))
+	}
+
+	cfg, err := newConfig()
+	if err != nil {
+		logger.Fatal("failed to initialize config", zap.Error(err))
+	}
+
+	ctx := context.Background()
+	bqClient, err := bigquery.NewClient(ctx, cfg.GCPProjectID)
+	if err != nil {
+		logger.Fatal("failed to initialize bigquery client", zap.Error(err))
+	}
+	defer bqClient.Close()
+
+	statsList, err := fetchVariantStats(ctx, bqClient, cfg.BigQueryEventsTable, *experimentID, *eventID, *days)
+	if err != nil {
+		logger.Fatal("failed to fetch variant stats", zap.Error(err))
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "variant\tsessions\tclicked_sessions\tsession_ctr\tdisplays\tclicks\tctr")
+	for _, stats := range statsList {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.4f\t%d\t%d\t%.4f\n",
+			stats.Variant, stats.Sessions, stats.ClickedSessions, stats.sessionCTR(), stats.Displays, stats.Clicks, stats.ctr(),
+		)
+	}
+	if err := tw.Flush(); err != nil {
+		logger.Fatal("failed to write result", zap.Error(err))
+	}
+}
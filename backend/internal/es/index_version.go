@@ -0,0 +1,140 @@
+package es
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// ErrNotAlias is returned when the index is still a concrete index created before versioning
+var ErrNotAlias = errors.New("index is not an alias")
+
+// reindexPollInterval is the interval to check if the reindex task is completed
+const reindexPollInterval = 5 * time.Second
+
+// IndexVersionName returns the name of the index version of the alias
+func IndexVersionName(alias string, createdAt time.Time) string {
+	return fmt.Sprintf("%s_%s", alias, createdAt.Format("20060102150405"))
+}
+
+// GetAliasedIndex returns the index version the alias points to
+// ErrNotAlias is returned when the given name is a concrete index.
+func GetAliasedIndex(ctx context.Context, esClient *elastic.Client, alias string) (string, error) {
+	resp, err := esClient.IndexGetSettings(alias).Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("esClient.IndexGetSettings: %w", err)
+	}
+	if len(resp) != 1 {
+		return "", fmt.Errorf("alias '%s' points to %d indices", alias, len(resp))
+	}
+	for index := range resp {
+		if index == alias {
+			return "", ErrNotAlias
+		}
+		return index, nil
+	}
+	return "", nil
+}
+
+// BlockWrites sets or removes the write block of the index
+// While writes are blocked, the item indexer fails to index and the updates are redelivered by Pub/Sub,
+// so the indexer is paused without stopping it.
+func BlockWrites(ctx context.Context, esClient *elastic.Client, index string, block bool) error {
+	_, err := esClient.IndexPutSettings(index).BodyJson(map[string]interface{}{"index.blocks.write": block}).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("esClient.IndexPutSettings: %w", err)
+	}
+	return nil
+}
+
+// Reindex copies the documents matched with the query from the source index to the destination index
+// and waits until all documents are copied. All documents are copied when the query is nil.
+func Reindex(ctx context.Context, esClient *elastic.Client, sourceIndex, destIndex string, query elastic.Query) error {
+	source := elastic.NewReindexSource().Index(sourceIndex)
+	if query != nil {
+		source = source.Query(query)
+	}
+	task, err := esClient.Reindex().Source(source).DestinationIndex(destIndex).DoAsync(ctx)
+	if err != nil {
+		return fmt.Errorf("esClient.Reindex: %w", err)
+	}
+
+	ticker := time.NewTicker(reindexPollInterval)
+	defer ticker.Stop()
+	for {
+		resp, err := esClient.TasksGetTask().TaskId(task.TaskId).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("esClient.TasksGetTask: %w", err)
+		}
+		if resp.Completed {
+			if resp.Error != nil {
+				return fmt.Errorf("reindex task '%s' failed: %s", task.TaskId, resp.Error.Reason)
+			}
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	if _, err := esClient.Refresh(destIndex).Do(ctx); err != nil {
+		return fmt.Errorf("esClient.Refresh: %w", err)
+	}
+	return nil
+}
+
+// SwapAlias points the alias from the old index to the new index atomically
+// It fails when the alias doesn't point to the old index anymore, so a concurrent swap is never overwritten.
+func SwapAlias(ctx context.Context, esClient *elastic.Client, alias, oldIndex, newIndex string) error {
+	_, err := esClient.Alias().Action(
+		elastic.NewAliasRemoveAction(alias).Index(oldIndex),
+		elastic.NewAliasAddAction(alias).Index(newIndex),
+	).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("esClient.Alias: %w", err)
+	}
+	return nil
+}
+
+// DeleteRetiredIndexVersions deletes the versions of the alias retired by the swap except the given one
+// Retired versions are write blocked and not pointed by the alias.
+// The last retired version should be kept since the point in time opened before the swap still refers to it.
+func DeleteRetiredIndexVersions(ctx context.Context, esClient *elastic.Client, alias, keepIndex string) ([]string, error) {
+	resp, err := esClient.IndexGetSettings(fmt.Sprintf("%s_*", alias)).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("esClient.IndexGetSettings: %w", err)
+	}
+	aliasedIndex, err := GetAliasedIndex(ctx, esClient, alias)
+	if err != nil {
+		return nil, err
+	}
+
+	var retiredIndices []string
+	for index, settings := range resp {
+		if index == keepIndex || index == aliasedIndex || !strings.HasPrefix(index, alias+"_") {
+			continue
+		}
+		if isWriteBlocked(settings.Settings) {
+			retiredIndices = append(retiredIndices, index)
+		}
+	}
+	if len(retiredIndices) == 0 {
+		return nil, nil
+	}
+	if _, err := esClient.DeleteIndex(retiredIndices...).Do(ctx); err != nil {
+		return nil, fmt.Errorf("esClient.DeleteIndex: %w", err)
+	}
+	return retiredIndices, nil
+}
+
+func isWriteBlocked(settings map[string]interface{}) bool {
+	index, _ := settings["index"].(map[string]interface{})
+	blocks, _ := index["blocks"].(map[string]interface{})
+	return blocks["write"] == "true"
+}
@@ -3,26 +3,37 @@ package es
 import "github.com/k-yomo/kagu-miru/backend/internal/xitem"
 
 type Item struct {
-	ID            string         `json:"id"`
-	GroupID       string         `json:"group_id"`
-	Name          string         `json:"name"`
-	Description   string         `json:"description"`
-	Status        xitem.Status   `json:"status"`
-	URL           string         `json:"url"`
-	AffiliateURL  string         `json:"affiliate_url"`
-	Price         int            `json:"price"`
-	ImageURLs     []string       `json:"image_urls"`
-	AverageRating float64        `json:"average_rating"`
-	ReviewCount   int            `json:"review_count"`
-	CategoryID    string         `json:"category_id"`
-	CategoryIDs   []string       `json:"category_ids"`
-	CategoryNames []string       `json:"category_names"`
-	BrandName     string         `json:"brand_name,omitempty"`
-	Colors        []string       `json:"colors"`
-	Metadata      []Metadata     `json:"metadata"`
-	JANCode       string         `json:"jan_code,omitempty"`
-	Platform      xitem.Platform `json:"platform"`
-	IndexedAt     int64          `json:"indexed_at"` // unix millis
+	ID            string          `json:"id"`
+	GroupID       string          `json:"group_id"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	Status        xitem.Status    `json:"status"`
+	URL           string          `json:"url"`
+	AffiliateURL  string          `json:"affiliate_url"`
+	Price         int             `json:"price"`
+	ImageURLs     []string        `json:"image_urls"`
+	AverageRating float64         `json:"average_rating"`
+	ReviewCount   int             `json:"review_count"`
+	CategoryID    string          `json:"category_id"`
+	CategoryIDs   []string        `json:"category_ids"`
+	CategoryNames []string        `json:"category_names"`
+	BrandName     string          `json:"brand_name,omitempty"`
+	Colors        []string        `json:"colors"`
+	WidthRange    *xitem.IntRange `json:"width_range,omitempty"`
+	DepthRange    *xitem.IntRange `json:"depth_range,omitempty"`
+	HeightRange   *xitem.IntRange `json:"height_range,omitempty"`
+	Metadata      []Metadata      `json:"metadata"`
+	JANCode       string          `json:"jan_code,omitempty"`
+	ShopID        string          `json:"shop_id,omitempty"`
+	ShopName      string          `json:"shop_name,omitempty"`
+	Platform      xitem.Platform  `json:"platform"`
+	IndexedAt     int64           `json:"indexed_at"` // unix millis
+
+	// RatingScore is the bayesian average rating of the group computed at index time to rank items fairly
+	RatingScore float64 `json:"rating_score"`
+	// GroupAverageRating and GroupReviewCount are aggregated from the reviewed items in the same group
+	GroupAverageRating float64 `json:"group_average_rating"`
+	GroupReviewCount   int     `json:"group_review_count"`
 }
 
 func (i *Item) IsActive() bool {
@@ -46,8 +57,29 @@ const (
 	ItemFieldCategoryNames = "category_names"
 	ItemFieldBrandName     = "brand_name"
 	ItemFieldColors        = "colors"
+	ItemFieldWidthRange    = "width_range"
+	ItemFieldDepthRange    = "depth_range"
+	ItemFieldHeightRange   = "height_range"
 	ItemFieldMetadata      = "metadata"
 	ItemFieldJANCode       = "jan_code"
+	ItemFieldShopID        = "shop_id"
+	ItemFieldShopName      = "shop_name"
 	ItemFieldPlatform      = "platform"
 	ItemFieldIndexedAt     = "indexed_at"
+
+	ItemFieldRatingScore        = "rating_score"
+	ItemFieldGroupAverageRating = "group_average_rating"
+	ItemFieldGroupReviewCount   = "group_review_count"
 )
+
+// ItemFieldNameModelNumber is the sub field of name to match model numbers like "ABC-1234"
+const ItemFieldNameModelNumber = "name.model_number"
+
+// ItemFieldNameShingle and ItemFieldCategoryNamesShingle are the sub fields of word shingles for spelling suggestions
+const (
+	ItemFieldNameShingle          = "name.shingle"
+	ItemFieldCategoryNamesShingle = "category_names.shingle"
+)
+
+// ItemsSynonymFilterName is the name of the search time synonym filter defined in items index
+const ItemsSynonymFilterName = "item_synonyms"
@@ -0,0 +1,103 @@
+package es
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// ErrLocked is returned when the lock is held by another process
+var ErrLocked = errors.New("lock is held by another process")
+
+// lockTTL is how long the lock is held at most
+// The lock left by a crashed process is taken over after it's expired, so it must cover the longest operation.
+const lockTTL = 30 * time.Minute
+
+type lock struct {
+	Owner     string    `json:"owner"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LockIndexName returns the name of the index storing the lock of the alias
+func LockIndexName(alias string) string {
+	return alias + ".locks"
+}
+
+// AcquireLock acquires the lock of the alias shared among processes changing the index the alias points to
+// e.g. applying synonyms in the API and switching the alias by items_index_creator, so that they don't interleave.
+// ErrLocked is returned when the lock is held by another process, and the returned function releases the lock.
+func AcquireLock(ctx context.Context, esClient *elastic.Client, alias, owner string) (func(ctx context.Context) error, error) {
+	lockIndex := LockIndexName(alias)
+	newLock := &lock{Owner: owner, ExpiresAt: time.Now().Add(lockTTL)}
+	resp, err := esClient.Index().
+		Index(lockIndex).
+		Id(alias).
+		OpType("create").
+		BodyJson(newLock).
+		Refresh("true").
+		Do(ctx)
+	if elastic.IsConflict(err) {
+		resp, err = takeOverExpiredLock(ctx, esClient, lockIndex, alias, newLock)
+		if err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("esClient.Index: %w", err)
+	}
+
+	release := func(ctx context.Context) error {
+		// the lock is not released when it's taken over by another process after expired
+		_, err := esClient.Delete().
+			Index(lockIndex).
+			Id(alias).
+			IfSeqNo(resp.SeqNo).
+			IfPrimaryTerm(resp.PrimaryTerm).
+			Refresh("true").
+			Do(ctx)
+		if err != nil && !elastic.IsConflict(err) && !elastic.IsNotFound(err) {
+			return fmt.Errorf("esClient.Delete: %w", err)
+		}
+		return nil
+	}
+	return release, nil
+}
+
+// takeOverExpiredLock replaces the expired lock with the new lock
+// ErrLocked is returned when the lock is not expired or taken over by another process first.
+func takeOverExpiredLock(ctx context.Context, esClient *elastic.Client, lockIndex, id string, newLock *lock) (*elastic.IndexResponse, error) {
+	getResp, err := esClient.Get().Index(lockIndex).Id(id).Do(ctx)
+	if elastic.IsNotFound(err) {
+		// the lock is released meanwhile
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("esClient.Get: %w", err)
+	}
+	var currentLock lock
+	if err := json.Unmarshal(getResp.Source, &currentLock); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	if time.Now().Before(currentLock.ExpiresAt) || getResp.SeqNo == nil || getResp.PrimaryTerm == nil {
+		return nil, fmt.Errorf("%w: owner: %s", ErrLocked, currentLock.Owner)
+	}
+
+	resp, err := esClient.Index().
+		Index(lockIndex).
+		Id(id).
+		IfSeqNo(*getResp.SeqNo).
+		IfPrimaryTerm(*getResp.PrimaryTerm).
+		BodyJson(newLock).
+		Refresh("true").
+		Do(ctx)
+	if elastic.IsConflict(err) {
+		return nil, ErrLocked
+	}
+	if err != nil {
+		return nil, fmt.Errorf("esClient.Index: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,78 @@
+package es
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// GetSynonyms gets the rules of the search time synonym filter of the index
+func GetSynonyms(ctx context.Context, esClient *elastic.Client, index string) ([]string, error) {
+	resp, err := esClient.IndexGetSettings(index).Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("esClient.IndexGetSettings: %w", err)
+	}
+	for _, settings := range resp {
+		indexSettings, _ := settings.Settings["index"].(map[string]interface{})
+		analysis, _ := indexSettings["analysis"].(map[string]interface{})
+		filters, _ := analysis["filter"].(map[string]interface{})
+		synonymFilter, ok := filters[ItemsSynonymFilterName].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("synonym filter '%s' is not found in '%s'", ItemsSynonymFilterName, index)
+		}
+		rules, _ := synonymFilter["synonyms"].([]interface{})
+		synonyms := make([]string, 0, len(rules))
+		for _, rule := range rules {
+			if s, ok := rule.(string); ok {
+				synonyms = append(synonyms, s)
+			}
+		}
+		return synonyms, nil
+	}
+	return nil, fmt.Errorf("settings of '%s' are not found", index)
+}
+
+// UpdateSynonyms replaces the rules of the search time synonym filter of the index
+// The filter is used only by the search analyzer, so the indexed items don't need to be reindexed.
+// Analysis settings can't be updated while the index is open, so the index is closed during the update
+// and searches and writes to the index fail for the moment, the failed writes are redelivered by Pub/Sub.
+func UpdateSynonyms(ctx context.Context, esClient *elastic.Client, index string, rules []string) (err error) {
+	if rules == nil {
+		// null resets the setting, which makes the filter invalid
+		rules = []string{}
+	}
+
+	if _, err := esClient.CloseIndex(index).Do(ctx); err != nil {
+		return fmt.Errorf("esClient.CloseIndex: %w", err)
+	}
+	defer func() {
+		// the index must be opened again even when the update failed
+		if _, openErr := esClient.OpenIndex(index).Do(context.Background()); openErr != nil && err == nil {
+			err = fmt.Errorf("esClient.OpenIndex: %w", openErr)
+		}
+	}()
+
+	_, err = esClient.IndexPutSettings(index).
+		BodyJson(map[string]interface{}{
+			fmt.Sprintf("index.analysis.filter.%s.synonyms", ItemsSynonymFilterName): rules,
+		}).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("esClient.IndexPutSettings: %w", err)
+	}
+	return nil
+}
+
+// IsSameSynonyms checks if the synonym rules are the same including the order
+func IsSameSynonyms(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
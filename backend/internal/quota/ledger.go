@@ -0,0 +1,111 @@
+package quota
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+const (
+	MarketplaceAmazon        = "amazon"
+	MarketplaceRakuten       = "rakuten"
+	MarketplaceYahooShopping = "yahoo_shopping"
+)
+
+// Unlimited is the daily limit of the api without the limit, and the remaining count of it
+const Unlimited = -1
+
+var ErrExhausted = errors.New("api quota is exhausted")
+
+// daily quotas of the marketplaces are reset at midnight in JST
+var jst = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// Ledger keeps track of the api calls of each credential per day
+// The calls are counted across processes, so fetchers can run in parallel or restart without exceeding the quota.
+type Ledger interface {
+	// Reserve reserves n calls for the credential and returns the remaining count of the day
+	// ErrExhausted is returned without reserving when the calls exceed the daily limit.
+	Reserve(ctx context.Context, credentialID string, n int) (int, error)
+	// Usages returns the usages of the credentials called today
+	Usages(ctx context.Context) ([]*Usage, error)
+}
+
+// Usage is the api calls of the credential today
+type Usage struct {
+	CredentialID string
+	CallCount    int
+	// Remaining is Unlimited when the api has no daily limit
+	Remaining int
+}
+
+// CredentialID returns the id to identify the credential in the ledger without storing the credential itself
+func CredentialID(credential string) string {
+	hash := sha256.Sum256([]byte(credential))
+	return hex.EncodeToString(hash[:])[:16]
+}
+
+type spannerLedger struct {
+	spannerClient *spanner.Client
+	marketplace   string
+	dailyLimit    int
+}
+
+// NewSpannerLedger returns the ledger of the marketplace persisted in spanner
+// dailyLimit is the limit of each credential, Unlimited can be set to only count the calls.
+func NewSpannerLedger(spannerClient *spanner.Client, marketplace string, dailyLimit int) Ledger {
+	return &spannerLedger{
+		spannerClient: spannerClient,
+		marketplace:   marketplace,
+		dailyLimit:    dailyLimit,
+	}
+}
+
+func (l *spannerLedger) Reserve(ctx context.Context, credentialID string, n int) (int, error) {
+	callCount, reserved, err := xspanner.IncrementAPIQuotaUsage(
+		ctx, l.spannerClient, l.marketplace, credentialID, l.today(), int64(n), int64(l.dailyLimit),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("xspanner.IncrementAPIQuotaUsage: %w", err)
+	}
+	if !reserved {
+		return l.remaining(int(callCount)), ErrExhausted
+	}
+	return l.remaining(int(callCount)), nil
+}
+
+func (l *spannerLedger) Usages(ctx context.Context) ([]*Usage, error) {
+	apiQuotaUsages, err := xspanner.GetAPIQuotaUsagesByDate(ctx, l.spannerClient, l.marketplace, l.today())
+	if err != nil {
+		return nil, fmt.Errorf("xspanner.GetAPIQuotaUsagesByDate: %w", err)
+	}
+	usages := make([]*Usage, 0, len(apiQuotaUsages))
+	for _, apiQuotaUsage := range apiQuotaUsages {
+		usages = append(usages, &Usage{
+			CredentialID: apiQuotaUsage.CredentialID,
+			CallCount:    int(apiQuotaUsage.CallCount),
+			Remaining:    l.remaining(int(apiQuotaUsage.CallCount)),
+		})
+	}
+	return usages, nil
+}
+
+func (l *spannerLedger) today() civil.Date {
+	return civil.DateOf(time.Now().In(jst))
+}
+
+func (l *spannerLedger) remaining(callCount int) int {
+	if l.dailyLimit == Unlimited {
+		return Unlimited
+	}
+	if callCount >= l.dailyLimit {
+		return 0
+	}
+	return l.dailyLimit - callCount
+}
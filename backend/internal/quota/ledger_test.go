@@ -0,0 +1,88 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner/xspannertest"
+)
+
+func TestSpannerLedger_Reserve(t *testing.T) {
+	t.Parallel()
+
+	spannerClient := xspannertest.NewEmulatorClient(t)
+
+	tests := []struct {
+		name          string
+		marketplace   string
+		dailyLimit    int
+		reserveNum    int
+		wantReserved  int
+		wantExhausted int
+		wantUsages    []*Usage
+	}{
+		{
+			name:          "reserves concurrently up to the daily limit",
+			marketplace:   MarketplaceAmazon,
+			dailyLimit:    10,
+			reserveNum:    20,
+			wantReserved:  10,
+			wantExhausted: 10,
+			wantUsages:    []*Usage{{CredentialID: "credential", CallCount: 10, Remaining: 0}},
+		},
+		{
+			name:          "counts the calls without the daily limit",
+			marketplace:   MarketplaceRakuten,
+			dailyLimit:    Unlimited,
+			reserveNum:    20,
+			wantReserved:  20,
+			wantExhausted: 0,
+			wantUsages:    []*Usage{{CredentialID: "credential", CallCount: 20, Remaining: Unlimited}},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			ledger := NewSpannerLedger(spannerClient, tt.marketplace, tt.dailyLimit)
+
+			var mu sync.Mutex
+			reserved, exhausted := 0, 0
+			wg := sync.WaitGroup{}
+			for i := 0; i < tt.reserveNum; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, err := ledger.Reserve(ctx, "credential", 1)
+					mu.Lock()
+					defer mu.Unlock()
+					switch {
+					case err == nil:
+						reserved++
+					case errors.Is(err, ErrExhausted):
+						exhausted++
+					default:
+						t.Errorf("Reserve() error = %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if reserved != tt.wantReserved || exhausted != tt.wantExhausted {
+				t.Errorf("Reserve() reserved %d and exhausted %d times, want %d and %d", reserved, exhausted, tt.wantReserved, tt.wantExhausted)
+			}
+			usages, err := ledger.Usages(ctx)
+			if err != nil {
+				t.Fatalf("Usages() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.wantUsages, usages); diff != "" {
+				t.Errorf("Usages(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
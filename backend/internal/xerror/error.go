@@ -7,6 +7,8 @@ type Type int
 const (
 	TypeUnknown Type = iota + 1
 	TypeNotFound
+	TypeInvalidArgument
+	TypeConflict
 )
 
 type internalError struct {
@@ -29,6 +31,14 @@ func NewNotFound(err error) *internalError {
 	return New(TypeNotFound, err)
 }
 
+func NewInvalidArgument(err error) *internalError {
+	return New(TypeInvalidArgument, err)
+}
+
+func NewConflict(err error) *internalError {
+	return New(TypeConflict, err)
+}
+
 func ErrorType(err error) Type {
 	var e *internalError
 	if errors.As(err, &e) {
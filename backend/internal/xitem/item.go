@@ -13,6 +13,12 @@ const (
 	PlatformPayPayMall    Platform = "paypay_mall"
 )
 
+// ProvidesReviews returns false for the platform whose API doesn't provide ratings and reviews
+// Rating and review count of the items are always 0 for such platforms.
+func (p Platform) ProvidesReviews() bool {
+	return p != PlatformAmazon
+}
+
 type Status int
 
 const (
@@ -41,6 +47,8 @@ type Item struct {
 	DepthRange    *IntRange `json:"depthRange,omitempty"`
 	HeightRange   *IntRange `json:"heightRange,omitempty"`
 	JANCode       string    `json:"jan_code,omitempty"`
+	ShopID        string    `json:"shop_id,omitempty"` // must set an ID generated from ShopUniqueID
+	ShopName      string    `json:"shop_name,omitempty"`
 	Platform      Platform  `json:"platform"`
 }
 
@@ -72,3 +80,7 @@ func (i *Item) IsIndexable() bool {
 func ItemUniqueID(platform Platform, itemID string) string {
 	return fmt.Sprintf("%s:%s", platform, itemID)
 }
+
+func ShopUniqueID(platform Platform, shopID string) string {
+	return fmt.Sprintf("%s:%s", platform, shopID)
+}
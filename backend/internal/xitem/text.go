@@ -0,0 +1,22 @@
+package xitem
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeQuery normalizes the query to be compared with the other queries
+// e.g. " ＳＯＦＡ  ベッド" => "sofa ベッド"
+func NormalizeQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(norm.NFKC.String(query))), " ")
+}
+
+// SplitCategoryName splits category name into terms
+// e.g. "ソファ・カウチ" => ["ソファ", "カウチ"]
+func SplitCategoryName(categoryName string) []string {
+	return strings.FieldsFunc(categoryName, func(r rune) bool {
+		return r == '・' || r == '/' || r == '、' || unicode.IsSpace(r)
+	})
+}
@@ -0,0 +1,105 @@
+package xspanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/civil"
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+const APIQuotaUsagesTableName = "api_quota_usages"
+
+var apiQuotaUsagesTableAllColumnsString = strings.Join(getColumnNames(APIQuotaUsage{}), ", ")
+
+// APIQuotaUsage is the number of external api calls with the credential in the day
+type APIQuotaUsage struct {
+	Marketplace  string     `spanner:"marketplace"`
+	CredentialID string     `spanner:"credential_id"`
+	Date         civil.Date `spanner:"date"`
+	CallCount    int64      `spanner:"call_count"`
+	UpdatedAt    time.Time  `spanner:"updated_at"`
+}
+
+// IncrementAPIQuotaUsage adds n to the call count of the day atomically unless the count exceeds maxCallCount
+// The returned bool is false when the count would exceed maxCallCount, then the call count is kept as it is.
+// maxCallCount <= 0 means no limit.
+func IncrementAPIQuotaUsage(ctx context.Context, spannerClient *spanner.Client, marketplace, credentialID string, date civil.Date, n, maxCallCount int64) (int64, bool, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.IncrementAPIQuotaUsage")
+	defer span.End()
+
+	var callCount int64
+	var incremented bool
+	_, err := spannerClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		callCount, incremented = 0, false
+		row, err := tx.ReadRow(ctx, APIQuotaUsagesTableName, spanner.Key{marketplace, credentialID, date}, []string{"call_count"})
+		if err != nil && spanner.ErrCode(err) != codes.NotFound {
+			return fmt.Errorf("tx.ReadRow: %w", err)
+		}
+		if row != nil {
+			if err := row.Column(0, &callCount); err != nil {
+				return fmt.Errorf("row.Column: %w", err)
+			}
+		}
+		if maxCallCount > 0 && callCount+n > maxCallCount {
+			return nil
+		}
+
+		callCount += n
+		incremented = true
+		mutation, err := spanner.InsertOrUpdateStruct(APIQuotaUsagesTableName, &APIQuotaUsage{
+			Marketplace:  marketplace,
+			CredentialID: credentialID,
+			Date:         date,
+			CallCount:    callCount,
+			UpdatedAt:    time.Now(),
+		})
+		if err != nil {
+			return fmt.Errorf("spanner.InsertOrUpdateStruct: %w", err)
+		}
+		return tx.BufferWrite([]*spanner.Mutation{mutation})
+	})
+	if err != nil {
+		return 0, false, logging.Error(ctx, fmt.Errorf("spannerClient.ReadWriteTransaction: %w", err))
+	}
+	return callCount, incremented, nil
+}
+
+// GetAPIQuotaUsagesByDate returns the usages of all credentials of the marketplace in the day
+func GetAPIQuotaUsagesByDate(ctx context.Context, spannerClient *spanner.Client, marketplace string, date civil.Date) ([]*APIQuotaUsage, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetAPIQuotaUsagesByDate")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL: fmt.Sprintf(
+			`SELECT %s FROM api_quota_usages WHERE marketplace = @marketplace AND date = @date ORDER BY credential_id`,
+			apiQuotaUsagesTableAllColumnsString,
+		),
+		Params: map[string]interface{}{"marketplace": marketplace, "date": date},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var usages []*APIQuotaUsage
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var usage APIQuotaUsage
+		if err := row.ToStruct(&usage); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		usages = append(usages, &usage)
+	}
+	return usages, nil
+}
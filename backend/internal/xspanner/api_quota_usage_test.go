@@ -0,0 +1,100 @@
+package xspanner
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"cloud.google.com/go/civil"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner/xspannertest"
+)
+
+func TestIncrementAPIQuotaUsage(t *testing.T) {
+	t.Parallel()
+
+	spannerClient := xspannertest.NewEmulatorClient(t)
+	date := civil.Date{Year: 2022, Month: 4, Day: 1}
+
+	tests := []struct {
+		name             string
+		credentialID     string
+		n                int64
+		maxCallCount     int64
+		callNum          int
+		wantCallCount    int64
+		wantIncrementNum int
+	}{
+		{
+			name:             "increments concurrently without losing any call",
+			credentialID:     "unlimited",
+			n:                1,
+			maxCallCount:     0,
+			callNum:          20,
+			wantCallCount:    20,
+			wantIncrementNum: 20,
+		},
+		{
+			name:             "stops incrementing at the max call count",
+			credentialID:     "limited",
+			n:                1,
+			maxCallCount:     10,
+			callNum:          20,
+			wantCallCount:    10,
+			wantIncrementNum: 10,
+		},
+		{
+			name:             "doesn't increment partially when n exceeds the rest",
+			credentialID:     "limited_partially",
+			n:                3,
+			maxCallCount:     10,
+			callNum:          5,
+			wantCallCount:    9,
+			wantIncrementNum: 3,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			var mu sync.Mutex
+			incrementNum := 0
+			wg := sync.WaitGroup{}
+			for i := 0; i < tt.callNum; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, incremented, err := IncrementAPIQuotaUsage(ctx, spannerClient, "test", tt.credentialID, date, tt.n, tt.maxCallCount)
+					if err != nil {
+						t.Errorf("IncrementAPIQuotaUsage() error = %v", err)
+						return
+					}
+					if incremented {
+						mu.Lock()
+						incrementNum++
+						mu.Unlock()
+					}
+				}()
+			}
+			wg.Wait()
+
+			if incrementNum != tt.wantIncrementNum {
+				t.Errorf("IncrementAPIQuotaUsage() incremented %d times, want %d", incrementNum, tt.wantIncrementNum)
+			}
+			usages, err := GetAPIQuotaUsagesByDate(ctx, spannerClient, "test", date)
+			if err != nil {
+				t.Fatalf("GetAPIQuotaUsagesByDate() error = %v", err)
+			}
+			var callCount int64
+			for _, usage := range usages {
+				if usage.CredentialID == tt.credentialID {
+					callCount = usage.CallCount
+				}
+			}
+			if callCount != tt.wantCallCount {
+				t.Errorf("call count = %d, want %d", callCount, tt.wantCallCount)
+			}
+		})
+	}
+}
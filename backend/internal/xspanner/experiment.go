@@ -0,0 +1,111 @@
+package xspanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+const ExperimentsTableName = "experiments"
+
+var experimentsTableAllColumnsString = strings.Join(getColumnNames(Experiment{}), ", ")
+
+// Experiment is the A/B test running during the period
+type Experiment struct {
+	ID   string `spanner:"id"`
+	Name string `spanner:"name"`
+	// Variants is the JSON array of the variants
+	Variants string `spanner:"variants"`
+	// TrafficAllocation is the ratio of users in the experiment from 0 to 1
+	TrafficAllocation float64   `spanner:"traffic_allocation"`
+	StartsAt          time.Time `spanner:"starts_at"`
+	EndsAt            time.Time `spanner:"ends_at"`
+	UpdatedAt         time.Time `spanner:"updated_at"`
+}
+
+func GetAllExperiments(ctx context.Context, spannerClient *spanner.Client) ([]*Experiment, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetAllExperiments")
+	defer span.End()
+
+	stmt := spanner.NewStatement(fmt.Sprintf(`SELECT %s FROM experiments ORDER BY id`, experimentsTableAllColumnsString))
+	return queryExperiments(ctx, spannerClient, stmt)
+}
+
+// GetUnexpiredExperiments returns experiments which are not ended at the given time
+// Experiments starting in the future are included so that they can be cached before they start.
+func GetUnexpiredExperiments(ctx context.Context, spannerClient *spanner.Client, now time.Time) ([]*Experiment, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetUnexpiredExperiments")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL:    fmt.Sprintf(`SELECT %s FROM experiments WHERE ends_at > @now ORDER BY starts_at, id`, experimentsTableAllColumnsString),
+		Params: map[string]interface{}{"now": now},
+	}
+	return queryExperiments(ctx, spannerClient, stmt)
+}
+
+func queryExperiments(ctx context.Context, spannerClient *spanner.Client, stmt spanner.Statement) ([]*Experiment, error) {
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var experiments []*Experiment
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var experiment Experiment
+		if err := row.ToStruct(&experiment); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		experiments = append(experiments, &experiment)
+	}
+	return experiments, nil
+}
+
+// SaveExperiment creates or updates the experiment
+func SaveExperiment(ctx context.Context, spannerClient *spanner.Client, experiment *Experiment) (*Experiment, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.SaveExperiment")
+	defer span.End()
+
+	saved := *experiment
+	saved.UpdatedAt = time.Now()
+	mutation, err := spanner.InsertOrUpdateStruct(ExperimentsTableName, &saved)
+	if err != nil {
+		return nil, fmt.Errorf("spanner.InsertOrUpdateStruct: %w", err)
+	}
+	if _, err := spannerClient.Apply(ctx, []*spanner.Mutation{mutation}); err != nil {
+		return nil, fmt.Errorf("spannerClient.Apply: %w", err)
+	}
+	return &saved, nil
+}
+
+func DeleteExperiment(ctx context.Context, spannerClient *spanner.Client, experimentID string) error {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.DeleteExperiment")
+	defer span.End()
+
+	_, err := spannerClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		if _, err := tx.ReadRow(ctx, ExperimentsTableName, spanner.Key{experimentID}, []string{"id"}); err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return xerror.NewNotFound(fmt.Errorf("experiment '%s' is not found", experimentID))
+			}
+			return fmt.Errorf("tx.ReadRow: %w", err)
+		}
+		return tx.BufferWrite([]*spanner.Mutation{spanner.Delete(ExperimentsTableName, spanner.Key{experimentID})})
+	})
+	if err != nil {
+		return fmt.Errorf("spannerClient.ReadWriteTransaction: %w", err)
+	}
+	return nil
+}
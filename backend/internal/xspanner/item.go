@@ -106,3 +106,259 @@ WHERE
 
 	return items, nil
 }
+
+// GetAllBrandNames returns distinct brand names of all items
+func GetAllBrandNames(ctx context.Context, spannerClient *spanner.Client) ([]string, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetAllBrandNames")
+	defer span.End()
+
+	stmt := spanner.NewStatement(`SELECT DISTINCT brand_name FROM items WHERE brand_name IS NOT NULL`)
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var brandNames []string
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var brandName string
+		if err := row.Column(0, &brandName); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.Column :%w", err))
+		}
+		brandNames = append(brandNames, brandName)
+	}
+
+	return brandNames, nil
+}
+
+// GetCategoryIDMapByItemIDs returns the map of item id to its category id
+func GetCategoryIDMapByItemIDs(ctx context.Context, spannerClient *spanner.Client, itemIDs []string) (map[string]string, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetCategoryIDMapByItemIDs")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL:    `SELECT id, category_id FROM items WHERE id IN UNNEST(@item_ids)`,
+		Params: map[string]interface{}{"item_ids": itemIDs},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	categoryIDMap := make(map[string]string, len(itemIDs))
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var itemID, categoryID string
+		if err := row.Columns(&itemID, &categoryID); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.Columns :%w", err))
+		}
+		categoryIDMap[itemID] = categoryID
+	}
+
+	return categoryIDMap, nil
+}
+
+// GetItemIDsMapByGroupIDs returns the map of group id to the item ids in the group
+func GetItemIDsMapByGroupIDs(ctx context.Context, spannerClient *spanner.Client, groupIDs []string) (map[string][]string, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetItemIDsMapByGroupIDs")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL:    `SELECT group_id, id FROM items WHERE group_id IN UNNEST(@group_ids) ORDER BY group_id, id`,
+		Params: map[string]interface{}{"group_ids": groupIDs},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	itemIDsMap := make(map[string][]string, len(groupIDs))
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var groupID, itemID string
+		if err := row.Columns(&groupID, &itemID); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.Columns :%w", err))
+		}
+		itemIDsMap[groupID] = append(itemIDsMap[groupID], itemID)
+	}
+
+	return itemIDsMap, nil
+}
+
+// GetStaleItemsByPlatform returns the active items of the platform not updated since updatedBefore
+// The items in prioritizedItemIDs come first in the given order, then the least recently updated ones.
+// Inactive items are excluded not to spend api calls on the items no longer sold.
+func GetStaleItemsByPlatform(ctx context.Context, spannerClient *spanner.Client, platform xitem.Platform, updatedBefore time.Time, prioritizedItemIDs []string, limit int) ([]*Item, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetStaleItemsByPlatform")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL: fmt.Sprintf(`
+SELECT %s
+FROM items
+LEFT JOIN UNNEST(@prioritized_item_ids) AS prioritized_item_id WITH OFFSET AS priority ON id = prioritized_item_id
+WHERE platform = @platform AND status = @status AND updated_at < @updated_before
+ORDER BY priority IS NULL, priority, updated_at, id
+LIMIT @limit
+`, itemsTableAllColumnsString),
+		Params: map[string]interface{}{
+			"platform":             string(platform),
+			"status":               int64(xitem.StatusActive),
+			"updated_before":       updatedBefore,
+			"prioritized_item_ids": prioritizedItemIDs,
+			"limit":                limit,
+		},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var items []*Item
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var item Item
+		if err := row.ToStruct(&item); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
+
+// ItemRatingStats is the aggregated ratings of items
+// RatingSum is the sum of average rating multiplied by review count, so that the mean is RatingSum / ReviewCount.
+type ItemRatingStats struct {
+	Platform    xitem.Platform `spanner:"platform"`
+	CategoryID  string         `spanner:"category_id"`
+	RatingSum   float64        `spanner:"rating_sum"`
+	ReviewCount int64          `spanner:"review_count"`
+}
+
+// GroupRatingStats is the aggregated ratings of items in the group
+type GroupRatingStats struct {
+	GroupID     string  `spanner:"group_id"`
+	RatingSum   float64 `spanner:"rating_sum"`
+	ReviewCount int64   `spanner:"review_count"`
+}
+
+// GetItemRatingStatsByPlatformAndCategory returns the rating stats of reviewed items per platform and category
+func GetItemRatingStatsByPlatformAndCategory(ctx context.Context, spannerClient *spanner.Client) ([]*ItemRatingStats, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetItemRatingStatsByPlatformAndCategory")
+	defer span.End()
+
+	stmt := spanner.NewStatement(`
+SELECT platform, category_id, SUM(average_rating * review_count) AS rating_sum, SUM(review_count) AS review_count
+FROM items
+WHERE review_count > 0
+GROUP BY platform, category_id
+`)
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var statsList []*ItemRatingStats
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var stats ItemRatingStats
+		if err := row.ToStruct(&stats); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		statsList = append(statsList, &stats)
+	}
+
+	return statsList, nil
+}
+
+// GetGroupRatingStats returns the rating stats of reviewed items in the groups except for the excluded items
+func GetGroupRatingStats(ctx context.Context, spannerClient *spanner.Client, groupIDs []string, excludedItemIDs []string) ([]*GroupRatingStats, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetGroupRatingStats")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL: `
+SELECT group_id, SUM(average_rating * review_count) AS rating_sum, SUM(review_count) AS review_count
+FROM items
+WHERE group_id IN UNNEST(@group_ids) AND id NOT IN UNNEST(@excluded_item_ids) AND review_count > 0
+GROUP BY group_id
+`,
+		Params: map[string]interface{}{"group_ids": groupIDs, "excluded_item_ids": excludedItemIDs},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var statsList []*GroupRatingStats
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var stats GroupRatingStats
+		if err := row.ToStruct(&stats); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		statsList = append(statsList, &stats)
+	}
+
+	return statsList, nil
+}
+
+// GetActiveItemsInGroups returns the active items in the groups except for the excluded items
+func GetActiveItemsInGroups(ctx context.Context, spannerClient *spanner.Client, groupIDs []string, excludedItemIDs []string) ([]*Item, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetActiveItemsInGroups")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL: fmt.Sprintf(`
+SELECT %s
+FROM items
+WHERE group_id IN UNNEST(@group_ids) AND id NOT IN UNNEST(@excluded_item_ids) AND status = @status
+`, itemsTableAllColumnsString),
+		Params: map[string]interface{}{"group_ids": groupIDs, "excluded_item_ids": excludedItemIDs, "status": int64(xitem.StatusActive)},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var items []*Item
+	for {
+		row, err := iter.Next()
+		if err != nil {
+			if err == iterator.Done {
+				break
+			}
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var item Item
+		if err := row.ToStruct(&item); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		items = append(items, &item)
+	}
+
+	return items, nil
+}
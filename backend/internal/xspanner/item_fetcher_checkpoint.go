@@ -0,0 +1,101 @@
+package xspanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/iterator"
+)
+
+const ItemFetcherCheckpointsTableName = "item_fetcher_checkpoints"
+
+var itemFetcherCheckpointsTableAllColumnsString = strings.Join(getColumnNames(ItemFetcherCheckpoint{}), ", ")
+
+// ItemFetcherCheckpoint is the progress of the fetcher's crawl unit (e.g. genre) in the run
+type ItemFetcherCheckpoint struct {
+	FetcherName string `spanner:"fetcher_name"`
+	UnitID      string `spanner:"unit_id"`
+	RunID       string `spanner:"run_id"`
+	// CursorState is the JSON of the cursor state to resume the crawl
+	CursorState string    `spanner:"cursor_state"`
+	UpdatedAt   time.Time `spanner:"updated_at"`
+}
+
+// GetLastItemFetcherRunID returns the run id of the fetcher's last checkpoint
+// Empty string is returned when the fetcher has never saved checkpoints.
+func GetLastItemFetcherRunID(ctx context.Context, spannerClient *spanner.Client, fetcherName string) (string, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetLastItemFetcherRunID")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL:    `SELECT run_id FROM item_fetcher_checkpoints WHERE fetcher_name = @fetcher_name ORDER BY updated_at DESC LIMIT 1`,
+		Params: map[string]interface{}{"fetcher_name": fetcherName},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	row, err := iter.Next()
+	if err == iterator.Done {
+		return "", nil
+	}
+	if err != nil {
+		return "", logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+	}
+	var runID string
+	if err := row.Column(0, &runID); err != nil {
+		return "", logging.Error(ctx, fmt.Errorf("row.Column :%w", err))
+	}
+	return runID, nil
+}
+
+func GetItemFetcherCheckpointsByRunID(ctx context.Context, spannerClient *spanner.Client, fetcherName, runID string) ([]*ItemFetcherCheckpoint, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetItemFetcherCheckpointsByRunID")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL: fmt.Sprintf(
+			`SELECT %s FROM item_fetcher_checkpoints WHERE fetcher_name = @fetcher_name AND run_id = @run_id`,
+			itemFetcherCheckpointsTableAllColumnsString,
+		),
+		Params: map[string]interface{}{"fetcher_name": fetcherName, "run_id": runID},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var checkpoints []*ItemFetcherCheckpoint
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var checkpoint ItemFetcherCheckpoint
+		if err := row.ToStruct(&checkpoint); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		checkpoints = append(checkpoints, &checkpoint)
+	}
+	return checkpoints, nil
+}
+
+// SaveItemFetcherCheckpoint overwrites the checkpoint of the unit
+func SaveItemFetcherCheckpoint(ctx context.Context, spannerClient *spanner.Client, checkpoint *ItemFetcherCheckpoint) error {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.SaveItemFetcherCheckpoint")
+	defer span.End()
+
+	mutation, err := spanner.InsertOrUpdateStruct(ItemFetcherCheckpointsTableName, checkpoint)
+	if err != nil {
+		return logging.Error(ctx, fmt.Errorf("spanner.InsertOrUpdateStruct: %w", err))
+	}
+	if _, err := spannerClient.Apply(ctx, []*spanner.Mutation{mutation}); err != nil {
+		return logging.Error(ctx, fmt.Errorf("spannerClient.Apply: %w", err))
+	}
+	return nil
+}
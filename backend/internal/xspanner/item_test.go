@@ -0,0 +1,66 @@
+package xspanner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner/xspannertest"
+)
+
+func newTestItem(id string, status xitem.Status, platform xitem.Platform, updatedAt time.Time) *Item {
+	return &Item{
+		ID:          id,
+		Name:        id,
+		Description: id,
+		Status:      int64(status),
+		ImageURLs:   []string{},
+		CategoryID:  "category",
+		Platform:    platform,
+		UpdatedAt:   updatedAt,
+	}
+}
+
+func TestGetStaleItemsByPlatform(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	spannerClient := xspannertest.NewEmulatorClient(t)
+	now := time.Now().UTC()
+	items := []*Item{
+		newTestItem("stale", xitem.StatusActive, xitem.PlatformAmazon, now.Add(-3*time.Hour)),
+		newTestItem("stale_prioritized", xitem.StatusActive, xitem.PlatformAmazon, now.Add(-2*time.Hour)),
+		newTestItem("stale_inactive", xitem.StatusInactive, xitem.PlatformAmazon, now.Add(-3*time.Hour)),
+		newTestItem("stale_other_platform", xitem.StatusActive, xitem.PlatformRakuten, now.Add(-3*time.Hour)),
+		newTestItem("fresh", xitem.StatusActive, xitem.PlatformAmazon, now),
+	}
+	mutations := []*spanner.Mutation{
+		spanner.InsertOrUpdate(ItemCategoriesTableName, []string{"id", "name", "level", "is_active", "updated_at"}, []interface{}{"category", "category", 0, true, now}),
+	}
+	for _, item := range items {
+		m, err := spanner.InsertOrUpdateStruct(ItemsTableName, item)
+		if err != nil {
+			t.Fatalf("spanner.InsertOrUpdateStruct() error = %v", err)
+		}
+		mutations = append(mutations, m)
+	}
+	if _, err := spannerClient.Apply(ctx, mutations); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	got, err := GetStaleItemsByPlatform(ctx, spannerClient, xitem.PlatformAmazon, now.Add(-time.Hour), []string{"stale_prioritized", "stale_inactive"}, 10)
+	if err != nil {
+		t.Fatalf("GetStaleItemsByPlatform() error = %v", err)
+	}
+	gotIDs := make([]string, 0, len(got))
+	for _, item := range got {
+		gotIDs = append(gotIDs, item.ID)
+	}
+	want := []string{"stale_prioritized", "stale"}
+	if diff := cmp.Diff(want, gotIDs); diff != "" {
+		t.Errorf("GetStaleItemsByPlatform(), (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,119 @@
+package xspanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+const MerchandisingRulesTableName = "merchandising_rules"
+
+var merchandisingRulesTableAllColumnsString = strings.Join(getColumnNames(MerchandisingRule{}), ", ")
+
+// MerchandisingRule pins or buries items in search results for the query and/or category during the period
+type MerchandisingRule struct {
+	ID   string `spanner:"id"`
+	Name string `spanner:"name"`
+	// Query is the normalized query, the rule is applied to any query when null
+	Query spanner.NullString `spanner:"query"`
+	// CategoryID is the category filtered, the rule is applied to any category when null
+	CategoryID     spanner.NullString `spanner:"category_id"`
+	PinnedItemIDs  []string           `spanner:"pinned_item_ids"`
+	PinnedGroupIDs []string           `spanner:"pinned_group_ids"`
+	BuriedItemIDs  []string           `spanner:"buried_item_ids"`
+	BuriedGroupIDs []string           `spanner:"buried_group_ids"`
+	StartsAt       time.Time          `spanner:"starts_at"`
+	EndsAt         time.Time          `spanner:"ends_at"`
+	IsActive       bool               `spanner:"is_active"`
+	UpdatedAt      time.Time          `spanner:"updated_at"`
+}
+
+func GetAllMerchandisingRules(ctx context.Context, spannerClient *spanner.Client) ([]*MerchandisingRule, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetAllMerchandisingRules")
+	defer span.End()
+
+	stmt := spanner.NewStatement(fmt.Sprintf(`SELECT %s FROM merchandising_rules ORDER BY id`, merchandisingRulesTableAllColumnsString))
+	return queryMerchandisingRules(ctx, spannerClient, stmt)
+}
+
+// GetUnexpiredMerchandisingRules returns active rules which are not expired at the given time
+// Rules starting in the future are included so that they can be cached before they start.
+func GetUnexpiredMerchandisingRules(ctx context.Context, spannerClient *spanner.Client, now time.Time) ([]*MerchandisingRule, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetUnexpiredMerchandisingRules")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL: fmt.Sprintf(
+			`SELECT %s FROM merchandising_rules WHERE is_active AND ends_at > @now ORDER BY id`,
+			merchandisingRulesTableAllColumnsString,
+		),
+		Params: map[string]interface{}{"now": now},
+	}
+	return queryMerchandisingRules(ctx, spannerClient, stmt)
+}
+
+func queryMerchandisingRules(ctx context.Context, spannerClient *spanner.Client, stmt spanner.Statement) ([]*MerchandisingRule, error) {
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var rules []*MerchandisingRule
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var rule MerchandisingRule
+		if err := row.ToStruct(&rule); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		rules = append(rules, &rule)
+	}
+	return rules, nil
+}
+
+// SaveMerchandisingRule creates or updates the merchandising rule
+func SaveMerchandisingRule(ctx context.Context, spannerClient *spanner.Client, rule *MerchandisingRule) (*MerchandisingRule, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.SaveMerchandisingRule")
+	defer span.End()
+
+	saved := *rule
+	saved.UpdatedAt = time.Now()
+	mutation, err := spanner.InsertOrUpdateStruct(MerchandisingRulesTableName, &saved)
+	if err != nil {
+		return nil, fmt.Errorf("spanner.InsertOrUpdateStruct: %w", err)
+	}
+	if _, err := spannerClient.Apply(ctx, []*spanner.Mutation{mutation}); err != nil {
+		return nil, fmt.Errorf("spannerClient.Apply: %w", err)
+	}
+	return &saved, nil
+}
+
+func DeleteMerchandisingRule(ctx context.Context, spannerClient *spanner.Client, ruleID string) error {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.DeleteMerchandisingRule")
+	defer span.End()
+
+	_, err := spannerClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		if _, err := tx.ReadRow(ctx, MerchandisingRulesTableName, spanner.Key{ruleID}, []string{"id"}); err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return xerror.NewNotFound(fmt.Errorf("merchandising rule '%s' is not found", ruleID))
+			}
+			return fmt.Errorf("tx.ReadRow: %w", err)
+		}
+		return tx.BufferWrite([]*spanner.Mutation{spanner.Delete(MerchandisingRulesTableName, spanner.Key{ruleID})})
+	})
+	if err != nil {
+		return fmt.Errorf("spannerClient.ReadWriteTransaction: %w", err)
+	}
+	return nil
+}
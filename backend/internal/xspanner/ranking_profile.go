@@ -0,0 +1,111 @@
+package xspanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/iterator"
+)
+
+const RankingProfilesTableName = "ranking_profiles"
+
+var rankingProfilesTableAllColumnsString = strings.Join(getColumnNames(RankingProfile{}), ", ")
+
+// RankingProfile is a version of the named ranking config
+// Versions are immutable, and the latest version of each name is used.
+type RankingProfile struct {
+	Name    string `spanner:"name"`
+	Version int64  `spanner:"version"`
+	// Config is the ranking config in JSON
+	Config    string    `spanner:"config"`
+	CreatedAt time.Time `spanner:"created_at"`
+}
+
+// GetLatestRankingProfiles returns the latest version of all ranking profiles
+func GetLatestRankingProfiles(ctx context.Context, spannerClient *spanner.Client) ([]*RankingProfile, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetLatestRankingProfiles")
+	defer span.End()
+
+	stmt := spanner.NewStatement(fmt.Sprintf(`
+SELECT %s
+FROM ranking_profiles
+JOIN (SELECT name, MAX(version) AS version FROM ranking_profiles GROUP BY name) USING (name, version)
+ORDER BY name
+`, rankingProfilesTableAllColumnsString))
+	return queryRankingProfiles(ctx, spannerClient, stmt)
+}
+
+func GetRankingProfileVersions(ctx context.Context, spannerClient *spanner.Client, name string) ([]*RankingProfile, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetRankingProfileVersions")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL:    fmt.Sprintf(`SELECT %s FROM ranking_profiles WHERE name = @name ORDER BY version DESC`, rankingProfilesTableAllColumnsString),
+		Params: map[string]interface{}{"name": name},
+	}
+	return queryRankingProfiles(ctx, spannerClient, stmt)
+}
+
+func queryRankingProfiles(ctx context.Context, spannerClient *spanner.Client, stmt spanner.Statement) ([]*RankingProfile, error) {
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var profiles []*RankingProfile
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var profile RankingProfile
+		if err := row.ToStruct(&profile); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		profiles = append(profiles, &profile)
+	}
+	return profiles, nil
+}
+
+// InsertRankingProfileVersion saves the config as the next version of the ranking profile
+func InsertRankingProfileVersion(ctx context.Context, spannerClient *spanner.Client, name string, config string) (*RankingProfile, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.InsertRankingProfileVersion")
+	defer span.End()
+
+	var saved RankingProfile
+	_, err := spannerClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		stmt := spanner.Statement{
+			SQL:    `SELECT IFNULL(MAX(version), 0) FROM ranking_profiles WHERE name = @name`,
+			Params: map[string]interface{}{"name": name},
+		}
+		var version int64
+		err := tx.Query(ctx, stmt).Do(func(row *spanner.Row) error {
+			return row.Column(0, &version)
+		})
+		if err != nil {
+			return fmt.Errorf("tx.Query: %w", err)
+		}
+
+		saved = RankingProfile{
+			Name:      name,
+			Version:   version + 1,
+			Config:    config,
+			CreatedAt: time.Now(),
+		}
+		mutation, err := spanner.InsertStruct(RankingProfilesTableName, &saved)
+		if err != nil {
+			return fmt.Errorf("spanner.InsertStruct: %w", err)
+		}
+		return tx.BufferWrite([]*spanner.Mutation{mutation})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("spannerClient.ReadWriteTransaction: %w", err)
+	}
+	return &saved, nil
+}
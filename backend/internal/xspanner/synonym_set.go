@@ -0,0 +1,205 @@
+package xspanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	SynonymSetsTableName         = "synonym_sets"
+	SynonymSetHistoriesTableName = "synonym_set_histories"
+)
+
+var (
+	synonymSetsTableAllColumnsString         = strings.Join(getColumnNames(SynonymSet{}), ", ")
+	synonymSetHistoriesTableAllColumnsString = strings.Join(getColumnNames(SynonymSetHistory{}), ", ")
+)
+
+// SynonymSet is a set of terms treated as the same term in search
+type SynonymSet struct {
+	ID       string   `spanner:"id"`
+	Name     string   `spanner:"name"`
+	Synonyms []string `spanner:"synonyms"`
+	IsActive bool     `spanner:"is_active"`
+	// Version is incremented every time the set is changed
+	Version   int64     `spanner:"version"`
+	UpdatedAt time.Time `spanner:"updated_at"`
+}
+
+// SynonymSetHistory is a snapshot of the synonym set at the version
+type SynonymSetHistory struct {
+	SynonymSetID string    `spanner:"synonym_set_id"`
+	Version      int64     `spanner:"version"`
+	Name         string    `spanner:"name"`
+	Synonyms     []string  `spanner:"synonyms"`
+	IsActive     bool      `spanner:"is_active"`
+	CreatedAt    time.Time `spanner:"created_at"`
+}
+
+func GetAllSynonymSets(ctx context.Context, spannerClient *spanner.Client) ([]*SynonymSet, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetAllSynonymSets")
+	defer span.End()
+
+	stmt := spanner.NewStatement(fmt.Sprintf(`SELECT %s FROM synonym_sets ORDER BY id`, synonymSetsTableAllColumnsString))
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var synonymSets []*SynonymSet
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var synonymSet SynonymSet
+		if err := row.ToStruct(&synonymSet); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		synonymSets = append(synonymSets, &synonymSet)
+	}
+	return synonymSets, nil
+}
+
+func GetSynonymSetHistories(ctx context.Context, spannerClient *spanner.Client, synonymSetID string) ([]*SynonymSetHistory, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.GetSynonymSetHistories")
+	defer span.End()
+
+	stmt := spanner.Statement{
+		SQL: fmt.Sprintf(
+			`SELECT %s FROM synonym_set_histories WHERE synonym_set_id = @synonym_set_id ORDER BY version DESC`,
+			synonymSetHistoriesTableAllColumnsString,
+		),
+		Params: map[string]interface{}{"synonym_set_id": synonymSetID},
+	}
+	iter := spannerClient.Single().Query(ctx, stmt)
+	defer iter.Stop()
+
+	var histories []*SynonymSetHistory
+	for {
+		row, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("iter.Next :%w", err))
+		}
+		var history SynonymSetHistory
+		if err := row.ToStruct(&history); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("row.ToStruct :%w", err))
+		}
+		histories = append(histories, &history)
+	}
+	return histories, nil
+}
+
+// SaveSynonymSet creates or updates the synonym set with incremented version and records the history
+// ID, Name, Synonyms and IsActive of the given set are saved, and Version and UpdatedAt are set.
+func SaveSynonymSet(ctx context.Context, spannerClient *spanner.Client, synonymSet *SynonymSet) (*SynonymSet, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.SaveSynonymSet")
+	defer span.End()
+
+	saved := *synonymSet
+	_, err := spannerClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		version, err := getSynonymSetVersion(ctx, tx, synonymSet.ID)
+		if err != nil {
+			return err
+		}
+		saved.Version = version + 1
+		saved.UpdatedAt = time.Now()
+		return saveSynonymSetWithHistory(tx, &saved)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("spannerClient.ReadWriteTransaction: %w", err)
+	}
+	return &saved, nil
+}
+
+// RollbackSynonymSet saves the synonym set at the given version as a new version
+func RollbackSynonymSet(ctx context.Context, spannerClient *spanner.Client, synonymSetID string, version int64) (*SynonymSet, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "xspanner.RollbackSynonymSet")
+	defer span.End()
+
+	var saved SynonymSet
+	_, err := spannerClient.ReadWriteTransaction(ctx, func(ctx context.Context, tx *spanner.ReadWriteTransaction) error {
+		row, err := tx.ReadRow(
+			ctx,
+			SynonymSetHistoriesTableName,
+			spanner.Key{synonymSetID, version},
+			getColumnNames(SynonymSetHistory{}),
+		)
+		if err != nil {
+			if spanner.ErrCode(err) == codes.NotFound {
+				return xerror.NewNotFound(fmt.Errorf("synonym set '%s' at version %d is not found", synonymSetID, version))
+			}
+			return fmt.Errorf("tx.ReadRow: %w", err)
+		}
+		var history SynonymSetHistory
+		if err := row.ToStruct(&history); err != nil {
+			return fmt.Errorf("row.ToStruct: %w", err)
+		}
+		currentVersion, err := getSynonymSetVersion(ctx, tx, synonymSetID)
+		if err != nil {
+			return err
+		}
+
+		saved = SynonymSet{
+			ID:        synonymSetID,
+			Name:      history.Name,
+			Synonyms:  history.Synonyms,
+			IsActive:  history.IsActive,
+			Version:   currentVersion + 1,
+			UpdatedAt: time.Now(),
+		}
+		return saveSynonymSetWithHistory(tx, &saved)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("spannerClient.ReadWriteTransaction: %w", err)
+	}
+	return &saved, nil
+}
+
+// getSynonymSetVersion returns the current version of the synonym set, or 0 if not exist
+func getSynonymSetVersion(ctx context.Context, tx *spanner.ReadWriteTransaction, synonymSetID string) (int64, error) {
+	row, err := tx.ReadRow(ctx, SynonymSetsTableName, spanner.Key{synonymSetID}, []string{"version"})
+	if err != nil {
+		if spanner.ErrCode(err) == codes.NotFound {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("tx.ReadRow: %w", err)
+	}
+	var version int64
+	if err := row.Column(0, &version); err != nil {
+		return 0, fmt.Errorf("row.Column: %w", err)
+	}
+	return version, nil
+}
+
+func saveSynonymSetWithHistory(tx *spanner.ReadWriteTransaction, synonymSet *SynonymSet) error {
+	synonymSetMutation, err := spanner.InsertOrUpdateStruct(SynonymSetsTableName, synonymSet)
+	if err != nil {
+		return fmt.Errorf("spanner.InsertOrUpdateStruct: %w", err)
+	}
+	historyMutation, err := spanner.InsertStruct(SynonymSetHistoriesTableName, &SynonymSetHistory{
+		SynonymSetID: synonymSet.ID,
+		Version:      synonymSet.Version,
+		Name:         synonymSet.Name,
+		Synonyms:     synonymSet.Synonyms,
+		IsActive:     synonymSet.IsActive,
+		CreatedAt:    synonymSet.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("spanner.InsertStruct: %w", err)
+	}
+	return tx.BufferWrite([]*spanner.Mutation{synonymSetMutation, historyMutation})
+}
@@ -0,0 +1,126 @@
+package xspannertest
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	database "cloud.google.com/go/spanner/admin/database/apiv1"
+	instance "cloud.google.com/go/spanner/admin/instance/apiv1"
+	"github.com/k-yomo/kagu-miru/backend/pkg/spannerutil"
+	"github.com/k-yomo/kagu-miru/backend/pkg/uuid"
+	databasepb "google.golang.org/genproto/googleapis/spanner/admin/database/v1"
+	instancepb "google.golang.org/genproto/googleapis/spanner/admin/instance/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	emulatorProjectID  = "test-project"
+	emulatorInstanceID = "test-instance"
+)
+
+// NewEmulatorClient creates a database with the schema on the Spanner emulator and returns the client for it
+// Each call creates a new database, so tests don't interfere with each other.
+// The test is skipped when SPANNER_EMULATOR_HOST is not set.
+func NewEmulatorClient(t *testing.T) *spanner.Client {
+	t.Helper()
+
+	if os.Getenv("SPANNER_EMULATOR_HOST") == "" {
+		t.Skip("SPANNER_EMULATOR_HOST is not set")
+	}
+	ctx := context.Background()
+
+	if err := createInstance(ctx); err != nil {
+		t.Fatalf("createInstance: %v", err)
+	}
+	databaseID := "t_" + strings.ToLower(uuid.UUID())
+	if err := createDatabase(ctx, databaseID); err != nil {
+		t.Fatalf("createDatabase: %v", err)
+	}
+
+	spannerClient, err := spanner.NewClient(ctx, spannerutil.BuildSpannerDBPath(emulatorProjectID, emulatorInstanceID, databaseID))
+	if err != nil {
+		t.Fatalf("spanner.NewClient: %v", err)
+	}
+	t.Cleanup(spannerClient.Close)
+	return spannerClient
+}
+
+func createInstance(ctx context.Context) error {
+	instanceAdminClient, err := instance.NewInstanceAdminClient(ctx)
+	if err != nil {
+		return fmt.Errorf("instance.NewInstanceAdminClient: %w", err)
+	}
+	defer instanceAdminClient.Close()
+
+	op, err := instanceAdminClient.CreateInstance(ctx, &instancepb.CreateInstanceRequest{
+		Parent:     fmt.Sprintf("projects/%s", emulatorProjectID),
+		InstanceId: emulatorInstanceID,
+		Instance: &instancepb.Instance{
+			Config:      fmt.Sprintf("projects/%s/instanceConfigs/emulator-config", emulatorProjectID),
+			DisplayName: emulatorInstanceID,
+			NodeCount:   1,
+		},
+	})
+	// the instance is shared by all tests
+	if status.Code(err) == codes.AlreadyExists {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("instanceAdminClient.CreateInstance: %w", err)
+	}
+	if _, err := op.Wait(ctx); err != nil && status.Code(err) != codes.AlreadyExists {
+		return fmt.Errorf("op.Wait: %w", err)
+	}
+	return nil
+}
+
+func createDatabase(ctx context.Context, databaseID string) error {
+	ddlStatements, err := loadDDLStatements()
+	if err != nil {
+		return err
+	}
+
+	databaseAdminClient, err := database.NewDatabaseAdminClient(ctx)
+	if err != nil {
+		return fmt.Errorf("database.NewDatabaseAdminClient: %w", err)
+	}
+	defer databaseAdminClient.Close()
+
+	op, err := databaseAdminClient.CreateDatabase(ctx, &databasepb.CreateDatabaseRequest{
+		Parent:          fmt.Sprintf("projects/%s/instances/%s", emulatorProjectID, emulatorInstanceID),
+		CreateStatement: fmt.Sprintf("CREATE DATABASE `%s`", databaseID),
+		ExtraStatements: ddlStatements,
+	})
+	if err != nil {
+		return fmt.Errorf("databaseAdminClient.CreateDatabase: %w", err)
+	}
+	if _, err := op.Wait(ctx); err != nil {
+		return fmt.Errorf("op.Wait: %w", err)
+	}
+	return nil
+}
+
+// loadDDLStatements loads the statements in defs/spanner/ddl/schema.sql
+func loadDDLStatements() ([]string, error) {
+	_, file, _, _ := runtime.Caller(0)
+	schemaPath := filepath.Join(filepath.Dir(file), "../../../../defs/spanner/ddl/schema.sql")
+	schema, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	var ddlStatements []string
+	for _, statement := range strings.Split(string(schema), ";") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			ddlStatements = append(ddlStatements, statement)
+		}
+	}
+	return ddlStatements, nil
+}
@@ -1,6 +1,15 @@
 package main
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
+
+const (
+	fetchModeCrawl   = "crawl"
+	fetchModeRefresh = "refresh"
+)
 
 type config struct {
 	GCPProjectID            string `envconfig:"GCP_PROJECT_ID"`
@@ -9,11 +18,27 @@ type config struct {
 	SpannerInstanceID string `envconfig:"SPANNER_INSTANCE_ID"`
 	SpannerDatabaseID string `envconfig:"SPANNER_DATABASE_ID"`
 
+	// ContinueLastRun resumes the last run from the checkpoints instead of starting a new run
+	// It's only for crawl mode, since refresh mode always starts from the stalest items.
+	ContinueLastRun    bool          `default:"false" envconfig:"CONTINUE_LAST_RUN"`
+	CheckpointInterval time.Duration `default:"30s" envconfig:"CHECKPOINT_INTERVAL"`
+
 	AmazonPartnerTag string `required:"true" envconfig:"AMAZON_PARTNER_TAG"`
 	AmazonAccessKey  string `required:"true" envconfig:"AMAZON_ACCESS_KEY"`
 	AmazonSecretKey  string `required:"true" envconfig:"AMAZON_SECRET_KEY"`
 
+	// AmazonFetchMode is either "crawl" to discover items by browse nodes or "refresh" to update the known items
+	AmazonFetchMode    string `default:"crawl" envconfig:"AMAZON_FETCH_MODE"`
 	AmazonStartGenreID string `envconfig:"AMAZON_START_GENRE_ID"`
+
+	AmazonRefreshStaleDuration time.Duration `default:"24h" envconfig:"AMAZON_REFRESH_STALE_DURATION"`
+	AmazonRefreshMaxItems      int           `default:"50000" envconfig:"AMAZON_REFRESH_MAX_ITEMS"`
+	// AmazonRefreshPopularityDays is the period of the clicks to refresh popular items first
+	AmazonRefreshPopularityDays int `default:"30" envconfig:"AMAZON_REFRESH_POPULARITY_DAYS"`
+
+	// BigQueryEventsTable is the fully qualified table name of tracking events to find popular items
+	// Items are refreshed only in order of staleness when not set.
+	BigQueryEventsTable string `envconfig:"BIGQUERY_EVENTS_TABLE"`
 }
 
 func newConfig() (*config, error) {
@@ -6,9 +6,12 @@ import (
 	"os/signal"
 	"syscall"
 
+	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/pubsub"
 	"cloud.google.com/go/spanner"
 	"github.com/blendle/zapdriver"
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
+	"github.com/k-yomo/kagu-miru/backend/item_fetcher"
 	"github.com/k-yomo/kagu-miru/backend/pkg/amazon"
 	"github.com/k-yomo/kagu-miru/backend/pkg/spannerutil"
 	"go.uber.org/zap"
@@ -40,23 +43,65 @@ func main() {
 		logger.Fatal("failed to initialize spanner client", zap.Error(err))
 	}
 
-	amazonIchibaClient, err := amazon.NewClient(cfg.AmazonPartnerTag, cfg.AmazonAccessKey, cfg.AmazonSecretKey)
+	quotaLedger := quota.NewSpannerLedger(spannerClient, quota.MarketplaceAmazon, amazon.MaxAPICallPerDay)
+	amazonIchibaClient, err := amazon.NewClient(cfg.AmazonPartnerTag, cfg.AmazonAccessKey, cfg.AmazonSecretKey, quotaLedger)
 	if err != nil {
 		logger.Fatal("failed to initialize amazon api client", zap.Error(err))
 	}
-	amazonItemWorker := newWorker(pubsubItemUpdateTopic, spannerClient, amazonIchibaClient, logger)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	doneCh := make(chan struct{}, 1)
 	go func() {
-		logger.Info("amazonItemWorker started running")
-		if err := amazonItemWorker.run(ctx, &amazonWorkerOption{
-			StartBrowseNodeID: cfg.AmazonStartGenreID,
-		}); err != nil {
-			logger.Error("amazonItemWorker failed", zap.Error(err))
+		switch cfg.AmazonFetchMode {
+		case fetchModeCrawl:
+			amazonSource, err := newAmazonSource(ctx, spannerClient, amazonIchibaClient)
+			if err != nil {
+				logger.Error("failed to initialize amazon source", zap.Error(err))
+				break
+			}
+			checkpointer, err := item_fetcher.NewCheckpointer(ctx, spannerClient, amazonSource.Name(), cfg.ContinueLastRun, cfg.CheckpointInterval)
+			if err != nil {
+				logger.Error("failed to initialize checkpointer", zap.Error(err))
+				break
+			}
+			logger.Info("checkpointer initialized", zap.String("runID", checkpointer.RunID()), zap.Bool("continueLastRun", cfg.ContinueLastRun))
+			runtime := item_fetcher.NewRuntime(amazonSource, pubsubItemUpdateTopic, checkpointer, &item_fetcher.RuntimeOption{
+				// PA-API is rate limited to 1 request per second for the account
+				Concurrency: 1,
+				RateLimit:   1,
+				MaxRetries:  5,
+			}, logger)
+			logger.Info("amazon item fetcher started running")
+			if err := runtime.Run(ctx, &item_fetcher.RunOption{
+				StartUnitID: cfg.AmazonStartGenreID,
+			}); err != nil {
+				logger.Error("amazon item fetcher failed", zap.Error(err))
+			}
+		case fetchModeRefresh:
+			var popularFetcher *popularItemFetcher
+			if cfg.BigQueryEventsTable != "" {
+				bqClient, err := bigquery.NewClient(ctx, cfg.GCPProjectID)
+				if err != nil {
+					logger.Error("failed to initialize bigquery client", zap.Error(err))
+					break
+				}
+				popularFetcher = newPopularItemFetcher(bqClient, cfg.BigQueryEventsTable)
+			}
+			amazonItemRefresher := newRefresher(pubsubItemUpdateTopic, spannerClient, amazonIchibaClient, popularFetcher, logger)
+			logger.Info("amazonItemRefresher started running")
+			if err := amazonItemRefresher.run(ctx, &refresherOption{
+				StaleDuration:  cfg.AmazonRefreshStaleDuration,
+				MaxItems:       cfg.AmazonRefreshMaxItems,
+				PopularityDays: cfg.AmazonRefreshPopularityDays,
+			}); err != nil {
+				logger.Error("amazonItemRefresher failed", zap.Error(err))
+			}
+		default:
+			logger.Error("unknown fetch mode", zap.String("mode", cfg.AmazonFetchMode))
 		}
+		item_fetcher.LogQuotaUsages(context.Background(), quotaLedger, logger)
 		doneCh <- struct{}{}
 	}()
 
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"google.golang.org/api/iterator"
+)
+
+// popularItemFetcher fetches the items clicked by users to refresh them first
+// Ratings and review counts can't be used as the popularity since they are always 0 for amazon items.
+type popularItemFetcher struct {
+	bqClient *bigquery.Client
+	// eventsTable is the fully qualified table name of tracking events e.g. "project.dataset.events"
+	eventsTable string
+}
+
+func newPopularItemFetcher(bqClient *bigquery.Client, eventsTable string) *popularItemFetcher {
+	return &popularItemFetcher{
+		bqClient:    bqClient,
+		eventsTable: eventsTable,
+	}
+}
+
+// fetchPopularItemIDs returns the ids of the items of the platform in descending order of the clicks in the period
+func (p *popularItemFetcher) fetchPopularItemIDs(ctx context.Context, platform xitem.Platform, days int, limit int) ([]string, error) {
+	q := p.bqClient.Query(fmt.Sprintf(`
+SELECT JSON_VALUE(params, '$.itemId') AS item_id, COUNT(*) AS clicks
+FROM %s
+WHERE action = 'CLICK_ITEM' AND created_at >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL @days DAY)
+	AND STARTS_WITH(JSON_VALUE(params, '$.itemId'), @item_id_prefix)
+GROUP BY item_id
+ORDER BY clicks DESC, item_id
+LIMIT @limit
+`, fmt.Sprintf("`%s`", p.eventsTable)))
+	q.Parameters = []bigquery.QueryParameter{
+		{Name: "days", Value: days},
+		{Name: "item_id_prefix", Value: xitem.ItemUniqueID(platform, "")},
+		{Name: "limit", Value: limit},
+	}
+	iter, err := q.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query.Read: %w", err)
+	}
+
+	var itemIDs []string
+	for {
+		var row struct {
+			ItemID string `bigquery:"item_id"`
+			Clicks int64  `bigquery:"clicks"`
+		}
+		err := iter.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("iter.Next: %w", err)
+		}
+		itemIDs = append(itemIDs, row.ItemID)
+	}
+	return itemIDs, nil
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/k-yomo/kagu-miru/backend/item_fetcher"
+	"github.com/k-yomo/kagu-miru/backend/pkg/amazon"
+	"go.uber.org/zap"
+)
+
+// refresher refreshes price and availability of the already indexed amazon items
+// Unlike the browse node crawling, it doesn't discover new items, but it costs only 1 api call per 10 items.
+type refresher struct {
+	pubsubItemUpdateTopic *pubsub.Topic
+	spannerClient         *spanner.Client
+	amazonAPIClient       *amazon.Client
+	// popularItemFetcher is nil when the tracking events are not available, then items are refreshed in order of staleness
+	popularItemFetcher *popularItemFetcher
+	logger             *zap.Logger
+}
+
+func newRefresher(pubsubItemUpdateTopic *pubsub.Topic, spannerClient *spanner.Client, amazonAPIClient *amazon.Client, popularItemFetcher *popularItemFetcher, logger *zap.Logger) *refresher {
+	return &refresher{
+		pubsubItemUpdateTopic: pubsubItemUpdateTopic,
+		spannerClient:         spannerClient,
+		amazonAPIClient:       amazonAPIClient,
+		popularItemFetcher:    popularItemFetcher,
+		logger:                logger,
+	}
+}
+
+type refresherOption struct {
+	// StaleDuration is the duration since the last update to refresh the item
+	StaleDuration time.Duration
+	MaxItems      int
+	// PopularityDays is the period of the clicks to find popular items
+	PopularityDays int
+}
+
+func (r *refresher) run(ctx context.Context, option *refresherOption) error {
+	var popularItemIDs []string
+	if r.popularItemFetcher != nil {
+		var err error
+		popularItemIDs, err = r.popularItemFetcher.fetchPopularItemIDs(ctx, xitem.PlatformAmazon, option.PopularityDays, option.MaxItems)
+		if err != nil {
+			// items can be still refreshed in order of staleness
+			r.logger.Error("popularItemFetcher.fetchPopularItemIDs failed", zap.Error(err))
+		}
+	}
+
+	staleItems, err := xspanner.GetStaleItemsByPlatform(ctx, r.spannerClient, xitem.PlatformAmazon, time.Now().Add(-option.StaleDuration), popularItemIDs, option.MaxItems)
+	if err != nil {
+		return fmt.Errorf("xspanner.GetStaleItemsByPlatform: %w", err)
+	}
+
+	itemCategoriesWithParent, err := xspanner.GetAllActiveItemCategoriesWithParent(ctx, r.spannerClient)
+	if err != nil {
+		return fmt.Errorf("xspanner.GetAllActiveItemCategoriesWithParent: %w", err)
+	}
+	itemCategoryMap := make(map[string]*xspanner.ItemCategoryWithParent)
+	for _, itemCategory := range itemCategoriesWithParent {
+		itemCategoryMap[itemCategory.ID] = itemCategory
+	}
+
+	// the item category is kept as it is, since the browse node of the item could be changed
+	asinStaleItemMap := make(map[string]*xspanner.Item, len(staleItems))
+	asins := make([]string, 0, len(staleItems))
+	for _, item := range staleItems {
+		asin := strings.TrimPrefix(item.ID, xitem.ItemUniqueID(xitem.PlatformAmazon, ""))
+		asinStaleItemMap[asin] = item
+		asins = append(asins, asin)
+	}
+
+	r.logger.Info(fmt.Sprintf("[start] refreshing %d items", len(asins)))
+
+	totalPublishedCount := 0
+	for i := 0; i < len(asins); i += amazon.MaxGetItemsCount {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		end := i + amazon.MaxGetItemsCount
+		if end > len(asins) {
+			end = len(asins)
+		}
+		amazonItems, err := r.amazonAPIClient.GetItems(ctx, asins[i:end])
+		if err == amazon.TransactionPerDayExhausted {
+			r.logger.Warn("TPD is exhausted, stop refreshing", zap.Int("refreshedCount", i))
+			break
+		}
+		if err != nil {
+			// some items could be got even if the error is returned
+			r.logger.Error("amazonAPIClient.GetItems failed", zap.Error(err), zap.Strings("asins", asins[i:end]))
+		}
+
+		items := make([]*xitem.Item, 0, end-i)
+		gotASINMap := make(map[string]bool, len(amazonItems))
+		for _, amazonItem := range amazonItems {
+			gotASINMap[amazonItem.ASIN] = true
+			if amazonItem.ItemInfo.ProductInfo.IsAdultProduct.DisplayValue {
+				continue
+			}
+			staleItem, ok := asinStaleItemMap[amazonItem.ASIN]
+			if !ok {
+				continue
+			}
+			itemCategory, ok := itemCategoryMap[staleItem.CategoryID]
+			if !ok {
+				continue
+			}
+			item, err := mapAmazonItemToIndexItem(amazonItem, itemCategory)
+			if err != nil {
+				r.logger.Error("mapAmazonItemToIndexItem failed", zap.Error(err))
+				continue
+			}
+			items = append(items, item)
+		}
+		items = append(items, getInactiveItems(asins[i:end], gotASINMap, asinStaleItemMap, err)...)
+
+		totalPublishedCount += item_fetcher.PublishItems(ctx, r.pubsubItemUpdateTopic, items, r.logger)
+	}
+
+	r.logger.Info(fmt.Sprintf("[end] refreshing %d items", len(asins)), zap.Int("total", totalPublishedCount))
+	return nil
+}
+
+// getInactiveItems returns the items missing in the result of GetItems as inactive
+// The items missing in the result are no longer sold, but they can be missing also when the request failed,
+// so they are deactivated only when all the errors are ErrItemNotAccessible.
+func getInactiveItems(asins []string, gotASINMap map[string]bool, asinStaleItemMap map[string]*xspanner.Item, getItemsErr error) []*xitem.Item {
+	if !amazon.IsOnlyItemNotAccessible(getItemsErr) {
+		return nil
+	}
+	var items []*xitem.Item
+	for _, asin := range asins {
+		if !gotASINMap[asin] {
+			items = append(items, mapSpannerItemToInactiveItem(asinStaleItemMap[asin]))
+		}
+	}
+	return items
+}
+
+// mapSpannerItemToInactiveItem maps the stored item to the inactive item to update only the status
+func mapSpannerItemToInactiveItem(item *xspanner.Item) *xitem.Item {
+	return &xitem.Item{
+		ID:            item.ID,
+		Name:          item.Name,
+		Description:   item.Description,
+		Status:        xitem.StatusInactive,
+		URL:           item.URL,
+		AffiliateURL:  item.AffiliateURL,
+		Price:         int(item.Price),
+		ImageURLs:     item.ImageURLs,
+		AverageRating: item.AverageRating,
+		ReviewCount:   int(item.ReviewCount),
+		CategoryID:    item.CategoryID,
+		BrandName:     item.BrandName.StringVal,
+		Colors:        item.Colors,
+		WidthRange:    mapSpannerRangeToIntRange(item.WidthRange),
+		DepthRange:    mapSpannerRangeToIntRange(item.DepthRange),
+		HeightRange:   mapSpannerRangeToIntRange(item.HeightRange),
+		JANCode:       item.JANCode.StringVal,
+		Platform:      item.Platform,
+	}
+}
+
+func mapSpannerRangeToIntRange(r []int64) *xitem.IntRange {
+	if len(r) != 2 {
+		return nil
+	}
+	return &xitem.IntRange{Gte: int(r[0]), Lte: int(r[1])}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/k-yomo/kagu-miru/backend/pkg/amazon"
+	"go.uber.org/multierr"
+)
+
+func Test_getInactiveItems(t *testing.T) {
+	t.Parallel()
+
+	asins := []string{"B000000001", "B000000002", "B000000003"}
+	asinStaleItemMap := make(map[string]*xspanner.Item, len(asins))
+	for _, asin := range asins {
+		asinStaleItemMap[asin] = &xspanner.Item{
+			ID:       xitem.ItemUniqueID(xitem.PlatformAmazon, asin),
+			Name:     asin,
+			Status:   int64(xitem.StatusActive),
+			Price:    1000,
+			Platform: xitem.PlatformAmazon,
+		}
+	}
+	gotASINMap := map[string]bool{"B000000001": true}
+
+	tests := []struct {
+		name        string
+		getItemsErr error
+		wantItemIDs []string
+	}{
+		{
+			name:        "deactivates the missing items without errors",
+			getItemsErr: nil,
+			wantItemIDs: []string{xitem.ItemUniqueID(xitem.PlatformAmazon, "B000000002"), xitem.ItemUniqueID(xitem.PlatformAmazon, "B000000003")},
+		},
+		{
+			name: "deactivates the missing items when every error is item not accessible",
+			getItemsErr: multierr.Combine(
+				fmt.Errorf("%w: B000000002", amazon.ErrItemNotAccessible),
+				fmt.Errorf("%w: B000000003", amazon.ErrItemNotAccessible),
+			),
+			wantItemIDs: []string{xitem.ItemUniqueID(xitem.PlatformAmazon, "B000000002"), xitem.ItemUniqueID(xitem.PlatformAmazon, "B000000003")},
+		},
+		{
+			name: "doesn't deactivate the missing items when any error is not item not accessible",
+			getItemsErr: multierr.Combine(
+				fmt.Errorf("%w: B000000002", amazon.ErrItemNotAccessible),
+				errors.New("type: ERROR, code: InternalFailure, message: internal failure"),
+			),
+			wantItemIDs: nil,
+		},
+		{
+			name:        "doesn't deactivate the missing items when the request failed",
+			getItemsErr: errors.New("request failed"),
+			wantItemIDs: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := getInactiveItems(asins, gotASINMap, asinStaleItemMap, tt.getItemsErr)
+			var gotItemIDs []string
+			for _, item := range got {
+				if item.Status != xitem.StatusInactive {
+					t.Errorf("status of %s = %v, want %v", item.ID, item.Status, xitem.StatusInactive)
+				}
+				gotItemIDs = append(gotItemIDs, item.ID)
+			}
+			if diff := cmp.Diff(tt.wantItemIDs, gotItemIDs); diff != "" {
+				t.Errorf("getInactiveItems(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
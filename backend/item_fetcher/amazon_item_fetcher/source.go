@@ -6,10 +6,8 @@ import (
 	"fmt"
 	"math"
 	"sort"
-	"sync"
-	"sync/atomic"
+	"strings"
 
-	"cloud.google.com/go/pubsub"
 	"cloud.google.com/go/spanner"
 	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
 	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
@@ -17,74 +15,36 @@ import (
 	"github.com/k-yomo/kagu-miru/backend/pkg/amazon"
 	"github.com/utekaravinash/gopaapi5/api"
 	"go.uber.org/multierr"
-	"go.uber.org/zap"
-	"golang.org/x/time/rate"
 )
 
-type browseNodeItemsFetcher struct {
-	pubsubItemUpdateTopic *pubsub.Topic
-	amazonAPIClient       *amazon.Client
-
-	wg           *sync.WaitGroup
-	pool         chan<- *browseNodeItemsFetcher
-	browseNodeID chan string
-
-	browseNodeIDItemCategoryMap map[string]*xspanner.ItemCategoryWithParent
-
-	logger *zap.Logger
-}
-
-type worker struct {
-	amazonAPIClient *amazon.Client
+// amazonSource fetches items in the top level browse nodes from Amazon
+type amazonSource struct {
 	spannerClient   *spanner.Client
-	pool            <-chan *browseNodeItemsFetcher
-	workers         []*browseNodeItemsFetcher
-	logger          *zap.Logger
+	amazonAPIClient *amazon.Client
 
-	wg *sync.WaitGroup
+	browseNodeIDItemCategoryMap map[string]*xspanner.ItemCategoryWithParent
 }
 
-func newWorker(pubsubItemUpdateTopic *pubsub.Topic, spannerClient *spanner.Client, amazonAPIClient *amazon.Client, logger *zap.Logger) *worker {
-	wg := &sync.WaitGroup{}
-	pool := make(chan *browseNodeItemsFetcher, 1)
-	workers := make([]*browseNodeItemsFetcher, 0, cap(pool))
-	for i := 0; i < cap(pool); i++ {
-		workers = append(workers, &browseNodeItemsFetcher{
-			pubsubItemUpdateTopic: pubsubItemUpdateTopic,
-			amazonAPIClient:       amazonAPIClient,
-			wg:                    wg,
-			pool:                  pool,
-			browseNodeID:          make(chan string),
-			logger:                logger,
-		})
-	}
-	return &worker{
-		spannerClient:   spannerClient,
-		amazonAPIClient: amazonAPIClient,
-		wg:              wg,
-		pool:            pool,
-		workers:         workers,
-		logger:          logger,
+func newAmazonSource(ctx context.Context, spannerClient *spanner.Client, amazonAPIClient *amazon.Client) (*amazonSource, error) {
+	browseNodeIDItemCategoryMap, err := getBrowseNodeIDItemCategoryMap(ctx, spannerClient)
+	if err != nil {
+		return nil, fmt.Errorf("getBrowseNodeIDItemCategoryMap: %w", err)
 	}
+	return &amazonSource{
+		spannerClient:               spannerClient,
+		amazonAPIClient:             amazonAPIClient,
+		browseNodeIDItemCategoryMap: browseNodeIDItemCategoryMap,
+	}, nil
 }
 
-type amazonWorkerOption struct {
-	StartBrowseNodeID string
+func (s *amazonSource) Name() string {
+	return "amazon_item_fetcher"
 }
 
-func (r *worker) run(ctx context.Context, option *amazonWorkerOption) error {
-	browseNodeIDItemCategoryMap, err := r.getBrowseNodeIDItemCategoryMap(ctx)
+func (s *amazonSource) Units(ctx context.Context) ([]string, error) {
+	amazonBrowseNodes, err := xspanner.GetAllAmazonBrowseNodes(ctx, s.spannerClient)
 	if err != nil {
-		return fmt.Errorf("getBrowseNodeIDItemCategoryMap: %w", err)
-	}
-	for _, w := range r.workers {
-		w.browseNodeIDItemCategoryMap = browseNodeIDItemCategoryMap
-		w.start(ctx)
-	}
-
-	amazonBrowseNodes, err := xspanner.GetAllAmazonBrowseNodes(ctx, r.spannerClient)
-	if err != nil {
-		return fmt.Errorf("xspanner.GetAllAmazonItemBrowseNodes: %w", err)
+		return nil, fmt.Errorf("xspanner.GetAllAmazonItemBrowseNodes: %w", err)
 	}
 
 	// TODO: use bottom level browse node to narrow down the search result for each search
@@ -97,31 +57,53 @@ func (r *worker) run(ctx context.Context, option *amazonWorkerOption) error {
 	sort.Slice(fetchBrowseNodes, func(i, j int) bool {
 		return fetchBrowseNodes[i] < fetchBrowseNodes[j]
 	})
+	return fetchBrowseNodes, nil
+}
 
-	startBrowseNodeIdx := 0
-	if option.StartBrowseNodeID != "" {
-		for i, browseNodeID := range fetchBrowseNodes {
-			if browseNodeID == option.StartBrowseNodeID {
-				startBrowseNodeIdx = i
-				break
-			}
+// We traverse all items in the given browseNode with following way
+// due to Amazon Ichiba API limitation(max 30 items at once, 100 page for the given condition)
+// 1. get items in price ascending order
+// 2. when we reach 100th page, set the last item's price to `minPrice` and fetch more 100 pages
+// 3. when we get 0 items, it means we reached the end.
+// Ideally, we want to refetch only updated items since we need to do full-reindex with the current approach.
+// But currently we don't have a way to get item's updated time(API doesn't return it) and set `from` parameter for search
+func (s *amazonSource) NewCursor(unitID string, state json.RawMessage) (item_fetcher.Cursor, error) {
+	if state != nil {
+		var cursorState amazon.BrowseNodeItemCursorState
+		if err := json.Unmarshal(state, &cursorState); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
 		}
+		return &browseNodeCursor{source: s, cursor: s.amazonAPIClient.NewBrowseNodeItemCursorFromState(&cursorState)}, nil
 	}
 
-	r.logger.Info(fmt.Sprintf("[start] fetching %d browseNode", len(fetchBrowseNodes[startBrowseNodeIdx:])))
+	cursor := s.amazonAPIClient.NewBrowseNodeItemCursor(unitID, item_fetcher.MinFetchItemPrice, item_fetcher.MaxFetchItemPrice)
+	return &browseNodeCursor{source: s, cursor: cursor}, nil
+}
 
-	for _, browseNodeID := range fetchBrowseNodes[startBrowseNodeIdx:] {
-		r.wg.Add(1)
-		(<-r.pool).browseNodeID <- browseNodeID
+type browseNodeCursor struct {
+	source *amazonSource
+	cursor *amazon.BrowseNodeItemCursor
+}
+
+func (c *browseNodeCursor) Next(ctx context.Context) (*item_fetcher.Page, error) {
+	amazonItems, err := c.cursor.Next(ctx)
+	if err == amazon.Done {
+		return nil, item_fetcher.Done
+	}
+	if err != nil {
+		return nil, err
 	}
-	r.wg.Wait()
 
-	r.logger.Info(fmt.Sprintf("[end] fetching %d browseNode", len(fetchBrowseNodes[startBrowseNodeIdx:])))
-	return nil
+	items, err := mapAmazonItemsToIndexItems(amazonItems, c.source.browseNodeIDItemCategoryMap)
+	return &item_fetcher.Page{Items: items, MappingErr: err}, nil
 }
 
-func (r *worker) getBrowseNodeIDItemCategoryMap(ctx context.Context) (map[string]*xspanner.ItemCategoryWithParent, error) {
-	amazonItemBrowseNodes, err := xspanner.GetAllAmazonBrowseNodes(ctx, r.spannerClient)
+func (c *browseNodeCursor) State() interface{} {
+	return c.cursor.State()
+}
+
+func getBrowseNodeIDItemCategoryMap(ctx context.Context, spannerClient *spanner.Client) (map[string]*xspanner.ItemCategoryWithParent, error) {
+	amazonItemBrowseNodes, err := xspanner.GetAllAmazonBrowseNodes(ctx, spannerClient)
 	if err != nil {
 		return nil, fmt.Errorf("xspanner.GetAllAmazonItemBrowseNodes: %w", err)
 	}
@@ -130,7 +112,7 @@ func (r *worker) getBrowseNodeIDItemCategoryMap(ctx context.Context) (map[string
 		browseNodeIDItemCategoryIDMap[browseNode.ID] = browseNode.ItemCategoryID
 	}
 
-	itemCategoriesWithParent, err := xspanner.GetAllActiveItemCategoriesWithParent(ctx, r.spannerClient)
+	itemCategoriesWithParent, err := xspanner.GetAllActiveItemCategoriesWithParent(ctx, spannerClient)
 	if err != nil {
 		return nil, fmt.Errorf("xspanner.GetAllActiveItemCategoriesWithParent: %w", err)
 	}
@@ -149,113 +131,6 @@ func (r *worker) getBrowseNodeIDItemCategoryMap(ctx context.Context) (map[string
 	return browseNodeIDItemCategoryMap, nil
 }
 
-// We traverse all items in the given browseNode with following way
-// due to Amazon Ichiba API limitation(max 30 items at once, 100 page for the given condition)
-// 1. get items in price ascending order
-// 2. when we reach 100th page, set the last item's price to `minPrice` and fetch more 100 pages
-// 3. when we get 0 items, it means we reached the end.
-// Ideally, we want to refetch only updated items since we need to do full-reindex with the current approach.
-// But currently we don't have a way to get item's updated time(API doesn't return it) and set `from` parameter for search
-func (w *browseNodeItemsFetcher) start(ctx context.Context) {
-	rateLimiter := rate.NewLimiter(1, 1)
-
-	go func() {
-		for {
-			w.pool <- w
-
-			select {
-			case <-ctx.Done():
-				return
-			case browseNodeID := <-w.browseNodeID:
-
-				totalPublishedCount := 0
-				cursor := w.amazonAPIClient.NewBrowseNodeItemCursor(browseNodeID, item_fetcher.MinFetchItemPrice, item_fetcher.MaxFetchItemPrice)
-				for {
-					if err := rateLimiter.Wait(ctx); err != nil {
-						w.logger.Error("rateLimiter.Wait failed", zap.Error(err))
-					}
-
-					amazonItems, err := cursor.Next(ctx)
-					if err == amazon.Done {
-						w.logger.Info(fmt.Sprintf(
-							"fetched all amazonItems in browseNode %s", browseNodeID),
-							zap.String("browseNodeID", browseNodeID),
-							zap.Int("total", totalPublishedCount),
-						)
-						break
-					}
-					if err != nil {
-						w.logger.Error("cursor.Next failed",
-							zap.Error(err),
-							zap.String("browseNodeID", browseNodeID),
-							zap.Int("minPrice", cursor.CurMinPrice()),
-							zap.Int("page", cursor.CurPage()),
-						)
-						break
-					}
-
-					items, err := mapAmazonItemsToIndexItems(amazonItems, w.browseNodeIDItemCategoryMap)
-					if err != nil {
-						w.logger.Error(
-							"mapAmazonItemsToIndexItems failed for some amazonItems",
-							zap.Error(err),
-							zap.Int("totalCount", len(items)),
-						)
-					}
-
-					wg := sync.WaitGroup{}
-					var publishedCount int64
-					for _, item := range items {
-						if !item.IsIndexable() {
-							continue
-						}
-
-						item := item
-						wg.Add(1)
-						go func() {
-							defer wg.Done()
-
-							itemJSON, err := json.Marshal(item)
-							if err != nil {
-								w.logger.Error(
-									"json.Marshal item failed",
-									zap.Error(err),
-									zap.Any("item", item),
-								)
-							}
-							res := w.pubsubItemUpdateTopic.Publish(ctx, &pubsub.Message{
-								Data:        itemJSON,
-								OrderingKey: item_fetcher.ItemOrderingKey(item),
-							})
-							if _, err := res.Get(ctx); err != nil {
-								w.logger.Error("publish item update failed",
-									zap.Error(err),
-									zap.String("itemId", item.ID),
-								)
-								return
-							}
-							atomic.AddInt64(&publishedCount, 1)
-						}()
-					}
-					wg.Wait()
-
-					totalPublishedCount += int(publishedCount)
-					if totalPublishedCount%300 == 0 {
-						w.logger.Info(fmt.Sprintf(
-							"published %d items", totalPublishedCount),
-							zap.String("browseNodeID", browseNodeID),
-							zap.Int("minPrice", cursor.CurMinPrice()),
-							zap.Int("page", cursor.CurPage()),
-						)
-					}
-				}
-
-				w.wg.Done()
-			}
-		}
-	}()
-}
-
 func mapAmazonItemsToIndexItems(
 	amazonItems []api.Item,
 	browseNodeIDItemCategoryMap map[string]*xspanner.ItemCategoryWithParent,
@@ -285,6 +160,9 @@ func mapAmazonItemToIndexItem(
 	amazonItem api.Item,
 	itemCategory *xspanner.ItemCategoryWithParent,
 ) (*xitem.Item, error) {
+	if len(amazonItem.Offers.Listings) == 0 {
+		return nil, fmt.Errorf("no offer listing, item ASIN: %v", amazonItem.ASIN)
+	}
 	listing := amazonItem.Offers.Listings[0]
 	var status xitem.Status
 	switch listing.Availability.Type {
@@ -310,7 +188,8 @@ func mapAmazonItemToIndexItem(
 	return &xitem.Item{
 		ID:   xitem.ItemUniqueID(xitem.PlatformAmazon, amazonItem.ASIN),
 		Name: amazonItem.ItemInfo.Title.DisplayValue,
-		// Description:   amazonItem.ItemInfo.ProductInfo.,
+		// description is not available in PA-API5, so the feature bullet points are used instead
+		Description:  strings.Join(amazonItem.ItemInfo.Features.DisplayValues, "\n"),
 		Status:       status,
 		URL:          amazonItem.DetailPageURL,
 		AffiliateURL: amazonItem.DetailPageURL,
@@ -328,6 +207,8 @@ func mapAmazonItemToIndexItem(
 		DepthRange:    mapLengthToItemIntRange(amazonItem.ItemInfo.ProductInfo.ItemDimensions.Length),
 		HeightRange:   mapLengthToItemIntRange(amazonItem.ItemInfo.ProductInfo.ItemDimensions.Height),
 		// JANCode:       janCode,
+		ShopID:   xitem.ShopUniqueID(xitem.PlatformAmazon, listing.MerchantInfo.Id),
+		ShopName: listing.MerchantInfo.Name,
 		Platform: xitem.PlatformAmazon,
 	}, nil
 }
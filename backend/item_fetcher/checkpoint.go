@@ -0,0 +1,114 @@
+package item_fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/k-yomo/kagu-miru/backend/pkg/uuid"
+)
+
+// Checkpointer saves the cursor state of each crawl unit (e.g. genre) periodically
+// so that the crawl can be resumed from where it stopped after a crash or redeploy.
+type Checkpointer struct {
+	spannerClient *spanner.Client
+	fetcherName   string
+	runID         string
+	interval      time.Duration
+
+	mu          sync.Mutex
+	cursorState map[string]string
+	lastSavedAt map[string]time.Time
+}
+
+// NewCheckpointer returns the checkpointer for the new run
+// When continueLastRun is true, the checkpoints of the last run are loaded to resume the run.
+func NewCheckpointer(ctx context.Context, spannerClient *spanner.Client, fetcherName string, continueLastRun bool, interval time.Duration) (*Checkpointer, error) {
+	c := &Checkpointer{
+		spannerClient: spannerClient,
+		fetcherName:   fetcherName,
+		runID:         uuid.UUID(),
+		interval:      interval,
+		cursorState:   make(map[string]string),
+		lastSavedAt:   make(map[string]time.Time),
+	}
+	if !continueLastRun {
+		return c, nil
+	}
+
+	lastRunID, err := xspanner.GetLastItemFetcherRunID(ctx, spannerClient, fetcherName)
+	if err != nil {
+		return nil, fmt.Errorf("xspanner.GetLastItemFetcherRunID: %w", err)
+	}
+	if lastRunID == "" {
+		return c, nil
+	}
+	checkpoints, err := xspanner.GetItemFetcherCheckpointsByRunID(ctx, spannerClient, fetcherName, lastRunID)
+	if err != nil {
+		return nil, fmt.Errorf("xspanner.GetItemFetcherCheckpointsByRunID: %w", err)
+	}
+	c.runID = lastRunID
+	for _, checkpoint := range checkpoints {
+		c.cursorState[checkpoint.UnitID] = checkpoint.CursorState
+	}
+	return c, nil
+}
+
+// RunID returns the id of the run, which is the same as the last run when continuing it
+func (c *Checkpointer) RunID() string {
+	return c.runID
+}
+
+// CursorState returns the saved cursor state of the unit
+// false is returned when the unit has no checkpoint in the run.
+func (c *Checkpointer) CursorState(unitID string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cursorState, ok := c.cursorState[unitID]
+	if !ok {
+		return nil, false
+	}
+	return json.RawMessage(cursorState), true
+}
+
+// SaveIfDue saves the cursor state of the unit when the interval has passed since the last save
+func (c *Checkpointer) SaveIfDue(ctx context.Context, unitID string, state interface{}) error {
+	c.mu.Lock()
+	lastSavedAt := c.lastSavedAt[unitID]
+	c.mu.Unlock()
+	if time.Since(lastSavedAt) < c.interval {
+		return nil
+	}
+	return c.Save(ctx, unitID, state)
+}
+
+// Save saves the cursor state of the unit
+// It should be called after the fetched items are published, otherwise the items could be lost on resume.
+func (c *Checkpointer) Save(ctx context.Context, unitID string, state interface{}) error {
+	cursorState, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	now := time.Now()
+	err = xspanner.SaveItemFetcherCheckpoint(ctx, c.spannerClient, &xspanner.ItemFetcherCheckpoint{
+		FetcherName: c.fetcherName,
+		UnitID:      unitID,
+		RunID:       c.runID,
+		CursorState: string(cursorState),
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		return fmt.Errorf("xspanner.SaveItemFetcherCheckpoint: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cursorState[unitID] = string(cursorState)
+	c.lastSavedAt[unitID] = now
+	return nil
+}
@@ -0,0 +1,144 @@
+package item_fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner/xspannertest"
+)
+
+func TestCheckpointer(t *testing.T) {
+	t.Parallel()
+
+	spannerClient := xspannertest.NewEmulatorClient(t)
+
+	t.Run("starts the new run without continuing the last run", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		lastRun, err := NewCheckpointer(ctx, spannerClient, "new_run", false, 0)
+		if err != nil {
+			t.Fatalf("NewCheckpointer() error = %v", err)
+		}
+		if err := lastRun.Save(ctx, "1", 2); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		c, err := NewCheckpointer(ctx, spannerClient, "new_run", false, 0)
+		if err != nil {
+			t.Fatalf("NewCheckpointer() error = %v", err)
+		}
+		if c.RunID() == lastRun.RunID() {
+			t.Errorf("RunID() = %s, want the new run id", c.RunID())
+		}
+		if state, ok := c.CursorState("1"); ok {
+			t.Errorf("CursorState() = %s, want no state", state)
+		}
+	})
+
+	t.Run("starts the new run when the last run doesn't exist", func(t *testing.T) {
+		t.Parallel()
+
+		c, err := NewCheckpointer(context.Background(), spannerClient, "no_last_run", true, 0)
+		if err != nil {
+			t.Fatalf("NewCheckpointer() error = %v", err)
+		}
+		if c.RunID() == "" {
+			t.Errorf("RunID() is empty")
+		}
+		if state, ok := c.CursorState("1"); ok {
+			t.Errorf("CursorState() = %s, want no state", state)
+		}
+	})
+
+	t.Run("resumes the last run", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		oldRun, err := NewCheckpointer(ctx, spannerClient, "resume", false, 0)
+		if err != nil {
+			t.Fatalf("NewCheckpointer() error = %v", err)
+		}
+		if err := oldRun.Save(ctx, "old_unit", 1); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		lastRun, err := NewCheckpointer(ctx, spannerClient, "resume", false, 0)
+		if err != nil {
+			t.Fatalf("NewCheckpointer() error = %v", err)
+		}
+		if err := lastRun.Save(ctx, "1", map[string]int{"page": 3}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		if err := lastRun.Save(ctx, "2", map[string]int{"page": 5}); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+
+		c, err := NewCheckpointer(ctx, spannerClient, "resume", true, 0)
+		if err != nil {
+			t.Fatalf("NewCheckpointer() error = %v", err)
+		}
+		if c.RunID() != lastRun.RunID() {
+			t.Errorf("RunID() = %s, want the last run id %s", c.RunID(), lastRun.RunID())
+		}
+		wantCursorStates := map[string]json.RawMessage{
+			"1": json.RawMessage(`{"page":3}`),
+			"2": json.RawMessage(`{"page":5}`),
+		}
+		for unitID, want := range wantCursorStates {
+			got, ok := c.CursorState(unitID)
+			if !ok {
+				t.Fatalf("CursorState(%s) is not found", unitID)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("CursorState(%s), (-want +got): %s", unitID, diff)
+			}
+		}
+		// the unit checkpointed only in the older run is fetched from the beginning
+		if state, ok := c.CursorState("old_unit"); ok {
+			t.Errorf("CursorState(old_unit) = %s, want no state", state)
+		}
+	})
+
+	t.Run("saves only when the interval has passed", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		c, err := NewCheckpointer(ctx, spannerClient, "save_if_due", false, time.Hour)
+		if err != nil {
+			t.Fatalf("NewCheckpointer() error = %v", err)
+		}
+		// the first state is saved since the unit has never been saved
+		if err := c.SaveIfDue(ctx, "1", 1); err != nil {
+			t.Fatalf("SaveIfDue() error = %v", err)
+		}
+		if err := c.SaveIfDue(ctx, "1", 2); err != nil {
+			t.Fatalf("SaveIfDue() error = %v", err)
+		}
+		if err := c.SaveIfDue(ctx, "2", 1); err != nil {
+			t.Fatalf("SaveIfDue() error = %v", err)
+		}
+
+		resumed, err := NewCheckpointer(ctx, spannerClient, "save_if_due", true, time.Hour)
+		if err != nil {
+			t.Fatalf("NewCheckpointer() error = %v", err)
+		}
+		for unitID, want := range map[string]json.RawMessage{"1": json.RawMessage("1"), "2": json.RawMessage("1")} {
+			got, _ := resumed.CursorState(unitID)
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("CursorState(%s), (-want +got): %s", unitID, diff)
+			}
+		}
+
+		// Save is not limited by the interval
+		if err := c.Save(ctx, "1", 3); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+		got, _ := c.CursorState("1")
+		if diff := cmp.Diff(json.RawMessage("3"), got); diff != "" {
+			t.Errorf("CursorState(1), (-want +got): %s", diff)
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package item_fetcher
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+// Metrics is the counters of the run
+type Metrics struct {
+	FetchedUnitCount   int64
+	FetchedPageCount   int64
+	FetchedItemCount   int64
+	MappingErrorCount  int64
+	PublishedItemCount int64
+	RetryCount         int64
+	FetchErrorCount    int64
+}
+
+func (m *Metrics) snapshot() Metrics {
+	return Metrics{
+		FetchedUnitCount:   atomic.LoadInt64(&m.FetchedUnitCount),
+		FetchedPageCount:   atomic.LoadInt64(&m.FetchedPageCount),
+		FetchedItemCount:   atomic.LoadInt64(&m.FetchedItemCount),
+		MappingErrorCount:  atomic.LoadInt64(&m.MappingErrorCount),
+		PublishedItemCount: atomic.LoadInt64(&m.PublishedItemCount),
+		RetryCount:         atomic.LoadInt64(&m.RetryCount),
+		FetchErrorCount:    atomic.LoadInt64(&m.FetchErrorCount),
+	}
+}
+
+func (m Metrics) zapFields() []zap.Field {
+	return []zap.Field{
+		zap.Int64("fetchedUnitCount", m.FetchedUnitCount),
+		zap.Int64("fetchedPageCount", m.FetchedPageCount),
+		zap.Int64("fetchedItemCount", m.FetchedItemCount),
+		zap.Int64("mappingErrorCount", m.MappingErrorCount),
+		zap.Int64("publishedItemCount", m.PublishedItemCount),
+		zap.Int64("retryCount", m.RetryCount),
+		zap.Int64("fetchErrorCount", m.FetchErrorCount),
+	}
+}
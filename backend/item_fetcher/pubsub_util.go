@@ -1,6 +1,15 @@
 package item_fetcher
 
-import "github.com/k-yomo/kagu-miru/backend/internal/xitem"
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"go.uber.org/zap"
+)
 
 func ItemOrderingKey(item *xitem.Item) string {
 	orderingKey := item.JANCode
@@ -9,3 +18,45 @@ func ItemOrderingKey(item *xitem.Item) string {
 	}
 	return orderingKey
 }
+
+// PublishItems publishes the indexable items to the item update topic and returns the published count
+func PublishItems(ctx context.Context, topic *pubsub.Topic, items []*xitem.Item, logger *zap.Logger) int {
+	wg := sync.WaitGroup{}
+	var publishedCount int64
+	for _, item := range items {
+		if !item.IsIndexable() {
+			continue
+		}
+
+		item := item
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			itemJSON, err := json.Marshal(item)
+			if err != nil {
+				logger.Error(
+					"json.Marshal item failed",
+					zap.Error(err),
+					zap.Any("item", item),
+				)
+				return
+			}
+			res := topic.Publish(ctx, &pubsub.Message{
+				Data:        itemJSON,
+				OrderingKey: ItemOrderingKey(item),
+			})
+			if _, err := res.Get(ctx); err != nil {
+				logger.Error("publish item update failed",
+					zap.Error(err),
+					zap.String("itemId", item.ID),
+				)
+				return
+			}
+			atomic.AddInt64(&publishedCount, 1)
+		}()
+	}
+	wg.Wait()
+
+	return int(publishedCount)
+}
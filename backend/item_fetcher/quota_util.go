@@ -0,0 +1,24 @@
+package item_fetcher
+
+import (
+	"context"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
+	"go.uber.org/zap"
+)
+
+// LogQuotaUsages logs the api calls and the remaining quota of each credential today
+func LogQuotaUsages(ctx context.Context, quotaLedger quota.Ledger, logger *zap.Logger) {
+	usages, err := quotaLedger.Usages(ctx)
+	if err != nil {
+		logger.Error("quotaLedger.Usages failed", zap.Error(err))
+		return
+	}
+	for _, usage := range usages {
+		logger.Info("api quota usage",
+			zap.String("credentialID", usage.CredentialID),
+			zap.Int("callCount", usage.CallCount),
+			zap.Int("remaining", usage.Remaining),
+		)
+	}
+}
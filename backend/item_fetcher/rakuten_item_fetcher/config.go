@@ -1,6 +1,10 @@
 package main
 
-import "github.com/kelseyhightower/envconfig"
+import (
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+)
 
 type config struct {
 	GCPProjectID            string `envconfig:"GCP_PROJECT_ID"`
@@ -9,6 +13,10 @@ type config struct {
 	SpannerInstanceID string `envconfig:"SPANNER_INSTANCE_ID"`
 	SpannerDatabaseID string `envconfig:"SPANNER_DATABASE_ID"`
 
+	// ContinueLastRun resumes the last run from the checkpoints instead of starting a new run
+	ContinueLastRun    bool          `default:"false" envconfig:"CONTINUE_LAST_RUN"`
+	CheckpointInterval time.Duration `default:"30s" envconfig:"CHECKPOINT_INTERVAL"`
+
 	// To avoid late limit, we use multiple ids
 	RakutenApplicationIDs []string `required:"true" envconfig:"RAKUTEN_APPLICATION_IDS"`
 	RakutenAffiliateID    string   `required:"true" envconfig:"RAKUTEN_AFFILIATE_ID"`
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"github.com/k-yomo/kagu-miru/backend/pkg/spannerutil"
@@ -12,6 +13,8 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	"github.com/blendle/zapdriver"
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
+	"github.com/k-yomo/kagu-miru/backend/item_fetcher"
 	"github.com/k-yomo/kagu-miru/backend/pkg/rakutenichiba"
 	"go.uber.org/zap"
 )
@@ -42,20 +45,39 @@ func main() {
 		logger.Fatal("failed to initialize spanner client", zap.Error(err))
 	}
 
-	rakutenIchibaClient := rakutenichiba.NewClient(cfg.RakutenApplicationIDs, cfg.RakutenAffiliateID)
-	rakutenItemWorker := newWorker(pubsubItemUpdateTopic, spannerClient, rakutenIchibaClient, logger)
+	// Rakuten Ichiba API has no daily limit, but the calls are counted to see the usage
+	quotaLedger := quota.NewSpannerLedger(spannerClient, quota.MarketplaceRakuten, quota.Unlimited)
+	rakutenIchibaClient := rakutenichiba.NewClient(cfg.RakutenApplicationIDs, cfg.RakutenAffiliateID, quotaLedger)
+	rakutenSource, err := newRakutenSource(context.Background(), spannerClient, rakutenIchibaClient)
+	if err != nil {
+		logger.Fatal("failed to initialize rakuten source", zap.Error(err))
+	}
+	checkpointer, err := item_fetcher.NewCheckpointer(context.Background(), spannerClient, rakutenSource.Name(), cfg.ContinueLastRun, cfg.CheckpointInterval)
+	if err != nil {
+		logger.Fatal("failed to initialize checkpointer", zap.Error(err))
+	}
+	logger.Info("checkpointer initialized", zap.String("runID", checkpointer.RunID()), zap.Bool("continueLastRun", cfg.ContinueLastRun))
+	runtime := item_fetcher.NewRuntime(rakutenSource, pubsubItemUpdateTopic, checkpointer, &item_fetcher.RuntimeOption{
+		// each application id is rate limited to 1 request per second
+		Concurrency: rakutenIchibaClient.ApplicationIDNum(),
+		RateLimit:   1,
+		MaxRetries:  5,
+	}, logger)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	doneCh := make(chan struct{}, 1)
 	go func() {
-		logger.Info("rakutenItemWorker started running")
-		if err := rakutenItemWorker.run(ctx, &rakutenWorkerOption{
-			StartGenreID: cfg.RakutenStartGenreID,
-		}); err != nil {
-			logger.Error("rakutenItemWorker failed", zap.Error(err))
+		runOption := &item_fetcher.RunOption{}
+		if cfg.RakutenStartGenreID != 0 {
+			runOption.StartUnitID = strconv.Itoa(cfg.RakutenStartGenreID)
+		}
+		logger.Info("rakuten item fetcher started running")
+		if err := runtime.Run(ctx, runOption); err != nil {
+			logger.Error("rakuten item fetcher failed", zap.Error(err))
 		}
+		item_fetcher.LogQuotaUsages(context.Background(), quotaLedger, logger)
 		doneCh <- struct{}{}
 	}()
 
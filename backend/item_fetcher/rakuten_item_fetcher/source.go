@@ -6,93 +6,52 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
-	"sync"
-	"sync/atomic"
 
 	"github.com/k-yomo/kagu-miru/backend/item_fetcher"
 
 	"cloud.google.com/go/spanner"
 	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
 
-	"cloud.google.com/go/pubsub"
 	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
 	"github.com/k-yomo/kagu-miru/backend/pkg/jancode"
 	"github.com/k-yomo/kagu-miru/backend/pkg/rakutenichiba"
 	"go.uber.org/multierr"
-	"go.uber.org/zap"
-	"golang.org/x/time/rate"
 )
 
-type genreItemsFetcher struct {
-	pubsubItemUpdateTopic  *pubsub.Topic
+// rakutenSource fetches items in the top level genres from Rakuten Ichiba
+type rakutenSource struct {
+	spannerClient          *spanner.Client
 	rakutenIchibaAPIClient *rakutenichiba.Client
 
-	wg      *sync.WaitGroup
-	pool    chan<- *genreItemsFetcher
-	genreID chan int
-
 	genreIDItemCategoryMap map[int]*xspanner.ItemCategoryWithParent
 	tagMap                 map[int]*xspanner.RakutenTag
-
-	logger *zap.Logger
 }
 
-type worker struct {
-	rakutenIchibaAPIClient *rakutenichiba.Client
-	spannerClient          *spanner.Client
-	pool                   <-chan *genreItemsFetcher
-	workers                []*genreItemsFetcher
-	logger                 *zap.Logger
-
-	wg *sync.WaitGroup
-}
-
-func newWorker(pubsubItemUpdateTopic *pubsub.Topic, spannerClient *spanner.Client, rakutenIchibaAPIClient *rakutenichiba.Client, logger *zap.Logger) *worker {
-	wg := &sync.WaitGroup{}
-	pool := make(chan *genreItemsFetcher, rakutenIchibaAPIClient.ApplicationIDNum())
-	workers := make([]*genreItemsFetcher, 0, cap(pool))
-	for i := 0; i < cap(pool); i++ {
-		workers = append(workers, &genreItemsFetcher{
-			pubsubItemUpdateTopic:  pubsubItemUpdateTopic,
-			rakutenIchibaAPIClient: rakutenIchibaAPIClient,
-			wg:                     wg,
-			pool:                   pool,
-			genreID:                make(chan int),
-			logger:                 logger,
-		})
+func newRakutenSource(ctx context.Context, spannerClient *spanner.Client, rakutenIchibaAPIClient *rakutenichiba.Client) (*rakutenSource, error) {
+	genreIDItemCategoryMap, err := getGenreIDItemCategoryMap(ctx, spannerClient)
+	if err != nil {
+		return nil, fmt.Errorf("getGenreIDItemCategoryMap: %w", err)
+	}
+	tagMap, err := getTagMap(ctx, spannerClient)
+	if err != nil {
+		return nil, fmt.Errorf("getTagMap: %w", err)
 	}
-	return &worker{
+	return &rakutenSource{
 		spannerClient:          spannerClient,
 		rakutenIchibaAPIClient: rakutenIchibaAPIClient,
-		wg:                     wg,
-		pool:                   pool,
-		workers:                workers,
-		logger:                 logger,
-	}
+		genreIDItemCategoryMap: genreIDItemCategoryMap,
+		tagMap:                 tagMap,
+	}, nil
 }
 
-type rakutenWorkerOption struct {
-	StartGenreID int
+func (s *rakutenSource) Name() string {
+	return "rakuten_item_fetcher"
 }
 
-func (r *worker) run(ctx context.Context, option *rakutenWorkerOption) error {
-	genreIDItemCategoryMap, err := r.getGenreIDItemCategoryMap(ctx)
+func (s *rakutenSource) Units(ctx context.Context) ([]string, error) {
+	rakutenItemGenres, err := xspanner.GetAllRakutenItemGenres(ctx, s.spannerClient)
 	if err != nil {
-		return fmt.Errorf("getGenreIDItemCategoryMap: %w", err)
-	}
-	tagMap, err := r.getTagMap(ctx)
-	if err != nil {
-		return fmt.Errorf("getTagMap: %w", err)
-	}
-	for _, w := range r.workers {
-		w.genreIDItemCategoryMap = genreIDItemCategoryMap
-		w.tagMap = tagMap
-		w.start(ctx)
-	}
-
-	rakutenItemGenres, err := xspanner.GetAllRakutenItemGenres(ctx, r.spannerClient)
-	if err != nil {
-		return fmt.Errorf("xspanner.GetAllRakutenItemGenres: %w", err)
+		return nil, fmt.Errorf("xspanner.GetAllRakutenItemGenres: %w", err)
 	}
 	var fetchGenreIDs []int
 	for _, genre := range rakutenItemGenres {
@@ -104,30 +63,65 @@ func (r *worker) run(ctx context.Context, option *rakutenWorkerOption) error {
 		return fetchGenreIDs[i] < fetchGenreIDs[j]
 	})
 
-	startGenreIdx := 0
-	if option.StartGenreID != 0 {
-		for i, genreID := range fetchGenreIDs {
-			if genreID == option.StartGenreID {
-				startGenreIdx = i
-				break
-			}
+	unitIDs := make([]string, 0, len(fetchGenreIDs))
+	for _, genreID := range fetchGenreIDs {
+		unitIDs = append(unitIDs, strconv.Itoa(genreID))
+	}
+	return unitIDs, nil
+}
+
+// We traverse all items in the given genre with following way
+// due to Rakuten Ichiba API limitation(max 30 items at once, 100 page for the given condition)
+// 1. get items in price ascending order
+// 2. when we reach 100th page, set the last item's price to `minPrice` and fetch more 100 pages
+// 3. when we get 0 items, it means we reached the end.
+// Ideally, we want to refetch only updated items since we need to do full-reindex with the current approach.
+// But currently we don't have a way to get item's updated time(API doesn't return it) and set `from` parameter for search
+func (s *rakutenSource) NewCursor(unitID string, state json.RawMessage) (item_fetcher.Cursor, error) {
+	if state != nil {
+		var cursorState rakutenichiba.GenreItemCursorState
+		if err := json.Unmarshal(state, &cursorState); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
 		}
+		return &genreCursor{source: s, cursor: s.rakutenIchibaAPIClient.NewGenreItemCursorFromState(&cursorState)}, nil
 	}
 
-	r.logger.Info(fmt.Sprintf("[start] fetching %d genre", len(fetchGenreIDs[startGenreIdx:])))
+	genreID, err := strconv.Atoi(unitID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid genre id '%s': %w", unitID, err)
+	}
+	cursor := s.rakutenIchibaAPIClient.NewGenreItemCursor(genreID, item_fetcher.MinFetchItemPrice, item_fetcher.MaxFetchItemPrice)
+	return &genreCursor{source: s, cursor: cursor}, nil
+}
+
+type genreCursor struct {
+	source *rakutenSource
+	cursor *rakutenichiba.GenreItemCursor
+}
+
+func (c *genreCursor) Next(ctx context.Context) (*item_fetcher.Page, error) {
+	res, err := c.cursor.Next(ctx)
+	if err == rakutenichiba.Done {
+		return nil, item_fetcher.Done
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	for _, genreID := range fetchGenreIDs[startGenreIdx:] {
-		r.wg.Add(1)
-		(<-r.pool).genreID <- genreID
+	rakutenItems := make([]*rakutenichiba.Item, 0, len(res.Items))
+	for _, item := range res.Items {
+		rakutenItems = append(rakutenItems, item.Item)
 	}
-	r.wg.Wait()
+	items, err := mapRakutenItemsToIndexItems(rakutenItems, c.source.genreIDItemCategoryMap, c.source.tagMap)
+	return &item_fetcher.Page{Items: items, MappingErr: err}, nil
+}
 
-	r.logger.Info(fmt.Sprintf("[end] fetching %d genre", len(fetchGenreIDs[startGenreIdx:])))
-	return nil
+func (c *genreCursor) State() interface{} {
+	return c.cursor.State()
 }
 
-func (r *worker) getTagMap(ctx context.Context) (map[int]*xspanner.RakutenTag, error) {
-	tags, err := xspanner.GetAllRakutenTags(ctx, r.spannerClient)
+func getTagMap(ctx context.Context, spannerClient *spanner.Client) (map[int]*xspanner.RakutenTag, error) {
+	tags, err := xspanner.GetAllRakutenTags(ctx, spannerClient)
 	if err != nil {
 		return nil, fmt.Errorf("xspanner.GetAllRakutenTags: %w", err)
 	}
@@ -140,8 +134,8 @@ func (r *worker) getTagMap(ctx context.Context) (map[int]*xspanner.RakutenTag, e
 	return tagMap, nil
 }
 
-func (r *worker) getGenreIDItemCategoryMap(ctx context.Context) (map[int]*xspanner.ItemCategoryWithParent, error) {
-	rakutenItemGenres, err := xspanner.GetAllRakutenItemGenres(ctx, r.spannerClient)
+func getGenreIDItemCategoryMap(ctx context.Context, spannerClient *spanner.Client) (map[int]*xspanner.ItemCategoryWithParent, error) {
+	rakutenItemGenres, err := xspanner.GetAllRakutenItemGenres(ctx, spannerClient)
 	if err != nil {
 		return nil, fmt.Errorf("xspanner.GetAllRakutenItemGenres: %w", err)
 	}
@@ -150,7 +144,7 @@ func (r *worker) getGenreIDItemCategoryMap(ctx context.Context) (map[int]*xspann
 		genreIDItemCategoryIDMap[int(genre.ID)] = genre.ItemCategoryID
 	}
 
-	itemCategoriesWithParent, err := xspanner.GetAllActiveItemCategoriesWithParent(ctx, r.spannerClient)
+	itemCategoriesWithParent, err := xspanner.GetAllActiveItemCategoriesWithParent(ctx, spannerClient)
 	if err != nil {
 		return nil, fmt.Errorf("xspanner.GetAllActiveItemCategoriesWithParent: %w", err)
 	}
@@ -169,118 +163,6 @@ func (r *worker) getGenreIDItemCategoryMap(ctx context.Context) (map[int]*xspann
 	return genreIDItemCategoryMap, nil
 }
 
-// We traverse all items in the given genre with following way
-// due to Rakuten Ichiba API limitation(max 30 items at once, 100 page for the given condition)
-// 1. get items in price ascending order
-// 2. when we reach 100th page, set the last item's price to `minPrice` and fetch more 100 pages
-// 3. when we get 0 items, it means we reached the end.
-// Ideally, we want to refetch only updated items since we need to do full-reindex with the current approach.
-// But currently we don't have a way to get item's updated time(API doesn't return it) and set `from` parameter for search
-func (w *genreItemsFetcher) start(ctx context.Context) {
-	rateLimiter := rate.NewLimiter(1, 1)
-
-	go func() {
-		for {
-			w.pool <- w
-
-			select {
-			case <-ctx.Done():
-				return
-			case genreID := <-w.genreID:
-
-				totalPublishedCount := 0
-				cursor := w.rakutenIchibaAPIClient.NewGenreItemCursor(genreID, item_fetcher.MinFetchItemPrice, item_fetcher.MaxFetchItemPrice)
-				for {
-					if err := rateLimiter.Wait(ctx); err != nil {
-						w.logger.Error("rateLimiter.Wait failed", zap.Error(err))
-					}
-
-					res, err := cursor.Next(ctx)
-					if err == rakutenichiba.Done {
-						w.logger.Info(fmt.Sprintf(
-							"fetched all items in genre %d", genreID),
-							zap.Int("genreID", genreID),
-							zap.Int("total", totalPublishedCount),
-						)
-						break
-					}
-					if err != nil {
-						w.logger.Error("cursor.Next failed",
-							zap.Error(err),
-							zap.Int("genreID", genreID),
-							zap.Int("minPrice", cursor.CurMinPrice()),
-							zap.Int("page", cursor.CurPage()),
-						)
-						break
-					}
-
-					rakutenItems := make([]*rakutenichiba.Item, 0, len(res.Items))
-					for _, item := range res.Items {
-						rakutenItems = append(rakutenItems, item.Item)
-					}
-					items, err := mapRakutenItemsToIndexItems(rakutenItems, w.genreIDItemCategoryMap, w.tagMap)
-					if err != nil {
-						w.logger.Error(
-							"mapRakutenItemsToIndexItems failed for some items",
-							zap.Error(err),
-							zap.Int("totalCount", len(rakutenItems)),
-							zap.Int("failedCount", len(rakutenItems)-len(items)),
-						)
-					}
-
-					wg := sync.WaitGroup{}
-					var publishedCount int64
-					for _, item := range items {
-						if !item.IsIndexable() {
-							continue
-						}
-
-						item := item
-						wg.Add(1)
-						go func() {
-							defer wg.Done()
-
-							itemJSON, err := json.Marshal(item)
-							if err != nil {
-								w.logger.Error(
-									"json.Marshal item failed",
-									zap.Error(err),
-									zap.Any("item", item),
-								)
-							}
-							res := w.pubsubItemUpdateTopic.Publish(ctx, &pubsub.Message{
-								Data:        itemJSON,
-								OrderingKey: item_fetcher.ItemOrderingKey(item),
-							})
-							if _, err := res.Get(ctx); err != nil {
-								w.logger.Error("publish item update failed",
-									zap.Error(err),
-									zap.String("itemId", item.ID),
-								)
-								return
-							}
-							atomic.AddInt64(&publishedCount, 1)
-						}()
-					}
-					wg.Wait()
-
-					totalPublishedCount += int(publishedCount)
-					if totalPublishedCount%300 == 0 {
-						w.logger.Info(fmt.Sprintf(
-							"published %d items", totalPublishedCount),
-							zap.Int("genreID", genreID),
-							zap.Int("minPrice", cursor.CurMinPrice()),
-							zap.Int("page", cursor.CurPage()),
-						)
-					}
-				}
-
-				w.wg.Done()
-			}
-		}
-	}()
-}
-
 func mapRakutenItemsToIndexItems(
 	rakutenItems []*rakutenichiba.Item,
 	genreIDItemCategoryMap map[int]*xspanner.ItemCategoryWithParent,
@@ -355,6 +237,8 @@ func mapRakutenItemToIndexItem(
 		DepthRange:    mapIntRangeToItemIntRange(metadata.depthRange),
 		HeightRange:   mapIntRangeToItemIntRange(metadata.heightRange),
 		JANCode:       janCode,
+		ShopID:        xitem.ShopUniqueID(xitem.PlatformRakuten, rakutenItem.ShopCode),
+		ShopName:      rakutenItem.ShopName,
 		Platform:      xitem.PlatformRakuten,
 	}, nil
 }
@@ -0,0 +1,203 @@
+package item_fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+type RuntimeOption struct {
+	// Concurrency is the number of units fetched concurrently
+	Concurrency int
+	// RateLimit is the max number of pages fetched per second by each concurrent worker
+	RateLimit rate.Limit
+	// MaxRetries is the max number of retries to fetch a page
+	MaxRetries uint64
+}
+
+type RunOption struct {
+	// StartUnitID skips the units before it
+	StartUnitID string
+}
+
+// checkpointer saves and restores the cursor states, which is implemented by Checkpointer
+type checkpointer interface {
+	CursorState(unitID string) (json.RawMessage, bool)
+	SaveIfDue(ctx context.Context, unitID string, state interface{}) error
+	Save(ctx context.Context, unitID string, state interface{}) error
+}
+
+// Runtime fetches all items from the source and publishes them to the item update topic
+type Runtime struct {
+	source                Source
+	pubsubItemUpdateTopic *pubsub.Topic
+	checkpointer          checkpointer
+	option                *RuntimeOption
+	logger                *zap.Logger
+
+	metrics *Metrics
+	// quotaExhausted is set to 1 when the api quota is exhausted to skip the rest
+	quotaExhausted int32
+}
+
+func NewRuntime(source Source, pubsubItemUpdateTopic *pubsub.Topic, checkpointer checkpointer, option *RuntimeOption, logger *zap.Logger) *Runtime {
+	return &Runtime{
+		source:                source,
+		pubsubItemUpdateTopic: pubsubItemUpdateTopic,
+		checkpointer:          checkpointer,
+		option:                option,
+		logger:                logger.With(zap.String("source", source.Name())),
+		metrics:               &Metrics{},
+	}
+}
+
+// Metrics returns the snapshot of the metrics of the run
+func (r *Runtime) Metrics() Metrics {
+	return r.metrics.snapshot()
+}
+
+func (r *Runtime) Run(ctx context.Context, option *RunOption) error {
+	unitIDs, err := r.source.Units(ctx)
+	if err != nil {
+		return fmt.Errorf("source.Units: %w", err)
+	}
+
+	startUnitIdx := 0
+	if option.StartUnitID != "" {
+		for i, unitID := range unitIDs {
+			if unitID == option.StartUnitID {
+				startUnitIdx = i
+				break
+			}
+		}
+	}
+	unitIDs = unitIDs[startUnitIdx:]
+
+	r.logger.Info(fmt.Sprintf("[start] fetching %d units", len(unitIDs)))
+
+	unitIDCh := make(chan string)
+	wg := sync.WaitGroup{}
+	for i := 0; i < r.option.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rateLimiter := rate.NewLimiter(r.option.RateLimit, 1)
+			for unitID := range unitIDCh {
+				r.fetchUnit(ctx, unitID, rateLimiter)
+			}
+		}()
+	}
+dispatch:
+	for _, unitID := range unitIDs {
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case unitIDCh <- unitID:
+		}
+	}
+	close(unitIDCh)
+	wg.Wait()
+
+	r.logger.Info(fmt.Sprintf("[end] fetching %d units", len(unitIDs)), r.Metrics().zapFields()...)
+	return nil
+}
+
+// We traverse all items in the given unit page by page with the cursor.
+// The cursor state is saved periodically after the items are published, so that the unit can be resumed from there.
+func (r *Runtime) fetchUnit(ctx context.Context, unitID string, rateLimiter *rate.Limiter) {
+	if atomic.LoadInt32(&r.quotaExhausted) == 1 {
+		return
+	}
+	logger := r.logger.With(zap.String("unitID", unitID))
+
+	state, _ := r.checkpointer.CursorState(unitID)
+	cursor, err := r.source.NewCursor(unitID, state)
+	if err != nil {
+		logger.Error("source.NewCursor failed", zap.Error(err))
+		return
+	}
+
+	totalPublishedCount := 0
+	for atomic.LoadInt32(&r.quotaExhausted) == 0 {
+		if err := rateLimiter.Wait(ctx); err != nil {
+			logger.Error("rateLimiter.Wait failed", zap.Error(err))
+			break
+		}
+
+		page, err := r.next(ctx, cursor, logger)
+		if err == Done {
+			logger.Info(fmt.Sprintf("fetched all items in unit %s", unitID), zap.Int("total", totalPublishedCount))
+			atomic.AddInt64(&r.metrics.FetchedUnitCount, 1)
+			break
+		}
+		if errors.Is(err, quota.ErrExhausted) {
+			atomic.StoreInt32(&r.quotaExhausted, 1)
+			logger.Warn("api quota is exhausted, stop fetching")
+			break
+		}
+		if err != nil {
+			atomic.AddInt64(&r.metrics.FetchErrorCount, 1)
+			logger.Error("cursor.Next failed", zap.Error(err), zap.Any("cursorState", cursor.State()))
+			break
+		}
+		atomic.AddInt64(&r.metrics.FetchedPageCount, 1)
+		atomic.AddInt64(&r.metrics.FetchedItemCount, int64(len(page.Items)))
+
+		if page.MappingErr != nil {
+			mappingErrCount := len(multierr.Errors(page.MappingErr))
+			atomic.AddInt64(&r.metrics.MappingErrorCount, int64(mappingErrCount))
+			logger.Error(
+				"mapping items failed for some items",
+				zap.Error(page.MappingErr),
+				zap.Int("failedCount", mappingErrCount),
+			)
+		}
+
+		publishedCount := PublishItems(ctx, r.pubsubItemUpdateTopic, page.Items, logger)
+		atomic.AddInt64(&r.metrics.PublishedItemCount, int64(publishedCount))
+
+		if err := r.checkpointer.SaveIfDue(ctx, unitID, cursor.State()); err != nil {
+			logger.Error("checkpointer.SaveIfDue failed", zap.Error(err))
+		}
+
+		totalPublishedCount += publishedCount
+		if totalPublishedCount%300 == 0 {
+			logger.Info(fmt.Sprintf("published %d items", totalPublishedCount), zap.Any("cursorState", cursor.State()))
+		}
+	}
+	if err := r.checkpointer.Save(ctx, unitID, cursor.State()); err != nil {
+		logger.Error("checkpointer.Save failed", zap.Error(err))
+	}
+}
+
+// next fetches the next page with retries
+func (r *Runtime) next(ctx context.Context, cursor Cursor, logger *zap.Logger) (*Page, error) {
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = 1 * time.Second
+	b := backoff.WithContext(backoff.WithMaxRetries(expBackoff, r.option.MaxRetries), ctx)
+
+	var page *Page
+	err := backoff.RetryNotify(func() error {
+		var err error
+		page, err = cursor.Next(ctx)
+		if err == Done || errors.Is(err, quota.ErrExhausted) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, b, func(err error, d time.Duration) {
+		atomic.AddInt64(&r.metrics.RetryCount, 1)
+		logger.Warn("cursor.Next failed, retrying", zap.Error(err), zap.Duration("after", d))
+	})
+	return page, err
+}
@@ -0,0 +1,303 @@
+package item_fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/pubsub/pstest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+)
+
+// fakeSource returns the results of each unit in order, the cursor state is the number of results returned
+type fakeSource struct {
+	units   []string
+	results map[string][]fakeResult
+
+	mu          sync.Mutex
+	cursorUnits []string
+	inFlight    int
+	maxInFlight int
+}
+
+type fakeResult struct {
+	page *Page
+	err  error
+}
+
+func (s *fakeSource) Name() string {
+	return "fake_item_fetcher"
+}
+
+func (s *fakeSource) Units(ctx context.Context) ([]string, error) {
+	return s.units, nil
+}
+
+func (s *fakeSource) NewCursor(unitID string, state json.RawMessage) (Cursor, error) {
+	s.mu.Lock()
+	s.cursorUnits = append(s.cursorUnits, unitID)
+	s.mu.Unlock()
+
+	cursor := &fakeCursor{source: s, results: s.results[unitID]}
+	if state != nil {
+		if err := json.Unmarshal(state, &cursor.pos); err != nil {
+			return nil, err
+		}
+	}
+	return cursor, nil
+}
+
+type fakeCursor struct {
+	source  *fakeSource
+	results []fakeResult
+	pos     int
+}
+
+func (c *fakeCursor) Next(ctx context.Context) (*Page, error) {
+	c.source.mu.Lock()
+	c.source.inFlight++
+	if c.source.inFlight > c.source.maxInFlight {
+		c.source.maxInFlight = c.source.inFlight
+	}
+	c.source.mu.Unlock()
+	defer func() {
+		c.source.mu.Lock()
+		c.source.inFlight--
+		c.source.mu.Unlock()
+	}()
+	// keep the call in flight for a while to observe the concurrent calls
+	time.Sleep(50 * time.Millisecond)
+
+	if c.pos >= len(c.results) {
+		return nil, Done
+	}
+	result := c.results[c.pos]
+	if result.err != nil {
+		// the failed result is consumed so that the retry gets the next result
+		c.results = append(c.results[:c.pos], c.results[c.pos+1:]...)
+		return nil, result.err
+	}
+	c.pos++
+	return result.page, nil
+}
+
+func (c *fakeCursor) State() interface{} {
+	return c.pos
+}
+
+// fakeCheckpointer keeps the cursor states in memory
+type fakeCheckpointer struct {
+	mu          sync.Mutex
+	cursorState map[string]json.RawMessage
+	savedState  map[string]interface{}
+}
+
+func newFakeCheckpointer(cursorState map[string]json.RawMessage) *fakeCheckpointer {
+	return &fakeCheckpointer{cursorState: cursorState, savedState: make(map[string]interface{})}
+}
+
+func (c *fakeCheckpointer) CursorState(unitID string) (json.RawMessage, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	state, ok := c.cursorState[unitID]
+	return state, ok
+}
+
+func (c *fakeCheckpointer) SaveIfDue(ctx context.Context, unitID string, state interface{}) error {
+	return c.Save(ctx, unitID, state)
+}
+
+func (c *fakeCheckpointer) Save(ctx context.Context, unitID string, state interface{}) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.savedState[unitID] = state
+	return nil
+}
+
+func newTestTopic(t *testing.T) (*pubsub.Topic, *pstest.Server) {
+	t.Helper()
+
+	ctx := context.Background()
+	srv := pstest.NewServer()
+	t.Cleanup(func() { _ = srv.Close() })
+	conn, err := grpc.Dial(srv.Addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+	client, err := pubsub.NewClient(ctx, "test", option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic, err := client.CreateTopic(ctx, "item-update")
+	if err != nil {
+		t.Fatal(err)
+	}
+	topic.EnableMessageOrdering = true
+	t.Cleanup(topic.Stop)
+	return topic, srv
+}
+
+func newTestPage(itemIDs ...string) *Page {
+	items := make([]*xitem.Item, 0, len(itemIDs))
+	for _, itemID := range itemIDs {
+		items = append(items, &xitem.Item{ID: itemID, Name: itemID})
+	}
+	return &Page{Items: items}
+}
+
+func TestRuntime_Run(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		source          *fakeSource
+		cursorState     map[string]json.RawMessage
+		option          *RuntimeOption
+		wantMetrics     Metrics
+		wantSavedState  map[string]interface{}
+		wantCursorUnits []string
+		wantMaxInFlight int
+	}{
+		{
+			name: "fetches units concurrently",
+			source: &fakeSource{
+				units: []string{"1", "2"},
+				results: map[string][]fakeResult{
+					"1": {{page: newTestPage("1-1", "1-2")}, {page: newTestPage("1-3")}},
+					"2": {{page: newTestPage("2-1")}},
+				},
+			},
+			option: &RuntimeOption{Concurrency: 2, RateLimit: rate.Inf},
+			wantMetrics: Metrics{
+				FetchedUnitCount:   2,
+				FetchedPageCount:   3,
+				FetchedItemCount:   4,
+				PublishedItemCount: 4,
+			},
+			wantSavedState:  map[string]interface{}{"1": 2, "2": 1},
+			wantCursorUnits: []string{"1", "2"},
+			wantMaxInFlight: 2,
+		},
+		{
+			name: "retries the failed page",
+			source: &fakeSource{
+				units: []string{"1"},
+				results: map[string][]fakeResult{
+					"1": {{err: errors.New("temporary error")}, {page: newTestPage("1-1")}},
+				},
+			},
+			option: &RuntimeOption{Concurrency: 1, RateLimit: rate.Inf, MaxRetries: 1},
+			wantMetrics: Metrics{
+				FetchedUnitCount:   1,
+				FetchedPageCount:   1,
+				FetchedItemCount:   1,
+				PublishedItemCount: 1,
+				RetryCount:         1,
+			},
+			wantSavedState:  map[string]interface{}{"1": 1},
+			wantCursorUnits: []string{"1"},
+			wantMaxInFlight: 1,
+		},
+		{
+			name: "saves the checkpoint of the unit failed after retries",
+			source: &fakeSource{
+				units: []string{"1"},
+				results: map[string][]fakeResult{
+					"1": {{page: newTestPage("1-1")}, {err: errors.New("error")}, {err: errors.New("error")}},
+				},
+			},
+			option: &RuntimeOption{Concurrency: 1, RateLimit: rate.Inf, MaxRetries: 1},
+			wantMetrics: Metrics{
+				FetchedPageCount:   1,
+				FetchedItemCount:   1,
+				PublishedItemCount: 1,
+				RetryCount:         1,
+				FetchErrorCount:    1,
+			},
+			wantSavedState:  map[string]interface{}{"1": 1},
+			wantCursorUnits: []string{"1"},
+			wantMaxInFlight: 1,
+		},
+		{
+			name: "resumes the unit from the checkpoint",
+			source: &fakeSource{
+				units: []string{"1"},
+				results: map[string][]fakeResult{
+					"1": {{page: newTestPage("1-1")}, {page: newTestPage("1-2", "1-3")}},
+				},
+			},
+			cursorState: map[string]json.RawMessage{"1": json.RawMessage("1")},
+			option:      &RuntimeOption{Concurrency: 1, RateLimit: rate.Inf},
+			wantMetrics: Metrics{
+				FetchedUnitCount:   1,
+				FetchedPageCount:   1,
+				FetchedItemCount:   2,
+				PublishedItemCount: 2,
+			},
+			wantSavedState:  map[string]interface{}{"1": 2},
+			wantCursorUnits: []string{"1"},
+			wantMaxInFlight: 1,
+		},
+		{
+			name: "stops fetching the rest of units when the quota is exhausted",
+			source: &fakeSource{
+				units: []string{"1", "2"},
+				results: map[string][]fakeResult{
+					"1": {{page: newTestPage("1-1")}, {err: fmt.Errorf("client.Search: %w", quota.ErrExhausted)}},
+					"2": {{page: newTestPage("2-1")}},
+				},
+			},
+			option: &RuntimeOption{Concurrency: 1, RateLimit: rate.Inf, MaxRetries: 5},
+			wantMetrics: Metrics{
+				FetchedPageCount:   1,
+				FetchedItemCount:   1,
+				PublishedItemCount: 1,
+			},
+			wantSavedState:  map[string]interface{}{"1": 1},
+			wantCursorUnits: []string{"1"},
+			wantMaxInFlight: 1,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			topic, srv := newTestTopic(t)
+			checkpointer := newFakeCheckpointer(tt.cursorState)
+			runtime := NewRuntime(tt.source, topic, checkpointer, tt.option, zap.NewNop())
+			if err := runtime.Run(context.Background(), &RunOption{}); err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.wantMetrics, runtime.Metrics()); diff != "" {
+				t.Errorf("Metrics(), (-want +got): %s", diff)
+			}
+			if got := len(srv.Messages()); got != int(tt.wantMetrics.PublishedItemCount) {
+				t.Errorf("published messages = %d, want %d", got, tt.wantMetrics.PublishedItemCount)
+			}
+			if diff := cmp.Diff(tt.wantSavedState, checkpointer.savedState); diff != "" {
+				t.Errorf("saved cursor states, (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantCursorUnits, tt.source.cursorUnits, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("fetched units, (-want +got): %s", diff)
+			}
+			if tt.source.maxInFlight != tt.wantMaxInFlight {
+				t.Errorf("max concurrent fetches = %d, want %d", tt.source.maxInFlight, tt.wantMaxInFlight)
+			}
+		})
+	}
+}
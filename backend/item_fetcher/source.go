@@ -0,0 +1,40 @@
+package item_fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+)
+
+// Done is returned by Cursor.Next when all items in the unit are fetched
+var Done = errors.New("DONE")
+
+// Source is the marketplace to fetch items from
+// Adding a marketplace only requires implementing the source, the runtime takes care of
+// concurrency, rate limiting, retries, checkpoints and publishing.
+type Source interface {
+	// Name identifies the source in checkpoints and logs
+	Name() string
+	// Units returns the ids of the crawl units (e.g. genre) in the order to fetch
+	Units(ctx context.Context) ([]string, error)
+	// NewCursor returns the cursor of the unit restored from the state, or a new cursor when the state is nil
+	NewCursor(unitID string, state json.RawMessage) (Cursor, error)
+}
+
+// Cursor iterates the items in the crawl unit page by page
+type Cursor interface {
+	// Next returns the next page, Done is returned when all items are fetched
+	// quota.ErrExhausted must be returned as it is or wrapped to stop the run.
+	Next(ctx context.Context) (*Page, error)
+	// State returns the serializable state to resume the cursor
+	State() interface{}
+}
+
+// Page is the items fetched at once
+type Page struct {
+	Items []*xitem.Item
+	// MappingErr is the error of the items failed to be mapped to xitem.Item, the rest of items are still published
+	MappingErr error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 
 	"cloud.google.com/go/spanner"
@@ -11,6 +12,8 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	"github.com/blendle/zapdriver"
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
+	"github.com/k-yomo/kagu-miru/backend/item_fetcher"
 	"github.com/k-yomo/kagu-miru/backend/pkg/yahoo_shopping"
 	"go.uber.org/zap"
 )
@@ -41,21 +44,38 @@ func main() {
 		logger.Fatal("failed to initialize spanner client", zap.Error(err))
 	}
 
-	yahooShoppingClient := yahoo_shopping.NewClient(cfg.YahooShoppingApplicationIDs)
-	yahooShoppingItemWorker := newWorker(pubsubItemUpdateTopic, spannerClient, yahooShoppingClient, logger)
+	quotaLedger := quota.NewSpannerLedger(spannerClient, quota.MarketplaceYahooShopping, yahoo_shopping.MaxAPICallPerDay)
+	yahooShoppingClient := yahoo_shopping.NewClient(cfg.YahooShoppingApplicationIDs, quotaLedger)
+	yahooShoppingSource, err := newYahooShoppingSource(context.Background(), spannerClient, yahooShoppingClient)
+	if err != nil {
+		logger.Fatal("failed to initialize yahoo shopping source", zap.Error(err))
+	}
+	checkpointer, err := item_fetcher.NewCheckpointer(context.Background(), spannerClient, yahooShoppingSource.Name(), cfg.ContinueLastRun, cfg.CheckpointInterval)
+	if err != nil {
+		logger.Fatal("failed to initialize checkpointer", zap.Error(err))
+	}
+	logger.Info("checkpointer initialized", zap.String("runID", checkpointer.RunID()), zap.Bool("continueLastRun", cfg.ContinueLastRun))
+	runtime := item_fetcher.NewRuntime(yahooShoppingSource, pubsubItemUpdateTopic, checkpointer, &item_fetcher.RuntimeOption{
+		// each application id is rate limited to 1 request per second
+		Concurrency: yahooShoppingClient.ApplicationIDNum(),
+		RateLimit:   1,
+		MaxRetries:  5,
+	}, logger)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	doneCh := make(chan struct{}, 1)
 	go func() {
-		logger.Info("yahooShoppingItemWorker started running")
-		if err := yahooShoppingItemWorker.run(ctx, &yahooShoppingWorkerOption{
-			StartCategoryID: cfg.YahooShoppingStartCategoryID,
-			MinPrice:        cfg.YahooShoppingMinPrice,
-		}); err != nil {
-			logger.Error("yahooShoppingItemWorker failed", zap.Error(err))
+		runOption := &item_fetcher.RunOption{}
+		if cfg.YahooShoppingStartCategoryID != 0 {
+			runOption.StartUnitID = strconv.Itoa(cfg.YahooShoppingStartCategoryID)
+		}
+		logger.Info("yahoo shopping item fetcher started running")
+		if err := runtime.Run(ctx, runOption); err != nil {
+			logger.Error("yahoo shopping item fetcher failed", zap.Error(err))
 		}
+		item_fetcher.LogQuotaUsages(context.Background(), quotaLedger, logger)
 		doneCh <- struct{}{}
 	}()
 
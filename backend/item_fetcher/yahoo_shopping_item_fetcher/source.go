@@ -6,10 +6,8 @@ import (
 	"fmt"
 	"math"
 	"sort"
-	"sync"
-	"sync/atomic"
+	"strconv"
 
-	"cloud.google.com/go/pubsub"
 	"cloud.google.com/go/spanner"
 	"github.com/k-yomo/jp-dimension-parser/dimparser"
 	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
@@ -18,78 +16,37 @@ import (
 	"github.com/k-yomo/kagu-miru/backend/pkg/jancode"
 	"github.com/k-yomo/kagu-miru/backend/pkg/yahoo_shopping"
 	"go.uber.org/multierr"
-	"go.uber.org/zap"
-	"golang.org/x/time/rate"
 )
 
-type categoryItemsFetcher struct {
-	pubsubItemUpdateTopic  *pubsub.Topic
+// yahooShoppingSource fetches items in the top level categories from Yahoo Shopping
+type yahooShoppingSource struct {
+	spannerClient          *spanner.Client
 	yahooShoppingAPIClient *yahoo_shopping.Client
 
-	wg         *sync.WaitGroup
-	pool       chan<- *categoryItemsFetcher
-	categoryID chan int
-
 	ysCategoryIDItemCategoryMap map[int]*xspanner.ItemCategoryWithParent
-
-	logger *zap.Logger
-}
-
-type worker struct {
-	yahooShoppingAPIClient *yahoo_shopping.Client
-	spannerClient          *spanner.Client
-	pool                   <-chan *categoryItemsFetcher
-	workers                []*categoryItemsFetcher
-	logger                 *zap.Logger
-
-	wg *sync.WaitGroup
 }
 
-func newWorker(pubsubItemUpdateTopic *pubsub.Topic, spannerClient *spanner.Client, yahooShoppingAPIClient *yahoo_shopping.Client, logger *zap.Logger) *worker {
-	wg := &sync.WaitGroup{}
-	pool := make(chan *categoryItemsFetcher, yahooShoppingAPIClient.ApplicationIDNum())
-	workers := make([]*categoryItemsFetcher, 0, cap(pool))
-	for i := 0; i < cap(pool); i++ {
-		workers = append(workers, &categoryItemsFetcher{
-			pubsubItemUpdateTopic:  pubsubItemUpdateTopic,
-			yahooShoppingAPIClient: yahooShoppingAPIClient,
-			wg:                     wg,
-			pool:                   pool,
-			categoryID:             make(chan int),
-			logger:                 logger,
-		})
-	}
-	return &worker{
-		yahooShoppingAPIClient: yahooShoppingAPIClient,
-		spannerClient:          spannerClient,
-		wg:                     wg,
-		pool:                   pool,
-		workers:                workers,
-		logger:                 logger,
+func newYahooShoppingSource(ctx context.Context, spannerClient *spanner.Client, yahooShoppingAPIClient *yahoo_shopping.Client) (*yahooShoppingSource, error) {
+	ysCategoryIDItemCategoryMap, err := getYSCategoryIDItemCategoryMap(ctx, spannerClient)
+	if err != nil {
+		return nil, fmt.Errorf("getYSCategoryIDItemCategoryMap: %w", err)
 	}
+	return &yahooShoppingSource{
+		spannerClient:               spannerClient,
+		yahooShoppingAPIClient:      yahooShoppingAPIClient,
+		ysCategoryIDItemCategoryMap: ysCategoryIDItemCategoryMap,
+	}, nil
 }
 
-type yahooShoppingWorkerOption struct {
-	StartCategoryID int
-	MinPrice        int
+func (s *yahooShoppingSource) Name() string {
+	return "yahoo_shopping_item_fetcher"
 }
 
-func (r *worker) run(ctx context.Context, option *yahooShoppingWorkerOption) error {
-	ysItemCategories, err := xspanner.GetAllYahooShoppingItemCategories(ctx, r.spannerClient)
+func (s *yahooShoppingSource) Units(ctx context.Context) ([]string, error) {
+	ysItemCategories, err := xspanner.GetAllYahooShoppingItemCategories(ctx, s.spannerClient)
 	if err != nil {
-		return fmt.Errorf("xspanner.GetAllYahooShoppingItemCategories: %w", err)
-	}
-
-	ysCategoryIDItemCategoryMap, err := r.getYSCategoryIDItemCategoryMap(ctx)
-	if err != nil {
-		return fmt.Errorf("getFurnitureGenre: %w", err)
-	}
-
-	for _, w := range r.workers {
-		w.ysCategoryIDItemCategoryMap = ysCategoryIDItemCategoryMap
-		w.start(ctx)
+		return nil, fmt.Errorf("xspanner.GetAllYahooShoppingItemCategories: %w", err)
 	}
-
 	fetchCategoryIDs := make([]int, 0, len(ysItemCategories))
 	for _, category := range ysItemCategories {
 		if category.Level == 0 {
@@ -100,30 +57,61 @@ func (r *worker) run(ctx context.Context, option *yahooShoppingWorkerOption) err
 		return fetchCategoryIDs[i] < fetchCategoryIDs[j]
 	})
 
-	startCategoryIdx := 0
-	if option.StartCategoryID != 0 {
-		for i, categoryID := range fetchCategoryIDs {
-			if categoryID == option.StartCategoryID {
-				startCategoryIdx = i
-				break
-			}
+	unitIDs := make([]string, 0, len(fetchCategoryIDs))
+	for _, categoryID := range fetchCategoryIDs {
+		unitIDs = append(unitIDs, strconv.Itoa(categoryID))
+	}
+	return unitIDs, nil
+}
+
+// We traverse all items in the given category with following way
+// due to Yahoo Shopping API limitation(max 30 items at once, 100 page for the given condition)
+// 1. get items in price ascending order
+// 2. when we reach 100th page, set the last item's price to `minPrice` and fetch more 100 pages
+// 3. when we get 0 items, it means we reached the end.
+// Ideally, we want to refetch only updated items since we need to do full-reindex with the current approach.
+// But currently we don't have a way to get item's updated time(API doesn't return it) and set `from` parameter for search
+func (s *yahooShoppingSource) NewCursor(unitID string, state json.RawMessage) (item_fetcher.Cursor, error) {
+	if state != nil {
+		var cursorState yahoo_shopping.CategoryItemCursorState
+		if err := json.Unmarshal(state, &cursorState); err != nil {
+			return nil, fmt.Errorf("json.Unmarshal: %w", err)
 		}
+		return &categoryCursor{source: s, cursor: s.yahooShoppingAPIClient.NewCategoryItemCursorFromState(&cursorState)}, nil
 	}
 
-	r.logger.Info(fmt.Sprintf("[start] fetching %d category", len(fetchCategoryIDs[startCategoryIdx:])))
+	categoryID, err := strconv.Atoi(unitID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid category id '%s': %w", unitID, err)
+	}
+	cursor := s.yahooShoppingAPIClient.NewCategoryItemCursor(categoryID, item_fetcher.MinFetchItemPrice, item_fetcher.MaxFetchItemPrice)
+	return &categoryCursor{source: s, cursor: cursor}, nil
+}
+
+type categoryCursor struct {
+	source *yahooShoppingSource
+	cursor *yahoo_shopping.CategoryItemCursor
+}
 
-	for _, categoryID := range fetchCategoryIDs[startCategoryIdx:] {
-		r.wg.Add(1)
-		(<-r.pool).categoryID <- categoryID
+func (c *categoryCursor) Next(ctx context.Context) (*item_fetcher.Page, error) {
+	res, err := c.cursor.Next(ctx)
+	if err == yahoo_shopping.Done {
+		return nil, item_fetcher.Done
 	}
-	r.wg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := mapYahooShoppingItemsToIndexItems(res.Hits, c.source.ysCategoryIDItemCategoryMap)
+	return &item_fetcher.Page{Items: items, MappingErr: err}, nil
+}
 
-	r.logger.Info(fmt.Sprintf("[end] fetching %d category", len(fetchCategoryIDs[startCategoryIdx:])))
-	return nil
+func (c *categoryCursor) State() interface{} {
+	return c.cursor.State()
 }
 
-func (r *worker) getYSCategoryIDItemCategoryMap(ctx context.Context) (map[int]*xspanner.ItemCategoryWithParent, error) {
-	ysItemCategories, err := xspanner.GetAllYahooShoppingItemCategories(ctx, r.spannerClient)
+func getYSCategoryIDItemCategoryMap(ctx context.Context, spannerClient *spanner.Client) (map[int]*xspanner.ItemCategoryWithParent, error) {
+	ysItemCategories, err := xspanner.GetAllYahooShoppingItemCategories(ctx, spannerClient)
 	if err != nil {
 		return nil, fmt.Errorf("xspanner.GetAllYahooShoppingItemCategories: %w", err)
 	}
@@ -132,7 +120,7 @@ func (r *worker) getYSCategoryIDItemCategoryMap(ctx context.Context) (map[int]*x
 		ysCategoryIDItemCategoryIDMap[int(genre.ID)] = genre.ItemCategoryID
 	}
 
-	itemCategoriesWithParent, err := xspanner.GetAllActiveItemCategoriesWithParent(ctx, r.spannerClient)
+	itemCategoriesWithParent, err := xspanner.GetAllActiveItemCategoriesWithParent(ctx, spannerClient)
 	if err != nil {
 		return nil, fmt.Errorf("xspanner.GetAllActiveItemCategoriesWithParent: %w", err)
 	}
@@ -151,115 +139,6 @@ func (r *worker) getYSCategoryIDItemCategoryMap(ctx context.Context) (map[int]*x
 	return ysCategoryIDItemCategoryMap, nil
 }
 
-// We traverse all items in the given category with following way
-// due to Yahoo Shopping API limitation(max 30 items at once, 100 page for the given condition)
-// 1. get items in price ascending order
-// 2. when we reach 100th page, set the last item's price to `minPrice` and fetch more 100 pages
-// 3. when we get 0 items, it means we reached the end.
-// Ideally, we want to refetch only updated items since we need to do full-reindex with the current approach.
-// But currently we don't have a way to get item's updated time(API doesn't return it) and set `from` parameter for search
-func (w *categoryItemsFetcher) start(ctx context.Context) {
-	rateLimiter := rate.NewLimiter(1, 1)
-
-	go func() {
-		for {
-			w.pool <- w
-
-			select {
-			case <-ctx.Done():
-				return
-			case categoryID := <-w.categoryID:
-
-				totalPublishedCount := 0
-				cursor := w.yahooShoppingAPIClient.NewCategoryItemCursor(categoryID, item_fetcher.MinFetchItemPrice, item_fetcher.MaxFetchItemPrice)
-				for {
-					if err := rateLimiter.Wait(ctx); err != nil {
-						w.logger.Error("rateLimiter.Wait failed", zap.Error(err))
-					}
-
-					res, err := cursor.Next(ctx)
-					if err == yahoo_shopping.Done {
-						w.logger.Info(fmt.Sprintf(
-							"fetched all items in category %d", categoryID),
-							zap.Int("categoryID", categoryID),
-							zap.Int("total", totalPublishedCount),
-						)
-						break
-					}
-					if err != nil {
-						w.logger.Error("cursor.Next failed",
-							zap.Error(err),
-							zap.Int("categoryID", categoryID),
-							zap.Int("minPrice", cursor.CurMinPrice()),
-							zap.Int("page", cursor.CurPage()),
-						)
-						break
-					}
-
-					items, err := mapYahooShoppingItemsToIndexItems(res.Hits, w.ysCategoryIDItemCategoryMap)
-					if err != nil {
-						w.logger.Error(
-							"mapYahooShoppingItemsToIndexItems failed for some items",
-							zap.Error(err),
-							zap.Int("totalCount", len(res.Hits)),
-							zap.Int("failedCount", len(res.Hits)-len(items)),
-						)
-					}
-
-					wg := sync.WaitGroup{}
-					var publishedCount int64
-					for _, item := range items {
-						if !item.IsIndexable() {
-							continue
-						}
-
-						item := item
-						wg.Add(1)
-						go func() {
-							defer wg.Done()
-
-							itemJSON, err := json.Marshal(item)
-							if err != nil {
-								w.logger.Error(
-									"json.Marshal item failed",
-									zap.Error(err),
-									zap.Any("item", item),
-								)
-								return
-							}
-							res := w.pubsubItemUpdateTopic.Publish(ctx, &pubsub.Message{
-								Data:        itemJSON,
-								OrderingKey: item_fetcher.ItemOrderingKey(item),
-							})
-							if _, err := res.Get(ctx); err != nil {
-								w.logger.Error("publish item update failed",
-									zap.Error(err),
-									zap.String("itemId", item.ID),
-								)
-								return
-							}
-							atomic.AddInt64(&publishedCount, 1)
-						}()
-					}
-					wg.Wait()
-
-					totalPublishedCount += int(publishedCount)
-					if totalPublishedCount%300 == 0 {
-						w.logger.Info(fmt.Sprintf(
-							"published %d items", totalPublishedCount),
-							zap.Int("categoryID", categoryID),
-							zap.Int("minPrice", cursor.CurMinPrice()),
-							zap.Int("page", cursor.CurPage()),
-						)
-					}
-				}
-
-				w.wg.Done()
-			}
-		}
-	}()
-}
-
 func mapYahooShoppingItemsToIndexItems(yahooShoppingItems []*yahoo_shopping.Item, ysCategoryIDItemCategoryMap map[int]*xspanner.ItemCategoryWithParent) ([]*xitem.Item, error) {
 	items := make([]*xitem.Item, 0, len(yahooShoppingItems))
 	var errors []error
@@ -319,6 +198,8 @@ func mapYahooShoppingItemToIndexItem(yahooShoppingItem *yahoo_shopping.Item, ite
 		DepthRange:    depthRange,
 		HeightRange:   heightRange,
 		JANCode:       janCode,
+		ShopID:        xitem.ShopUniqueID(platform, yahooShoppingItem.Seller.SellerId),
+		ShopName:      yahooShoppingItem.Seller.Name,
 		Platform:      platform,
 	}, nil
 }
@@ -27,8 +27,13 @@ func mapItemFetcherItemToElasticsearchItem(item *xitem.Item) *es.Item {
 		CategoryNames: item.CategoryNames,
 		BrandName:     item.BrandName,
 		Colors:        item.Colors,
+		WidthRange:    item.WidthRange,
+		DepthRange:    item.DepthRange,
+		HeightRange:   item.HeightRange,
 		Metadata:      extractMetadata(item),
 		JANCode:       item.JANCode,
+		ShopID:        item.ShopID,
+		ShopName:      item.ShopName,
 		Platform:      item.Platform,
 		IndexedAt:     time.Now().UnixMilli(),
 	}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
 	"github.com/k-yomo/kagu-miru/backend/pkg/imageutil"
 
@@ -28,6 +30,10 @@ type ItemIndexer struct {
 	spannerClient *spanner.Client
 	esClient      *elastic.Client
 	indexName     string
+
+	ratingPriorsMu        sync.Mutex
+	ratingPriors          *ratingPriors
+	ratingPriorsExpiresAt time.Time
 }
 
 func NewItemIndexer(spannerClient *spanner.Client, esClient *elastic.Client, indexName string) *ItemIndexer {
@@ -48,6 +54,11 @@ func (i *ItemIndexer) BulkIndex(ctx context.Context, items []*xitem.Item) error
 		return err
 	}
 
+	itemRatings, siblingRatings, err := i.getItemRatings(ctx, items, itemIDGroupIDMap)
+	if err != nil {
+		return err
+	}
+
 	eg := errgroup.Group{}
 	eg.Go(func() error {
 		spannerItems := make([]*xspanner.Item, 0, len(items))
@@ -70,9 +81,17 @@ func (i *ItemIndexer) BulkIndex(ctx context.Context, items []*xitem.Item) error
 			}
 			esItem := mapItemFetcherItemToElasticsearchItem(item)
 			esItem.GroupID = groupID
+			if rating, ok := itemRatings[item.ID]; ok {
+				esItem.RatingScore = rating.ratingScore
+				esItem.GroupAverageRating = rating.groupAverageRating
+				esItem.GroupReviewCount = rating.groupReviewCount
+			}
 			esItems = append(esItems, esItem)
 		}
-		return i.bulkIndexItemsToElasticsearch(ctx, esItems)
+		if err := i.bulkIndexItemsToElasticsearch(ctx, esItems); err != nil {
+			return err
+		}
+		return i.updateSiblingRatings(ctx, siblingRatings)
 	})
 
 	return eg.Wait()
@@ -245,3 +264,39 @@ func mapElasticsearchHitsToItems(hits []*elastic.SearchHit) []*es.Item {
 
 	return items
 }
+
+// updateSiblingRatings updates the ratings of the other items in the groups of the indexed items
+// The group ratings of the siblings are changed by the indexed items, but the siblings are not fetched again soon.
+// Siblings not found in the index are skipped since the ratings are computed when they are indexed.
+func (i *ItemIndexer) updateSiblingRatings(ctx context.Context, siblingRatings map[string]*itemRating) error {
+	if len(siblingRatings) == 0 {
+		return nil
+	}
+
+	bulk := i.esClient.Bulk().Index(i.indexName)
+	for itemID, rating := range siblingRatings {
+		bulk.Add(elastic.NewBulkUpdateRequest().Index(i.indexName).Id(itemID).Doc(map[string]interface{}{
+			es.ItemFieldRatingScore:        rating.ratingScore,
+			es.ItemFieldGroupAverageRating: rating.groupAverageRating,
+			es.ItemFieldGroupReviewCount:   rating.groupReviewCount,
+			// the update must be caught up with when switching the index version
+			es.ItemFieldIndexedAt: time.Now().UnixMilli(),
+		}))
+	}
+
+	resp, err := bulk.Do(ctx)
+	if err != nil {
+		return fmt.Errorf("esClient.Bulk failed: %w", err)
+	}
+	var errs []error
+	for _, failed := range resp.Failed() {
+		if failed.Status == http.StatusNotFound {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("id: %s, status: %d, err: %s", failed.Id, failed.Status, failed.Result))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("update sibling ratings failed: %w", multierr.Combine(errs...))
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+const (
+	// ratingPriorWeight is the number of virtual reviews rated with the prior mean
+	// The larger the weight, the more reviews are required for the rating score to get closer to the actual rating.
+	ratingPriorWeight = 10
+	// minReviewsForPrior is the min number of reviews to use the mean of the platform and category as the prior
+	minReviewsForPrior = 100
+	// defaultRatingPrior is used when there are not enough reviews at all
+	defaultRatingPrior = 3.5
+	ratingPriorsTTL    = 1 * time.Hour
+)
+
+// ratingStats is the aggregated ratings
+type ratingStats struct {
+	ratingSum   float64
+	reviewCount int
+}
+
+func (s ratingStats) add(averageRating float64, reviewCount int) ratingStats {
+	return ratingStats{
+		ratingSum:   s.ratingSum + averageRating*float64(reviewCount),
+		reviewCount: s.reviewCount + reviewCount,
+	}
+}
+
+func (s ratingStats) mean() float64 {
+	if s.reviewCount == 0 {
+		return 0
+	}
+	return s.ratingSum / float64(s.reviewCount)
+}
+
+type platformCategory struct {
+	platform   xitem.Platform
+	categoryID string
+}
+
+// ratingPriors is the prior mean of ratings
+// Since ratings tend to differ between platforms (e.g. Rakuten's reviews are more generous) and categories,
+// the prior is the mean of the same platform and category, falling back to the category and then all items.
+type ratingPriors struct {
+	platformCategoryStats map[platformCategory]ratingStats
+	categoryStats         map[string]ratingStats
+	allStats              ratingStats
+}
+
+func newRatingPriors(statsList []*xspanner.ItemRatingStats) *ratingPriors {
+	priors := &ratingPriors{
+		platformCategoryStats: make(map[platformCategory]ratingStats),
+		categoryStats:         make(map[string]ratingStats),
+	}
+	for _, stats := range statsList {
+		s := ratingStats{ratingSum: stats.RatingSum, reviewCount: int(stats.ReviewCount)}
+		key := platformCategory{platform: stats.Platform, categoryID: stats.CategoryID}
+		priors.platformCategoryStats[key] = priors.platformCategoryStats[key].add(s.mean(), s.reviewCount)
+		priors.categoryStats[stats.CategoryID] = priors.categoryStats[stats.CategoryID].add(s.mean(), s.reviewCount)
+		priors.allStats = priors.allStats.add(s.mean(), s.reviewCount)
+	}
+	return priors
+}
+
+func (p *ratingPriors) priorMean(platform xitem.Platform, categoryID string) float64 {
+	if stats := p.platformCategoryStats[platformCategory{platform: platform, categoryID: categoryID}]; stats.reviewCount >= minReviewsForPrior {
+		return stats.mean()
+	}
+	if stats := p.categoryStats[categoryID]; stats.reviewCount >= minReviewsForPrior {
+		return stats.mean()
+	}
+	if p.allStats.reviewCount >= minReviewsForPrior {
+		return p.allStats.mean()
+	}
+	return defaultRatingPrior
+}
+
+// calcBayesianRating returns the average rating with the prior, which is the prior mean when there are no reviews
+func calcBayesianRating(priorMean float64, stats ratingStats) float64 {
+	return (priorMean*ratingPriorWeight + stats.ratingSum) / float64(ratingPriorWeight+stats.reviewCount)
+}
+
+// itemRating is the ratings of the item computed at index time
+type itemRating struct {
+	ratingScore        float64
+	groupAverageRating float64
+	groupReviewCount   int
+}
+
+// calcItemRatings computes ratings of the items from the ratings of the items in the same group
+// groupStatsMap is the stats of the other items in the groups which are not included in items.
+// The ratings of the given siblings, which are the other items in the groups, are returned separately
+// since their group ratings are changed by the items.
+func calcItemRatings(
+	items []*xitem.Item,
+	itemIDGroupIDMap map[string]string,
+	groupStatsMap map[string]ratingStats,
+	siblings []*xspanner.Item,
+	priors *ratingPriors,
+) (map[string]*itemRating, map[string]*itemRating) {
+	groupStats := make(map[string]ratingStats, len(groupStatsMap))
+	for groupID, stats := range groupStatsMap {
+		groupStats[groupID] = stats
+	}
+	for _, item := range items {
+		groupID, ok := itemIDGroupIDMap[item.ID]
+		if !ok || item.ReviewCount == 0 {
+			continue
+		}
+		groupStats[groupID] = groupStats[groupID].add(item.AverageRating, item.ReviewCount)
+	}
+
+	itemRatings := make(map[string]*itemRating, len(items))
+	for _, item := range items {
+		stats := ratingStats{}.add(item.AverageRating, item.ReviewCount)
+		if groupID, ok := itemIDGroupIDMap[item.ID]; ok {
+			stats = groupStats[groupID]
+		}
+		itemRatings[item.ID] = newItemRating(priors.priorMean(item.Platform, item.CategoryID), stats)
+	}
+	siblingRatings := make(map[string]*itemRating, len(siblings))
+	for _, sibling := range siblings {
+		stats := groupStats[sibling.GroupID.StringVal]
+		siblingRatings[sibling.ID] = newItemRating(priors.priorMean(sibling.Platform, sibling.CategoryID), stats)
+	}
+	return itemRatings, siblingRatings
+}
+
+func newItemRating(priorMean float64, groupStats ratingStats) *itemRating {
+	return &itemRating{
+		ratingScore:        calcBayesianRating(priorMean, groupStats),
+		groupAverageRating: groupStats.mean(),
+		groupReviewCount:   groupStats.reviewCount,
+	}
+}
+
+// getItemRatings returns ratings of the items indexed and the other active items in the same groups, the key is the item id
+func (i *ItemIndexer) getItemRatings(ctx context.Context, items []*xitem.Item, itemIDGroupIDMap map[string]string) (map[string]*itemRating, map[string]*itemRating, error) {
+	priors, err := i.getRatingPriors(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	itemIDs := make([]string, 0, len(items))
+	for _, item := range items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	groupIDMap := make(map[string]bool)
+	for _, groupID := range itemIDGroupIDMap {
+		groupIDMap[groupID] = true
+	}
+	groupIDs := make([]string, 0, len(groupIDMap))
+	for groupID := range groupIDMap {
+		groupIDs = append(groupIDs, groupID)
+	}
+	// items indexed now are excluded since they may be not updated yet in Spanner
+	groupStatsList, err := xspanner.GetGroupRatingStats(ctx, i.spannerClient, groupIDs, itemIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("xspanner.GetGroupRatingStats: %w", err)
+	}
+	groupStatsMap := make(map[string]ratingStats, len(groupStatsList))
+	for _, stats := range groupStatsList {
+		groupStatsMap[stats.GroupID] = ratingStats{ratingSum: stats.RatingSum, reviewCount: int(stats.ReviewCount)}
+	}
+	siblings, err := xspanner.GetActiveItemsInGroups(ctx, i.spannerClient, groupIDs, itemIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("xspanner.GetActiveItemsInGroups: %w", err)
+	}
+
+	itemRatings, siblingRatings := calcItemRatings(items, itemIDGroupIDMap, groupStatsMap, siblings, priors)
+	return itemRatings, siblingRatings, nil
+}
+
+func (i *ItemIndexer) getRatingPriors(ctx context.Context) (*ratingPriors, error) {
+	i.ratingPriorsMu.Lock()
+	defer i.ratingPriorsMu.Unlock()
+	if i.ratingPriors != nil && time.Now().Before(i.ratingPriorsExpiresAt) {
+		return i.ratingPriors, nil
+	}
+
+	statsList, err := xspanner.GetItemRatingStatsByPlatformAndCategory(ctx, i.spannerClient)
+	if err != nil {
+		return nil, fmt.Errorf("xspanner.GetItemRatingStatsByPlatformAndCategory: %w", err)
+	}
+	i.ratingPriors = newRatingPriors(statsList)
+	i.ratingPriorsExpiresAt = time.Now().Add(ratingPriorsTTL)
+	return i.ratingPriors, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+func Test_ratingPriors_priorMean(t *testing.T) {
+	t.Parallel()
+
+	priors := newRatingPriors([]*xspanner.ItemRatingStats{
+		{Platform: xitem.PlatformRakuten, CategoryID: "sofa", RatingSum: 4.5 * 200, ReviewCount: 200},
+		{Platform: xitem.PlatformYahooShopping, CategoryID: "sofa", RatingSum: 4.0 * 50, ReviewCount: 50},
+		{Platform: xitem.PlatformYahooShopping, CategoryID: "bed", RatingSum: 3.0 * 100, ReviewCount: 100},
+	})
+
+	tests := []struct {
+		name       string
+		platform   xitem.Platform
+		categoryID string
+		want       float64
+	}{
+		{
+			name:       "mean of the platform and category",
+			platform:   xitem.PlatformRakuten,
+			categoryID: "sofa",
+			want:       4.5,
+		},
+		{
+			name:       "mean of the category when the platform doesn't have enough reviews",
+			platform:   xitem.PlatformYahooShopping,
+			categoryID: "sofa",
+			want:       4.4,
+		},
+		{
+			name:       "mean of the category for the platform without reviews",
+			platform:   xitem.PlatformAmazon,
+			categoryID: "sofa",
+			want:       4.4,
+		},
+		{
+			name:       "mean of all items when the category doesn't have enough reviews",
+			platform:   xitem.PlatformAmazon,
+			categoryID: "table",
+			want:       (4.5*200 + 4.0*50 + 3.0*100) / 350,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := priors.priorMean(tt.platform, tt.categoryID)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("priorMean(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_calcItemRatings(t *testing.T) {
+	t.Parallel()
+
+	priors := newRatingPriors([]*xspanner.ItemRatingStats{
+		{Platform: xitem.PlatformRakuten, CategoryID: "sofa", RatingSum: 4.0 * 1000, ReviewCount: 1000},
+	})
+	items := []*xitem.Item{
+		{ID: "few_reviews", CategoryID: "sofa", Platform: xitem.PlatformRakuten, AverageRating: 5.0, ReviewCount: 1},
+		{ID: "many_reviews", CategoryID: "sofa", Platform: xitem.PlatformRakuten, AverageRating: 4.6, ReviewCount: 2000},
+		{ID: "amazon", CategoryID: "sofa", Platform: xitem.PlatformAmazon},
+		{ID: "amazon_in_group", CategoryID: "sofa", Platform: xitem.PlatformAmazon},
+	}
+	itemIDGroupIDMap := map[string]string{
+		"few_reviews":     "a",
+		"many_reviews":    "b",
+		"amazon":          "c",
+		"amazon_in_group": "d",
+	}
+	groupStatsMap := map[string]ratingStats{
+		"d": {ratingSum: 4.8 * 90, reviewCount: 90},
+	}
+
+	want := map[string]*itemRating{
+		"few_reviews":     {ratingScore: (4.0*10 + 5.0) / 11, groupAverageRating: 5.0, groupReviewCount: 1},
+		"many_reviews":    {ratingScore: (4.0*10 + 4.6*2000) / 2010, groupAverageRating: 4.6, groupReviewCount: 2000},
+		"amazon":          {ratingScore: 4.0},
+		"amazon_in_group": {ratingScore: (4.0*10 + 4.8*90) / 100, groupAverageRating: 4.8, groupReviewCount: 90},
+	}
+	siblings := []*xspanner.Item{
+		{ID: "sibling_of_many_reviews", GroupID: spanner.NullString{StringVal: "b", Valid: true}, CategoryID: "sofa", Platform: xitem.PlatformRakuten},
+		{ID: "sibling_of_amazon_in_group", GroupID: spanner.NullString{StringVal: "d", Valid: true}, CategoryID: "sofa", Platform: xitem.PlatformRakuten},
+	}
+	wantSiblings := map[string]*itemRating{
+		"sibling_of_many_reviews":    {ratingScore: (4.0*10 + 4.6*2000) / 2010, groupAverageRating: 4.6, groupReviewCount: 2000},
+		"sibling_of_amazon_in_group": {ratingScore: (4.0*10 + 4.8*90) / 100, groupAverageRating: 4.8, groupReviewCount: 90},
+	}
+	got, gotSiblings := calcItemRatings(items, itemIDGroupIDMap, groupStatsMap, siblings, priors)
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(itemRating{})); diff != "" {
+		t.Errorf("calcItemRatings(), (-want +got): %s", diff)
+	}
+	if diff := cmp.Diff(wantSiblings, gotSiblings, cmp.AllowUnexported(itemRating{})); diff != "" {
+		t.Errorf("calcItemRatings() siblings, (-want +got): %s", diff)
+	}
+	if got["few_reviews"].ratingScore >= got["many_reviews"].ratingScore {
+		t.Errorf("item with few reviews must be scored lower than item with many reviews")
+	}
+}
@@ -0,0 +1,27 @@
+package main
+
+import "github.com/kelseyhightower/envconfig"
+
+type config struct {
+	GCPProjectID string `envconfig:"GCP_PROJECT_ID"`
+
+	SpannerInstanceID string `envconfig:"SPANNER_INSTANCE_ID"`
+	SpannerDatabaseID string `envconfig:"SPANNER_DATABASE_ID"`
+
+	ElasticSearchUsername string `envconfig:"ELASTICSEARCH_USERNAME"`
+	ElasticSearchPassword string `envconfig:"ELASTICSEARCH_PASSWORD"`
+	ElasticSearchURL      string `default:"http://localhost:9200" envconfig:"ELASTICSEARCH_URL"`
+	// ItemsIndexName is the alias pointing to the items index version used by the API and the indexer
+	ItemsIndexName string `default:"items" envconfig:"ITEMS_INDEX_NAME"`
+
+	ItemsMappingPath   string `default:"defs/elasticsearch/mappings/items.json" envconfig:"ITEMS_MAPPING_PATH"`
+	UserDictionaryPath string `default:"defs/elasticsearch/user_dictionary/furniture.txt" envconfig:"USER_DICTIONARY_PATH"`
+}
+
+func newConfig() (*config, error) {
+	var cfg config
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// userDictionaryPartOfSpeech is the part of speech set to all user dictionary entries
+	userDictionaryPartOfSpeech  = "カスタム名詞"
+	minUserDictionaryTermLength = 2
+)
+
+// userDictionaryEntry is an entry of kuromoji user dictionary
+type userDictionaryEntry struct {
+	Term string
+	// Segmentation is the tokens the term is split into
+	Segmentation []string
+}
+
+// readUserDictionaryFile reads managed user dictionary entries
+// Each line is "<term>[,<segmentation separated by space>]", and lines starting with # are ignored.
+func readUserDictionaryFile(path string) ([]*userDictionaryEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	var entries []*userDictionaryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		columns := strings.SplitN(line, ",", 2)
+		entry := &userDictionaryEntry{Term: strings.TrimSpace(columns[0])}
+		if len(columns) == 2 {
+			entry.Segmentation = strings.Fields(columns[1])
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	return entries, nil
+}
+
+// buildUserDictionaryRules builds kuromoji user dictionary rules in "<term>,<segmentation>,<readings>,<part of speech>" format
+// Terms are normalized in the same way as the normalize char filter since the dictionary is looked up after char filters.
+// ASCII only terms are skipped since kuromoji already keeps alphanumeric sequences as a token.
+// The first entry is prioritized when the same term is given multiple times, since kuromoji rejects duplicated terms.
+func buildUserDictionaryRules(entries []*userDictionaryEntry) []string {
+	seen := make(map[string]bool)
+	var rules []string
+	for _, entry := range entries {
+		term := norm.NFKC.String(strings.TrimSpace(entry.Term))
+		if seen[term] || !isValidUserDictionaryTerm(term) {
+			continue
+		}
+		segmentation := []string{term}
+		if len(entry.Segmentation) > 0 {
+			segmentation = make([]string, 0, len(entry.Segmentation))
+			for _, s := range entry.Segmentation {
+				segmentation = append(segmentation, norm.NFKC.String(s))
+			}
+			// segmentation must be composed of the term's characters
+			if strings.Join(segmentation, "") != term {
+				continue
+			}
+		}
+		seen[term] = true
+		// readings are required but not used, so the segmentation is also used as readings
+		rules = append(rules, fmt.Sprintf(
+			"%s,%s,%s,%s",
+			term,
+			strings.Join(segmentation, " "),
+			strings.Join(segmentation, " "),
+			userDictionaryPartOfSpeech,
+		))
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+func isValidUserDictionaryTerm(term string) bool {
+	if len([]rune(term)) < minUserDictionaryTermLength {
+		return false
+	}
+	isASCII := true
+	for _, r := range term {
+		if r == ',' || r == '"' || r == '#' || unicode.IsSpace(r) {
+			return false
+		}
+		if r > unicode.MaxASCII {
+			isASCII = false
+		}
+	}
+	return !isASCII
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_buildUserDictionaryRules(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []*userDictionaryEntry
+		want    []string
+	}{
+		{
+			name: "builds rules with segmentation",
+			entries: []*userDictionaryEntry{
+				{Term: "すのこベッド", Segmentation: []string{"すのこ", "ベッド"}},
+				{Term: "ニトリ"},
+			},
+			want: []string{
+				"すのこベッド,すのこ ベッド,すのこ ベッド,カスタム名詞",
+				"ニトリ,ニトリ,ニトリ,カスタム名詞",
+			},
+		},
+		{
+			name: "normalizes terms and skips duplicated terms",
+			entries: []*userDictionaryEntry{
+				{Term: "ﾛｰﾃｰﾌﾞﾙ", Segmentation: []string{"ﾛｰ", "ﾃｰﾌﾞﾙ"}},
+				{Term: "ローテーブル"},
+			},
+			want: []string{"ローテーブル,ロー テーブル,ロー テーブル,カスタム名詞"},
+		},
+		{
+			name: "skips invalid terms",
+			entries: []*userDictionaryEntry{
+				{Term: "IKEA"},
+				{Term: "棚"},
+				{Term: "無印 良品"},
+				{Term: "テレビ台", Segmentation: []string{"テレビ", "ボード"}},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := buildUserDictionaryRules(tt.entries)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("buildUserDictionaryRules(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
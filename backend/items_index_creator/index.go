@@ -0,0 +1,443 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/olivere/elastic/v7"
+	"go.uber.org/zap"
+)
+
+// lockOwner is the owner of the lock of the items index held while switching the alias
+const lockOwner = "items_index_creator"
+
+const (
+	defaultTokenizerName        = "kuromoji_tokenizer"
+	userDictionaryTokenizerName = "kuromoji_user_dictionary_tokenizer"
+)
+
+const (
+	// catchUpMargin is subtracted from the creation time of the new version when catching up with the writes
+	// to cover the clock skew between the item indexer and elasticsearch
+	catchUpMargin = 1 * time.Minute
+	// removedItemsCheckBatchSize is the number of items checked at once if they are removed from the current version
+	removedItemsCheckBatchSize = 5000
+)
+
+type indexCreator struct {
+	cfg           *config
+	spannerClient *spanner.Client
+	esClient      *elastic.Client
+	logger        *zap.Logger
+}
+
+func newIndexCreator(cfg *config, spannerClient *spanner.Client, esClient *elastic.Client, logger *zap.Logger) *indexCreator {
+	return &indexCreator{
+		cfg:           cfg,
+		spannerClient: spannerClient,
+		esClient:      esClient,
+		logger:        logger,
+	}
+}
+
+// createIndexVersion creates a new items index version with the user dictionary,
+// and starts reindexing items from the current version asynchronously.
+// The alias is not switched, so the new version can be evaluated before switching.
+// The item indexer keeps writing to the current version, and the writes are caught up with when switching.
+func (c *indexCreator) createIndexVersion(ctx context.Context) (string, error) {
+	rules, err := c.buildUserDictionaryRules(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.logger.Info("user dictionary is built", zap.Int("rules", len(rules)))
+
+	synonyms, err := c.getCurrentSynonyms(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	mapping, err := ioutil.ReadFile(c.cfg.ItemsMappingPath)
+	if err != nil {
+		return "", fmt.Errorf("ioutil.ReadFile: %w", err)
+	}
+	body, err := buildItemsIndexBody(mapping, rules, synonyms)
+	if err != nil {
+		return "", err
+	}
+
+	indexName := es.IndexVersionName(c.cfg.ItemsIndexName, time.Now())
+	if _, err := c.esClient.CreateIndex(indexName).BodyJson(body).Do(ctx); err != nil {
+		return "", fmt.Errorf("esClient.CreateIndex: %w", err)
+	}
+	c.logger.Info("index is created", zap.String("index", indexName))
+
+	exists, err := c.esClient.IndexExists(c.cfg.ItemsIndexName).Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("esClient.IndexExists: %w", err)
+	}
+	if !exists {
+		return indexName, nil
+	}
+	task, err := c.esClient.Reindex().
+		SourceIndex(c.cfg.ItemsIndexName).
+		DestinationIndex(indexName).
+		DoAsync(ctx)
+	if err != nil {
+		return "", fmt.Errorf("esClient.Reindex: %w", err)
+	}
+	c.logger.Info("reindex is started", zap.String("taskID", task.TaskId))
+	return indexName, nil
+}
+
+// switchAlias points the alias to the given index version
+// Writes to the current version are blocked to pause the item indexer during the cutover,
+// and the blocked updates are redelivered by Pub/Sub and written to the new version after the switch.
+// Before the switch, the writes made to the current version since the new version was created are caught up with.
+// When the alias is still a concrete index created before versioning, the index is replaced with the alias.
+func (c *indexCreator) switchAlias(ctx context.Context, indexName string) (err error) {
+	alias := c.cfg.ItemsIndexName
+	// the lock is shared with the API applying synonyms, so the synonyms are not changed during the switch
+	release, err := es.AcquireLock(ctx, c.esClient, alias, lockOwner)
+	if err != nil {
+		return fmt.Errorf("es.AcquireLock: %w", err)
+	}
+	defer func() {
+		if err := release(context.Background()); err != nil {
+			c.logger.Error("failed to release the lock", zap.Error(err))
+		}
+	}()
+
+	reindexing, err := c.isReindexing(ctx, indexName)
+	if err != nil {
+		return err
+	}
+	if reindexing {
+		return fmt.Errorf("reindex to '%s' is still running", indexName)
+	}
+
+	exists, err := c.esClient.IndexExists(alias).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("esClient.IndexExists: %w", err)
+	}
+	if !exists {
+		if _, err := c.esClient.Alias().Add(indexName, alias).Do(ctx); err != nil {
+			return fmt.Errorf("esClient.Alias: %w", err)
+		}
+		c.logger.Info("alias is created", zap.String("alias", alias), zap.String("index", indexName))
+		return nil
+	}
+
+	isConcreteIndex := false
+	currentIndex, err := es.GetAliasedIndex(ctx, c.esClient, alias)
+	if errors.Is(err, es.ErrNotAlias) {
+		isConcreteIndex = true
+		currentIndex = alias
+	} else if err != nil {
+		return fmt.Errorf("es.GetAliasedIndex: %w", err)
+	}
+
+	if err := c.syncSynonyms(ctx, currentIndex, indexName); err != nil {
+		return err
+	}
+
+	if err := es.BlockWrites(ctx, c.esClient, currentIndex, true); err != nil {
+		return fmt.Errorf("es.BlockWrites: %w", err)
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		// the current version must accept writes again when the switch failed
+		if unblockErr := es.BlockWrites(context.Background(), c.esClient, currentIndex, false); unblockErr != nil {
+			c.logger.Error("es.BlockWrites failed", zap.Error(unblockErr), zap.String("index", currentIndex))
+		}
+	}()
+
+	if err := c.catchUp(ctx, currentIndex, indexName); err != nil {
+		return err
+	}
+
+	if isConcreteIndex {
+		// the concrete index is deleted and replaced with the alias atomically
+		_, err := c.esClient.Alias().Action(
+			elastic.NewAliasRemoveIndexAction(currentIndex),
+			elastic.NewAliasAddAction(alias).Index(indexName),
+		).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("esClient.Alias: %w", err)
+		}
+		c.logger.Info("index is migrated to alias", zap.String("alias", alias), zap.String("index", indexName))
+		return nil
+	}
+
+	if err := es.SwapAlias(ctx, c.esClient, alias, currentIndex, indexName); err != nil {
+		return fmt.Errorf("es.SwapAlias: %w", err)
+	}
+	// the retired version is kept until the next switch for the cursors using the point in time on it
+	deletedIndices, err := es.DeleteRetiredIndexVersions(ctx, c.esClient, alias, currentIndex)
+	if err != nil {
+		c.logger.Error("es.DeleteRetiredIndexVersions failed", zap.Error(err))
+		return nil
+	}
+	c.logger.Info(
+		"alias is switched",
+		zap.String("alias", alias),
+		zap.String("index", indexName),
+		zap.String("retiredIndex", currentIndex),
+		zap.Strings("deletedIndices", deletedIndices),
+	)
+	return nil
+}
+
+// syncSynonyms updates the synonyms of the new version to the ones of the current version
+// Synonyms are copied when the new version is created, but they might be applied again while evaluating the new version.
+func (c *indexCreator) syncSynonyms(ctx context.Context, currentIndex, newIndex string) error {
+	currentSynonyms, err := es.GetSynonyms(ctx, c.esClient, currentIndex)
+	if err != nil {
+		return fmt.Errorf("es.GetSynonyms: %w", err)
+	}
+	newSynonyms, err := es.GetSynonyms(ctx, c.esClient, newIndex)
+	if err != nil {
+		return fmt.Errorf("es.GetSynonyms: %w", err)
+	}
+	if es.IsSameSynonyms(currentSynonyms, newSynonyms) {
+		return nil
+	}
+	// the new version is not used by searches yet, so closing it while updating doesn't matter
+	if err := es.UpdateSynonyms(ctx, c.esClient, newIndex, currentSynonyms); err != nil {
+		return fmt.Errorf("es.UpdateSynonyms: %w", err)
+	}
+	c.logger.Info("synonyms are updated", zap.String("index", newIndex), zap.Int("rules", len(currentSynonyms)))
+	return nil
+}
+
+// isReindexing checks if the reindex to the index started by createIndexVersion is still running
+func (c *indexCreator) isReindexing(ctx context.Context, indexName string) (bool, error) {
+	resp, err := c.esClient.TasksList().Actions("indices:data/write/reindex").Detailed(true).Do(ctx)
+	if err != nil {
+		return false, fmt.Errorf("esClient.TasksList: %w", err)
+	}
+	for _, node := range resp.Nodes {
+		for _, task := range node.Tasks {
+			// description is "reindex from [<source>] to [<destination>]"
+			if description, ok := task.Description.(string); ok && strings.Contains(description, fmt.Sprintf("to [%s]", indexName)) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// catchUp copies the items indexed into the current version after the new version was created,
+// and deletes the items deleted from the current version meanwhile.
+func (c *indexCreator) catchUp(ctx context.Context, currentIndex, newIndex string) error {
+	createdAt, err := c.getIndexCreationTime(ctx, newIndex)
+	if err != nil {
+		return err
+	}
+	query := elastic.NewRangeQuery(es.ItemFieldIndexedAt).Gte(createdAt.Add(-catchUpMargin).UnixMilli())
+	if err := es.Reindex(ctx, c.esClient, currentIndex, newIndex, query); err != nil {
+		return fmt.Errorf("es.Reindex: %w", err)
+	}
+
+	currentCount, err := c.esClient.Count(currentIndex).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("esClient.Count: %w", err)
+	}
+	newCount, err := c.esClient.Count(newIndex).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("esClient.Count: %w", err)
+	}
+	// the new version has all items in the current version, so the extra items are the deleted ones
+	if newCount < currentCount {
+		return fmt.Errorf("'%s' has %d items out of %d items, the reindex might have failed", newIndex, newCount, currentCount)
+	}
+	if newCount > currentCount {
+		deletedCount, err := c.deleteRemovedItems(ctx, currentIndex, newIndex)
+		if err != nil {
+			return err
+		}
+		c.logger.Info("removed items are deleted", zap.Int("count", deletedCount))
+	}
+	c.logger.Info("writes are caught up with", zap.String("index", newIndex), zap.Time("since", createdAt.Add(-catchUpMargin)))
+	return nil
+}
+
+// deleteRemovedItems deletes the items not found in the current version from the new version
+func (c *indexCreator) deleteRemovedItems(ctx context.Context, currentIndex, newIndex string) (int, error) {
+	scroll := c.esClient.Scroll(newIndex).FetchSource(false).Size(removedItemsCheckBatchSize)
+	defer func() { _ = scroll.Clear(context.Background()) }()
+
+	deletedCount := 0
+	for {
+		res, err := scroll.Do(ctx)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("scroll.Do: %w", err)
+		}
+		ids := make([]string, 0, len(res.Hits.Hits))
+		for _, hit := range res.Hits.Hits {
+			ids = append(ids, hit.Id)
+		}
+
+		existingRes, err := c.esClient.Search(currentIndex).
+			Query(elastic.NewIdsQuery().Ids(ids...)).
+			FetchSource(false).
+			Size(len(ids)).
+			Do(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("esClient.Search: %w", err)
+		}
+		existingIDs := make(map[string]bool, len(existingRes.Hits.Hits))
+		for _, hit := range existingRes.Hits.Hits {
+			existingIDs[hit.Id] = true
+		}
+
+		bulk := c.esClient.Bulk().Index(newIndex)
+		for _, id := range ids {
+			if !existingIDs[id] {
+				bulk.Add(elastic.NewBulkDeleteRequest().Id(id))
+			}
+		}
+		if bulk.NumberOfActions() == 0 {
+			continue
+		}
+		bulkResp, err := bulk.Do(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("bulk.Do: %w", err)
+		}
+		if failed := bulkResp.Failed(); len(failed) > 0 {
+			return 0, fmt.Errorf("deleting %d items failed, e.g. id: %s, status: %d", len(failed), failed[0].Id, failed[0].Status)
+		}
+		deletedCount += len(bulkResp.Deleted())
+	}
+
+	if _, err := c.esClient.Refresh(newIndex).Do(ctx); err != nil {
+		return 0, fmt.Errorf("esClient.Refresh: %w", err)
+	}
+	return deletedCount, nil
+}
+
+// getIndexCreationTime returns the time the index was created
+func (c *indexCreator) getIndexCreationTime(ctx context.Context, indexName string) (time.Time, error) {
+	resp, err := c.esClient.IndexGetSettings(indexName).Do(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("esClient.IndexGetSettings: %w", err)
+	}
+	settings, ok := resp[indexName]
+	if !ok {
+		return time.Time{}, fmt.Errorf("settings of '%s' are not found", indexName)
+	}
+	creationDate, _ := getNestedValue(settings.Settings, "index", "creation_date").(string)
+	creationMillis, err := strconv.ParseInt(creationDate, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("strconv.ParseInt: %w", err)
+	}
+	return time.UnixMilli(creationMillis), nil
+}
+
+func (c *indexCreator) buildUserDictionaryRules(ctx context.Context) ([]string, error) {
+	// managed entries are prioritized since their segmentation is explicitly defined
+	entries, err := readUserDictionaryFile(c.cfg.UserDictionaryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	brandNames, err := xspanner.GetAllBrandNames(ctx, c.spannerClient)
+	if err != nil {
+		return nil, fmt.Errorf("xspanner.GetAllBrandNames: %w", err)
+	}
+	for _, brandName := range brandNames {
+		entries = append(entries, &userDictionaryEntry{Term: brandName})
+	}
+
+	categories, err := xspanner.GetAllActiveItemCategories(ctx, c.spannerClient)
+	if err != nil {
+		return nil, fmt.Errorf("xspanner.GetAllActiveItemCategories: %w", err)
+	}
+	for _, category := range categories {
+		for _, term := range xitem.SplitCategoryName(category.Name) {
+			entries = append(entries, &userDictionaryEntry{Term: term})
+		}
+	}
+
+	return buildUserDictionaryRules(entries), nil
+}
+
+// getCurrentSynonyms gets synonyms applied to the current version to inherit them to the new version
+func (c *indexCreator) getCurrentSynonyms(ctx context.Context) ([]interface{}, error) {
+	resp, err := c.esClient.IndexGetSettings(c.cfg.ItemsIndexName).Do(ctx)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("esClient.IndexGetSettings: %w", err)
+	}
+	for _, settings := range resp {
+		synonyms, _ := getNestedValue(settings.Settings, "index", "analysis", "filter", es.ItemsSynonymFilterName, "synonyms").([]interface{})
+		return synonyms, nil
+	}
+	return nil, nil
+}
+
+// buildItemsIndexBody builds the request body to create items index
+// from the mapping file with the user dictionary and synonyms.
+func buildItemsIndexBody(mapping []byte, userDictionaryRules []string, synonyms []interface{}) (map[string]interface{}, error) {
+	var body map[string]interface{}
+	if err := json.Unmarshal(mapping, &body); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	analysis, ok := getNestedValue(body, "settings", "analysis").(map[string]interface{})
+	if !ok {
+		return nil, errors.New("settings.analysis is not found in the mapping")
+	}
+
+	tokenizers, ok := analysis["tokenizer"].(map[string]interface{})
+	if !ok {
+		tokenizers = make(map[string]interface{})
+		analysis["tokenizer"] = tokenizers
+	}
+	tokenizers[userDictionaryTokenizerName] = map[string]interface{}{
+		"type":                  "kuromoji_tokenizer",
+		"mode":                  "search",
+		"user_dictionary_rules": userDictionaryRules,
+	}
+
+	analyzers, _ := analysis["analyzer"].(map[string]interface{})
+	for _, a := range analyzers {
+		analyzer, ok := a.(map[string]interface{})
+		if ok && analyzer["tokenizer"] == defaultTokenizerName {
+			analyzer["tokenizer"] = userDictionaryTokenizerName
+		}
+	}
+
+	if synonymFilter, ok := getNestedValue(analysis, "filter", es.ItemsSynonymFilterName).(map[string]interface{}); ok && len(synonyms) > 0 {
+		synonymFilter["synonyms"] = synonyms
+	}
+	return body, nil
+}
+
+func getNestedValue(m map[string]interface{}, keys ...string) interface{} {
+	var v interface{} = m
+	for _, key := range keys {
+		current, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = current[key]
+	}
+	return v
+}
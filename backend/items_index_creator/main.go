@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"cloud.google.com/go/spanner"
+	"github.com/blendle/zapdriver"
+	"github.com/k-yomo/kagu-miru/backend/pkg/spannerutil"
+	"github.com/olivere/elastic/v7"
+	esconfig "github.com/olivere/elastic/v7/config"
+	"go.uber.org/zap"
+)
+
+// items_index_creator creates a new version of items index with the user dictionary built from the latest vocabulary,
+// and reindexes items into it. The created index name is printed, and the alias can be switched to it
+// with -switch-alias flag after the new version is evaluated.
+// On switching, the item indexer is paused by blocking writes to the current version, the writes made since
+// the new version was created are copied to it, and the blocked updates are indexed into it after the switch.
+// The synonyms applied while evaluating the new version are copied to it before the switch, and the switch holds
+// the lock shared with the API applying synonyms, so that the synonyms are not changed during the switch.
+// The first switch replaces the items index created before versioning with the alias, and deletes the index.
+func main() {
+	switchAliasTo := flag.String("switch-alias", "", "index name to switch the items alias to")
+	flag.Parse()
+
+	logger, err := zapdriver.NewProduction()
+	if err != nil {
+		panic(err)
+	}
+
+	cfg, err := newConfig()
+	if err != nil {
+		logger.Fatal("failed to initialize config", zap.Error(err))
+	}
+
+	esClient, err := elastic.NewClientFromConfig(&esconfig.Config{
+		URL:      cfg.ElasticSearchURL,
+		Username: cfg.ElasticSearchUsername,
+		Password: cfg.ElasticSearchPassword,
+		Sniff:    func() *bool { f := false; return &f }(),
+	})
+	if err != nil {
+		logger.Fatal("failed to initialize elasticsearch client", zap.Error(err))
+	}
+
+	ctx := context.Background()
+	if *switchAliasTo != "" {
+		if err := newIndexCreator(cfg, nil, esClient, logger).switchAlias(ctx, *switchAliasTo); err != nil {
+			logger.Fatal("failed to switch alias", zap.Error(err))
+		}
+		return
+	}
+
+	spannerClient, err := spanner.NewClient(
+		ctx,
+		spannerutil.BuildSpannerDBPath(cfg.GCPProjectID, cfg.SpannerInstanceID, cfg.SpannerDatabaseID),
+	)
+	if err != nil {
+		logger.Fatal("failed to initialize spanner client", zap.Error(err))
+	}
+	defer spannerClient.Close()
+
+	indexName, err := newIndexCreator(cfg, spannerClient, esClient, logger).createIndexVersion(ctx)
+	if err != nil {
+		logger.Fatal("failed to create index version", zap.Error(err))
+	}
+	_, _ = fmt.Fprintln(os.Stdout, indexName)
+}
@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+type Experiment struct {
+	ID                string          `json:"id"`
+	Name              string          `json:"name"`
+	Variants          json.RawMessage `json:"variants"`
+	TrafficAllocation float64         `json:"trafficAllocation"`
+	StartsAt          time.Time       `json:"startsAt"`
+	EndsAt            time.Time       `json:"endsAt"`
+	UpdatedAt         time.Time       `json:"updatedAt"`
+}
+
+type saveExperimentRequest struct {
+	Name string `json:"name"`
+	// Variants is the array of the variants e.g. [{"name": "control", "weight": 1}, {"name": "treatment", "weight": 1, "searchRankingProfile": "new"}]
+	Variants json.RawMessage `json:"variants"`
+	// TrafficAllocation is the ratio of users in the experiment from 0 to 1
+	TrafficAllocation float64   `json:"trafficAllocation"`
+	StartsAt          time.Time `json:"startsAt"`
+	// EndsAt can be set to the current time to stop the experiment
+	EndsAt time.Time `json:"endsAt"`
+}
+
+func (h *Handler) listExperiments(w http.ResponseWriter, r *http.Request) {
+	experiments, err := h.experimentClient.GetAllExperiments(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	resp := make([]*Experiment, 0, len(experiments))
+	for _, experiment := range experiments {
+		resp = append(resp, mapSpannerExperimentToExperiment(experiment))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// saveExperiment creates or updates the experiment
+// Changing variants or weights of the running experiment moves users between variants, so it should be avoided.
+func (h *Handler) saveExperiment(w http.ResponseWriter, r *http.Request) {
+	var req saveExperimentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, xerror.NewInvalidArgument(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	experiment, err := h.experimentClient.SaveExperiment(r.Context(), &xspanner.Experiment{
+		ID:                chi.URLParam(r, "experimentID"),
+		Name:              req.Name,
+		Variants:          string(req.Variants),
+		TrafficAllocation: req.TrafficAllocation,
+		StartsAt:          req.StartsAt,
+		EndsAt:            req.EndsAt,
+	})
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSpannerExperimentToExperiment(experiment))
+}
+
+func (h *Handler) deleteExperiment(w http.ResponseWriter, r *http.Request) {
+	if err := h.experimentClient.DeleteExperiment(r.Context(), chi.URLParam(r, "experimentID")); err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func mapSpannerExperimentToExperiment(experiment *xspanner.Experiment) *Experiment {
+	return &Experiment{
+		ID:                experiment.ID,
+		Name:              experiment.Name,
+		Variants:          json.RawMessage(experiment.Variants),
+		TrafficAllocation: experiment.TrafficAllocation,
+		StartsAt:          experiment.StartsAt,
+		EndsAt:            experiment.EndsAt,
+		UpdatedAt:         experiment.UpdatedAt,
+	}
+}
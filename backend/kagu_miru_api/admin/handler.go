@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/experiment"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/merchandising"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/synonym"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.uber.org/zap"
+)
+
+// Handler serves admin API to manage search settings
+// Admin API is authenticated with a static bearer token since it's used only by operators.
+type Handler struct {
+	apiToken            string
+	synonymClient       synonym.Client
+	merchandisingClient merchandising.Client
+	rankingClient       ranking.Client
+	experimentClient    experiment.Client
+}
+
+func NewHandler(
+	apiToken string,
+	synonymClient synonym.Client,
+	merchandisingClient merchandising.Client,
+	rankingClient ranking.Client,
+	experimentClient experiment.Client,
+) *Handler {
+	return &Handler{
+		apiToken:            apiToken,
+		synonymClient:       synonymClient,
+		merchandisingClient: merchandisingClient,
+		rankingClient:       rankingClient,
+		experimentClient:    experimentClient,
+	}
+}
+
+// Routes returns the router for admin API
+func (h *Handler) Routes() chi.Router {
+	r := chi.NewRouter()
+	r.Use(h.authenticate)
+	r.Route("/synonym_sets", func(r chi.Router) {
+		r.Get("/", h.listSynonymSets)
+		r.Post("/apply", h.applySynonyms)
+		r.Put("/{synonymSetID}", h.saveSynonymSet)
+		r.Get("/{synonymSetID}/histories", h.listSynonymSetHistories)
+		r.Post("/{synonymSetID}/rollback", h.rollbackSynonymSet)
+	})
+	r.Route("/merchandising_rules", func(r chi.Router) {
+		r.Get("/", h.listMerchandisingRules)
+		r.Put("/{ruleID}", h.saveMerchandisingRule)
+		r.Delete("/{ruleID}", h.deleteMerchandisingRule)
+	})
+	r.Route("/ranking_profiles", func(r chi.Router) {
+		r.Get("/", h.listRankingProfiles)
+		r.Put("/{name}", h.saveRankingProfile)
+		r.Get("/{name}/versions", h.listRankingProfileVersions)
+	})
+	r.Route("/experiments", func(r chi.Router) {
+		r.Get("/", h.listExperiments)
+		r.Put("/{experimentID}", h.saveExperiment)
+		r.Delete("/{experimentID}", h.deleteExperiment)
+	})
+	return r
+}
+
+func (h *Handler) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if h.apiToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.apiToken)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// handleError writes error response with the status code corresponding to the error type
+func handleError(w http.ResponseWriter, r *http.Request, err error) {
+	switch xerror.ErrorType(err) {
+	case xerror.TypeNotFound:
+		writeError(w, http.StatusNotFound, err)
+	case xerror.TypeInvalidArgument:
+		writeError(w, http.StatusBadRequest, err)
+	case xerror.TypeConflict:
+		writeError(w, http.StatusConflict, err)
+	default:
+		logging.Logger(r.Context()).Error("admin api request failed", zap.Error(err))
+		writeError(w, http.StatusInternalServerError, errors.New("internal error"))
+	}
+}
@@ -0,0 +1,121 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/go-chi/chi/v5"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+type MerchandisingRule struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Query          *string   `json:"query"`
+	CategoryID     *string   `json:"categoryId"`
+	PinnedItemIDs  []string  `json:"pinnedItemIds"`
+	PinnedGroupIDs []string  `json:"pinnedGroupIds"`
+	BuriedItemIDs  []string  `json:"buriedItemIds"`
+	BuriedGroupIDs []string  `json:"buriedGroupIds"`
+	StartsAt       time.Time `json:"startsAt"`
+	EndsAt         time.Time `json:"endsAt"`
+	IsActive       bool      `json:"isActive"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+type saveMerchandisingRuleRequest struct {
+	Name string `json:"name"`
+	// Query is normalized on save, the rule is applied to any query when null
+	Query *string `json:"query"`
+	// CategoryID is the category filtered, the rule is applied to any category when null
+	CategoryID     *string   `json:"categoryId"`
+	PinnedItemIDs  []string  `json:"pinnedItemIds"`
+	PinnedGroupIDs []string  `json:"pinnedGroupIds"`
+	BuriedItemIDs  []string  `json:"buriedItemIds"`
+	BuriedGroupIDs []string  `json:"buriedGroupIds"`
+	StartsAt       time.Time `json:"startsAt"`
+	EndsAt         time.Time `json:"endsAt"`
+	IsActive       bool      `json:"isActive"`
+}
+
+func (h *Handler) listMerchandisingRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.merchandisingClient.GetAllRules(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	resp := make([]*MerchandisingRule, 0, len(rules))
+	for _, rule := range rules {
+		resp = append(resp, mapSpannerMerchandisingRuleToMerchandisingRule(rule))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) saveMerchandisingRule(w http.ResponseWriter, r *http.Request) {
+	var req saveMerchandisingRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, xerror.NewInvalidArgument(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	rule, err := h.merchandisingClient.SaveRule(r.Context(), &xspanner.MerchandisingRule{
+		ID:             chi.URLParam(r, "ruleID"),
+		Name:           req.Name,
+		Query:          mapStringPointerToNullString(req.Query),
+		CategoryID:     mapStringPointerToNullString(req.CategoryID),
+		PinnedItemIDs:  req.PinnedItemIDs,
+		PinnedGroupIDs: req.PinnedGroupIDs,
+		BuriedItemIDs:  req.BuriedItemIDs,
+		BuriedGroupIDs: req.BuriedGroupIDs,
+		StartsAt:       req.StartsAt,
+		EndsAt:         req.EndsAt,
+		IsActive:       req.IsActive,
+	})
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSpannerMerchandisingRuleToMerchandisingRule(rule))
+}
+
+func (h *Handler) deleteMerchandisingRule(w http.ResponseWriter, r *http.Request) {
+	if err := h.merchandisingClient.DeleteRule(r.Context(), chi.URLParam(r, "ruleID")); err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func mapSpannerMerchandisingRuleToMerchandisingRule(rule *xspanner.MerchandisingRule) *MerchandisingRule {
+	return &MerchandisingRule{
+		ID:             rule.ID,
+		Name:           rule.Name,
+		Query:          mapNullStringToStringPointer(rule.Query),
+		CategoryID:     mapNullStringToStringPointer(rule.CategoryID),
+		PinnedItemIDs:  rule.PinnedItemIDs,
+		PinnedGroupIDs: rule.PinnedGroupIDs,
+		BuriedItemIDs:  rule.BuriedItemIDs,
+		BuriedGroupIDs: rule.BuriedGroupIDs,
+		StartsAt:       rule.StartsAt,
+		EndsAt:         rule.EndsAt,
+		IsActive:       rule.IsActive,
+		UpdatedAt:      rule.UpdatedAt,
+	}
+}
+
+func mapStringPointerToNullString(s *string) spanner.NullString {
+	if s == nil {
+		return spanner.NullString{}
+	}
+	return spanner.NullString{StringVal: *s, Valid: true}
+}
+
+func mapNullStringToStringPointer(s spanner.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.StringVal
+}
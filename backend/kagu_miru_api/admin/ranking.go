@@ -0,0 +1,75 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+type RankingProfile struct {
+	Name      string          `json:"name"`
+	Version   int64           `json:"version"`
+	Config    json.RawMessage `json:"config"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type saveRankingProfileRequest struct {
+	// Config is saved as the new version of the profile
+	Config json.RawMessage `json:"config"`
+}
+
+func (h *Handler) listRankingProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.rankingClient.GetAllProfiles(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSpannerRankingProfilesToRankingProfiles(profiles))
+}
+
+func (h *Handler) listRankingProfileVersions(w http.ResponseWriter, r *http.Request) {
+	profiles, err := h.rankingClient.GetProfileVersions(r.Context(), chi.URLParam(r, "name"))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSpannerRankingProfilesToRankingProfiles(profiles))
+}
+
+// saveRankingProfile saves the new version of the profile, which is used in search within a minute
+// Rollback can be done by saving the config of the previous version again.
+func (h *Handler) saveRankingProfile(w http.ResponseWriter, r *http.Request) {
+	var req saveRankingProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, xerror.NewInvalidArgument(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	profile, err := h.rankingClient.SaveProfile(r.Context(), chi.URLParam(r, "name"), string(req.Config))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSpannerRankingProfileToRankingProfile(profile))
+}
+
+func mapSpannerRankingProfilesToRankingProfiles(profiles []*xspanner.RankingProfile) []*RankingProfile {
+	resp := make([]*RankingProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		resp = append(resp, mapSpannerRankingProfileToRankingProfile(profile))
+	}
+	return resp
+}
+
+func mapSpannerRankingProfileToRankingProfile(profile *xspanner.RankingProfile) *RankingProfile {
+	return &RankingProfile{
+		Name:      profile.Name,
+		Version:   profile.Version,
+		Config:    json.RawMessage(profile.Config),
+		CreatedAt: profile.CreatedAt,
+	}
+}
@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+type SynonymSet struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Synonyms  []string  `json:"synonyms"`
+	IsActive  bool      `json:"isActive"`
+	Version   int64     `json:"version"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type SynonymSetHistory struct {
+	Version   int64     `json:"version"`
+	Name      string    `json:"name"`
+	Synonyms  []string  `json:"synonyms"`
+	IsActive  bool      `json:"isActive"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type saveSynonymSetRequest struct {
+	Name     string   `json:"name"`
+	Synonyms []string `json:"synonyms"`
+	IsActive bool     `json:"isActive"`
+}
+
+type rollbackSynonymSetRequest struct {
+	Version int64 `json:"version"`
+}
+
+func (h *Handler) listSynonymSets(w http.ResponseWriter, r *http.Request) {
+	synonymSets, err := h.synonymClient.GetAllSynonymSets(r.Context())
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	resp := make([]*SynonymSet, 0, len(synonymSets))
+	for _, synonymSet := range synonymSets {
+		resp = append(resp, mapSpannerSynonymSetToSynonymSet(synonymSet))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) saveSynonymSet(w http.ResponseWriter, r *http.Request) {
+	var req saveSynonymSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, xerror.NewInvalidArgument(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	synonymSet, err := h.synonymClient.SaveSynonymSet(r.Context(), &xspanner.SynonymSet{
+		ID:       chi.URLParam(r, "synonymSetID"),
+		Name:     req.Name,
+		Synonyms: req.Synonyms,
+		IsActive: req.IsActive,
+	})
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSpannerSynonymSetToSynonymSet(synonymSet))
+}
+
+func (h *Handler) listSynonymSetHistories(w http.ResponseWriter, r *http.Request) {
+	histories, err := h.synonymClient.GetSynonymSetHistories(r.Context(), chi.URLParam(r, "synonymSetID"))
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	resp := make([]*SynonymSetHistory, 0, len(histories))
+	for _, history := range histories {
+		resp = append(resp, &SynonymSetHistory{
+			Version:   history.Version,
+			Name:      history.Name,
+			Synonyms:  history.Synonyms,
+			IsActive:  history.IsActive,
+			CreatedAt: history.CreatedAt,
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *Handler) rollbackSynonymSet(w http.ResponseWriter, r *http.Request) {
+	var req rollbackSynonymSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		handleError(w, r, xerror.NewInvalidArgument(fmt.Errorf("invalid request body: %w", err)))
+		return
+	}
+	synonymSet, err := h.synonymClient.RollbackSynonymSet(r.Context(), chi.URLParam(r, "synonymSetID"), req.Version)
+	if err != nil {
+		handleError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, mapSpannerSynonymSetToSynonymSet(synonymSet))
+}
+
+// applySynonyms applies synonyms again, which is used when applying synonyms to the index failed after saving
+func (h *Handler) applySynonyms(w http.ResponseWriter, r *http.Request) {
+	if err := h.synonymClient.ApplySynonyms(r.Context()); err != nil {
+		handleError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func mapSpannerSynonymSetToSynonymSet(synonymSet *xspanner.SynonymSet) *SynonymSet {
+	return &SynonymSet{
+		ID:        synonymSet.ID,
+		Name:      synonymSet.Name,
+		Synonyms:  synonymSet.Synonyms,
+		IsActive:  synonymSet.IsActive,
+		Version:   synonymSet.Version,
+		UpdatedAt: synonymSet.UpdatedAt,
+	}
+}
@@ -19,6 +19,10 @@ type Config struct {
 	Env            Env      `default:"local" envconfig:"APP_ENV"`
 	Port           int      `default:"8000" envconfig:"PORT"`
 	AllowedOrigins []string `default:"http://localhost:3000,http://localhost:3333" envconfig:"ALLOWED_ORIGINS"`
+	// AdminAPIToken is a bearer token for admin API, admin API is disabled when empty
+	AdminAPIToken string `envconfig:"ADMIN_API_TOKEN"`
+	// SearchDebugToken allows search debug mode in prod with X-Search-Debug-Token header, disabled when empty
+	SearchDebugToken string `envconfig:"SEARCH_DEBUG_TOKEN"`
 
 	GCPProjectID       string `default:"local" envconfig:"GCP_PROJECT_ID"`
 	PubSubEventTopicID string `envconfig:"PUBSUB_EVENT_TOPIC_ID"`
@@ -26,7 +30,10 @@ type Config struct {
 	SpannerInstanceID string `envconfig:"SPANNER_INSTANCE_ID"`
 	SpannerDatabaseID string `envconfig:"SPANNER_DATABASE_ID"`
 
+	// VertexAICategoryClassificationEndpointID is optional, Vertex AI is used only when the local query classifier predicts nothing
 	VertexAICategoryClassificationEndpointID string `envconfig:"VERTEX_AI_CATEGORY_CLASSIFICATION_ENDPOINT_ID"`
+	// BigQueryEventsTable is the fully qualified table name of tracking events to use click-through for query classification
+	BigQueryEventsTable string `envconfig:"BIGQUERY_EVENTS_TABLE"`
 
 	SanityProjectID string `envconfig:"SANITY_PROJECT_ID"`
 	SanityDatasetID string `default:"development" envconfig:"SANITY_DATASET_ID"`
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
 )
@@ -12,4 +13,21 @@ type Client interface {
 	GetAllActiveItemCategories(ctx context.Context) ([]*xspanner.ItemCategory, error)
 	GetAllItemCategoriesWithParent(ctx context.Context) ([]*xspanner.ItemCategoryWithParent, error)
 	GetTopLevelItemCategories(ctx context.Context) ([]*xspanner.ItemCategory, error)
+	GetCategoryIDMapByItemIDs(ctx context.Context, itemIDs []string) (map[string]string, error)
+	GetAllSynonymSets(ctx context.Context) ([]*xspanner.SynonymSet, error)
+	GetSynonymSetHistories(ctx context.Context, synonymSetID string) ([]*xspanner.SynonymSetHistory, error)
+	SaveSynonymSet(ctx context.Context, synonymSet *xspanner.SynonymSet) (*xspanner.SynonymSet, error)
+	RollbackSynonymSet(ctx context.Context, synonymSetID string, version int64) (*xspanner.SynonymSet, error)
+	GetItemIDsMapByGroupIDs(ctx context.Context, groupIDs []string) (map[string][]string, error)
+	GetAllMerchandisingRules(ctx context.Context) ([]*xspanner.MerchandisingRule, error)
+	GetUnexpiredMerchandisingRules(ctx context.Context, now time.Time) ([]*xspanner.MerchandisingRule, error)
+	SaveMerchandisingRule(ctx context.Context, rule *xspanner.MerchandisingRule) (*xspanner.MerchandisingRule, error)
+	DeleteMerchandisingRule(ctx context.Context, ruleID string) error
+	GetLatestRankingProfiles(ctx context.Context) ([]*xspanner.RankingProfile, error)
+	GetRankingProfileVersions(ctx context.Context, name string) ([]*xspanner.RankingProfile, error)
+	InsertRankingProfileVersion(ctx context.Context, name string, config string) (*xspanner.RankingProfile, error)
+	GetAllExperiments(ctx context.Context) ([]*xspanner.Experiment, error)
+	GetUnexpiredExperiments(ctx context.Context, now time.Time) ([]*xspanner.Experiment, error)
+	SaveExperiment(ctx context.Context, experiment *xspanner.Experiment) (*xspanner.Experiment, error)
+	DeleteExperiment(ctx context.Context, experimentID string) error
 }
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"cloud.google.com/go/spanner"
 	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
@@ -35,3 +36,71 @@ func (s *SpannerDBClient) GetTopLevelItemCategories(ctx context.Context) ([]*xsp
 	return xspanner.GetTopLevelItemCategories(ctx, s.spannerClient)
 
 }
+
+func (s *SpannerDBClient) GetCategoryIDMapByItemIDs(ctx context.Context, itemIDs []string) (map[string]string, error) {
+	return xspanner.GetCategoryIDMapByItemIDs(ctx, s.spannerClient, itemIDs)
+}
+
+func (s *SpannerDBClient) GetAllSynonymSets(ctx context.Context) ([]*xspanner.SynonymSet, error) {
+	return xspanner.GetAllSynonymSets(ctx, s.spannerClient)
+}
+
+func (s *SpannerDBClient) GetSynonymSetHistories(ctx context.Context, synonymSetID string) ([]*xspanner.SynonymSetHistory, error) {
+	return xspanner.GetSynonymSetHistories(ctx, s.spannerClient, synonymSetID)
+}
+
+func (s *SpannerDBClient) SaveSynonymSet(ctx context.Context, synonymSet *xspanner.SynonymSet) (*xspanner.SynonymSet, error) {
+	return xspanner.SaveSynonymSet(ctx, s.spannerClient, synonymSet)
+}
+
+func (s *SpannerDBClient) RollbackSynonymSet(ctx context.Context, synonymSetID string, version int64) (*xspanner.SynonymSet, error) {
+	return xspanner.RollbackSynonymSet(ctx, s.spannerClient, synonymSetID, version)
+}
+
+func (s *SpannerDBClient) GetItemIDsMapByGroupIDs(ctx context.Context, groupIDs []string) (map[string][]string, error) {
+	return xspanner.GetItemIDsMapByGroupIDs(ctx, s.spannerClient, groupIDs)
+}
+
+func (s *SpannerDBClient) GetAllMerchandisingRules(ctx context.Context) ([]*xspanner.MerchandisingRule, error) {
+	return xspanner.GetAllMerchandisingRules(ctx, s.spannerClient)
+}
+
+func (s *SpannerDBClient) GetUnexpiredMerchandisingRules(ctx context.Context, now time.Time) ([]*xspanner.MerchandisingRule, error) {
+	return xspanner.GetUnexpiredMerchandisingRules(ctx, s.spannerClient, now)
+}
+
+func (s *SpannerDBClient) SaveMerchandisingRule(ctx context.Context, rule *xspanner.MerchandisingRule) (*xspanner.MerchandisingRule, error) {
+	return xspanner.SaveMerchandisingRule(ctx, s.spannerClient, rule)
+}
+
+func (s *SpannerDBClient) DeleteMerchandisingRule(ctx context.Context, ruleID string) error {
+	return xspanner.DeleteMerchandisingRule(ctx, s.spannerClient, ruleID)
+}
+
+func (s *SpannerDBClient) GetLatestRankingProfiles(ctx context.Context) ([]*xspanner.RankingProfile, error) {
+	return xspanner.GetLatestRankingProfiles(ctx, s.spannerClient)
+}
+
+func (s *SpannerDBClient) GetRankingProfileVersions(ctx context.Context, name string) ([]*xspanner.RankingProfile, error) {
+	return xspanner.GetRankingProfileVersions(ctx, s.spannerClient, name)
+}
+
+func (s *SpannerDBClient) InsertRankingProfileVersion(ctx context.Context, name string, config string) (*xspanner.RankingProfile, error) {
+	return xspanner.InsertRankingProfileVersion(ctx, s.spannerClient, name, config)
+}
+
+func (s *SpannerDBClient) GetAllExperiments(ctx context.Context) ([]*xspanner.Experiment, error) {
+	return xspanner.GetAllExperiments(ctx, s.spannerClient)
+}
+
+func (s *SpannerDBClient) GetUnexpiredExperiments(ctx context.Context, now time.Time) ([]*xspanner.Experiment, error) {
+	return xspanner.GetUnexpiredExperiments(ctx, s.spannerClient, now)
+}
+
+func (s *SpannerDBClient) SaveExperiment(ctx context.Context, experiment *xspanner.Experiment) (*xspanner.Experiment, error) {
+	return xspanner.SaveExperiment(ctx, s.spannerClient, experiment)
+}
+
+func (s *SpannerDBClient) DeleteExperiment(ctx context.Context, experimentID string) error {
+	return xspanner.DeleteExperiment(ctx, s.spannerClient, experimentID)
+}
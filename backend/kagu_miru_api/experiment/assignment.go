@@ -0,0 +1,115 @@
+package experiment
+
+import (
+	"context"
+	"hash/fnv"
+	"time"
+)
+
+// bucketCount is the number of buckets to allocate traffic, so the allocation is in 0.01% steps
+const bucketCount = 10000
+
+// Assignment is the variant of the experiment assigned to the user
+type Assignment struct {
+	ExperimentID string
+	Variant      *Variant
+}
+
+// Assignments are the assignments of the running experiments in the order of the start time
+// When multiple experiments set the same behavior, the earliest experiment wins.
+type Assignments []*Assignment
+
+// SearchRankingProfile returns the ranking profile name for search, or empty when not set by any experiments
+func (a Assignments) SearchRankingProfile() string {
+	for _, assignment := range a {
+		if assignment.Variant.SearchRankingProfile != "" {
+			return assignment.Variant.SearchRankingProfile
+		}
+	}
+	return ""
+}
+
+// SimilarItemsRankingProfile returns the ranking profile name for similar items, or empty when not set by any experiments
+func (a Assignments) SimilarItemsRankingProfile() string {
+	for _, assignment := range a {
+		if assignment.Variant.SimilarItemsRankingProfile != "" {
+			return assignment.Variant.SimilarItemsRankingProfile
+		}
+	}
+	return ""
+}
+
+// Param returns the param of the key, or empty when not set by any experiments
+func (a Assignments) Param(key string) string {
+	for _, assignment := range a {
+		if v, ok := assignment.Variant.Params[key]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+// VariantMap returns the map of the experiment id to the assigned variant name
+func (a Assignments) VariantMap() map[string]string {
+	variantMap := make(map[string]string, len(a))
+	for _, assignment := range a {
+		variantMap[assignment.ExperimentID] = assignment.Variant.Name
+	}
+	return variantMap
+}
+
+// assign assigns the unit to the variants of the running experiments
+// The assignment is deterministic, so the same unit is always assigned to the same variant as long as the experiment
+// is not changed. The unit is hashed with different salts for the traffic allocation and the variant,
+// so that changing the allocation doesn't move units between variants.
+func assign(experiments []*Experiment, unitID string, now time.Time) Assignments {
+	var assignments Assignments
+	for _, experiment := range experiments {
+		if !experiment.isRunning(now) {
+			continue
+		}
+		if hashBucket(experiment.ID+":allocation", unitID, bucketCount) >= int(experiment.TrafficAllocation*bucketCount) {
+			continue
+		}
+		assignments = append(assignments, &Assignment{
+			ExperimentID: experiment.ID,
+			Variant:      experiment.pickVariant(unitID),
+		})
+	}
+	return assignments
+}
+
+func (e *Experiment) pickVariant(unitID string) *Variant {
+	totalWeight := 0
+	for _, variant := range e.Variants {
+		totalWeight += variant.Weight
+	}
+	bucket := hashBucket(e.ID+":variant", unitID, totalWeight)
+	for _, variant := range e.Variants {
+		if bucket < variant.Weight {
+			return variant
+		}
+		bucket -= variant.Weight
+	}
+	return e.Variants[len(e.Variants)-1]
+}
+
+// hashBucket returns the bucket of the unit in [0, n)
+func hashBucket(salt string, unitID string, n int) int {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(salt + ":" + unitID))
+	return int(h.Sum64() % uint64(n))
+}
+
+type ctxAssignmentsKey struct{}
+
+// WithAssignments sets the assignments to the context
+func WithAssignments(ctx context.Context, assignments Assignments) context.Context {
+	return context.WithValue(ctx, ctxAssignmentsKey{}, assignments)
+}
+
+// GetAssignmentsFromCtx returns the assignments of the request, which is nil when not assigned to any experiments
+func GetAssignmentsFromCtx(ctx context.Context) Assignments {
+	assignments, _ := ctx.Value(ctxAssignmentsKey{}).(Assignments)
+	return assignments
+}
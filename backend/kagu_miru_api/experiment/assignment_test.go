@@ -0,0 +1,80 @@
+package experiment
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_assign(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	control := &Variant{Name: "control", Weight: 1}
+	treatment := &Variant{Name: "treatment", Weight: 3, SearchRankingProfile: "new"}
+	running := &Experiment{
+		ID:                "running",
+		Variants:          []*Variant{control, treatment},
+		TrafficAllocation: 0.5,
+		StartsAt:          now.Add(-time.Hour),
+		EndsAt:            now.Add(time.Hour),
+	}
+	notStarted := &Experiment{
+		ID:                "not_started",
+		Variants:          []*Variant{control, treatment},
+		TrafficAllocation: 1,
+		StartsAt:          now.Add(time.Hour),
+		EndsAt:            now.Add(2 * time.Hour),
+	}
+
+	const unitCount = 10000
+	assignedCount := 0
+	variantCounts := make(map[string]int)
+	for i := 0; i < unitCount; i++ {
+		unitID := fmt.Sprintf("session_%d", i)
+		assignments := assign([]*Experiment{running, notStarted}, unitID, now)
+		if diff := cmp.Diff(assignments, assign([]*Experiment{running, notStarted}, unitID, now)); diff != "" {
+			t.Fatalf("assign() must be deterministic, (-first +second): %s", diff)
+		}
+		for _, assignment := range assignments {
+			if assignment.ExperimentID != running.ID {
+				t.Fatalf("assign() assigned to the experiment not running: %s", assignment.ExperimentID)
+			}
+			assignedCount++
+			variantCounts[assignment.Variant.Name]++
+		}
+	}
+
+	if got := float64(assignedCount) / unitCount; math.Abs(got-running.TrafficAllocation) > 0.02 {
+		t.Errorf("assigned ratio = %v, want about %v", got, running.TrafficAllocation)
+	}
+	if got := float64(variantCounts[treatment.Name]) / float64(assignedCount); math.Abs(got-0.75) > 0.02 {
+		t.Errorf("treatment ratio = %v, want about %v", got, 0.75)
+	}
+}
+
+func TestAssignments(t *testing.T) {
+	t.Parallel()
+
+	assignments := Assignments{
+		{ExperimentID: "first", Variant: &Variant{Name: "a", Params: map[string]string{"layout": "grid"}}},
+		{ExperimentID: "second", Variant: &Variant{Name: "b", SearchRankingProfile: "second", Params: map[string]string{"layout": "list"}}},
+		{ExperimentID: "third", Variant: &Variant{Name: "c", SearchRankingProfile: "third"}},
+	}
+	if got := assignments.SearchRankingProfile(); got != "second" {
+		t.Errorf("SearchRankingProfile() = %v, want %v", got, "second")
+	}
+	if got := assignments.SimilarItemsRankingProfile(); got != "" {
+		t.Errorf("SimilarItemsRankingProfile() = %v, want empty", got)
+	}
+	if got := assignments.Param("layout"); got != "grid" {
+		t.Errorf("Param() = %v, want %v", got, "grid")
+	}
+	want := map[string]string{"first": "a", "second": "b", "third": "c"}
+	if diff := cmp.Diff(want, assignments.VariantMap()); diff != "" {
+		t.Errorf("VariantMap(), (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,146 @@
+package experiment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+const experimentsCacheTTL = 1 * time.Minute
+
+type Client interface {
+	GetAllExperiments(ctx context.Context) ([]*xspanner.Experiment, error)
+	SaveExperiment(ctx context.Context, experiment *xspanner.Experiment) (*xspanner.Experiment, error)
+	DeleteExperiment(ctx context.Context, experimentID string) error
+	// GetAssignments returns the variants of the running experiments assigned to the unit e.g. session
+	GetAssignments(ctx context.Context, unitID string) (Assignments, error)
+}
+
+type experimentClient struct {
+	dbClient      db.Client
+	rankingClient ranking.Client
+
+	mu          sync.RWMutex
+	experiments []*Experiment
+	expiresAt   time.Time
+}
+
+func NewExperimentClient(dbClient db.Client, rankingClient ranking.Client) Client {
+	return &experimentClient{dbClient: dbClient, rankingClient: rankingClient}
+}
+
+func (e *experimentClient) GetAllExperiments(ctx context.Context) ([]*xspanner.Experiment, error) {
+	return e.dbClient.GetAllExperiments(ctx)
+}
+
+func (e *experimentClient) SaveExperiment(ctx context.Context, spannerExperiment *xspanner.Experiment) (*xspanner.Experiment, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "experiment.experimentClient_SaveExperiment")
+	defer span.End()
+
+	experiment, err := newExperiment(spannerExperiment)
+	if err != nil {
+		return nil, xerror.NewInvalidArgument(fmt.Errorf("invalid experiment: %w", err))
+	}
+	// ranking profiles are validated on save not to break search while the experiment is running
+	for _, variant := range experiment.Variants {
+		for _, profileName := range []string{variant.SearchRankingProfile, variant.SimilarItemsRankingProfile} {
+			if profileName == "" {
+				continue
+			}
+			if _, err := e.rankingClient.GetProfile(ctx, profileName); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// variants are saved in the canonical form
+	variants, err := json.Marshal(experiment.Variants)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("json.Marshal: %w", err))
+	}
+	normalized := *spannerExperiment
+	normalized.Variants = string(variants)
+	saved, err := e.dbClient.SaveExperiment(ctx, &normalized)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("dbClient.SaveExperiment: %w", err))
+	}
+	e.invalidateCache()
+	return saved, nil
+}
+
+func (e *experimentClient) DeleteExperiment(ctx context.Context, experimentID string) error {
+	ctx, span := otel.Tracer("").Start(ctx, "experiment.experimentClient_DeleteExperiment")
+	defer span.End()
+
+	if err := e.dbClient.DeleteExperiment(ctx, experimentID); err != nil {
+		if xerror.IsErrorType(err, xerror.TypeNotFound) {
+			return err
+		}
+		return logging.Error(ctx, fmt.Errorf("dbClient.DeleteExperiment: %w", err))
+	}
+	e.invalidateCache()
+	return nil
+}
+
+func (e *experimentClient) GetAssignments(ctx context.Context, unitID string) (Assignments, error) {
+	experiments, err := e.getExperiments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return assign(experiments, unitID, time.Now()), nil
+}
+
+// getExperiments returns the cached unexpired experiments
+// Since experiments are cached on each server, changes are reflected to the other servers after the cache expires.
+func (e *experimentClient) getExperiments(ctx context.Context) ([]*Experiment, error) {
+	e.mu.RLock()
+	experiments, expiresAt := e.experiments, e.expiresAt
+	e.mu.RUnlock()
+	if experiments != nil && time.Now().Before(expiresAt) {
+		return experiments, nil
+	}
+
+	experiments, err := e.fetchExperiments(ctx)
+	if err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	e.experiments = experiments
+	e.expiresAt = time.Now().Add(experimentsCacheTTL)
+	e.mu.Unlock()
+	return experiments, nil
+}
+
+func (e *experimentClient) fetchExperiments(ctx context.Context) ([]*Experiment, error) {
+	spannerExperiments, err := e.dbClient.GetUnexpiredExperiments(ctx, time.Now())
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("dbClient.GetUnexpiredExperiments: %w", err))
+	}
+	experiments := make([]*Experiment, 0, len(spannerExperiments))
+	for _, spannerExperiment := range spannerExperiments {
+		experiment, err := newExperiment(spannerExperiment)
+		if err != nil {
+			// an invalid experiment must not stop the other experiments
+			logging.Logger(ctx).Warn("invalid experiment is skipped", zap.String("experimentId", spannerExperiment.ID), zap.Error(err))
+			continue
+		}
+		experiments = append(experiments, experiment)
+	}
+	return experiments, nil
+}
+
+func (e *experimentClient) invalidateCache() {
+	e.mu.Lock()
+	e.expiresAt = time.Time{}
+	e.mu.Unlock()
+}
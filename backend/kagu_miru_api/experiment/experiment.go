@@ -0,0 +1,97 @@
+package experiment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+// experimentIDRegexp restricts the id since it's used as the key of the experiments in the tracking events
+var experimentIDRegexp = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// Experiment is the A/B test assigning users to the variants during the period
+type Experiment struct {
+	ID       string
+	Name     string
+	Variants []*Variant
+	// TrafficAllocation is the ratio of users in the experiment from 0 to 1
+	TrafficAllocation float64
+	StartsAt          time.Time
+	EndsAt            time.Time
+}
+
+// Variant is the behavior of the users assigned to the variant
+// The default behavior is kept for the empty fields, so the control variant can be defined only with the name and weight.
+type Variant struct {
+	Name string `json:"name"`
+	// Weight is the relative share of the users in the experiment assigned to the variant
+	Weight                     int    `json:"weight"`
+	SearchRankingProfile       string `json:"searchRankingProfile,omitempty"`
+	SimilarItemsRankingProfile string `json:"similarItemsRankingProfile,omitempty"`
+	// Params are the other parameters to switch the behavior e.g. UI layouts
+	Params map[string]string `json:"params,omitempty"`
+}
+
+// IsValidID returns true if the id is valid as the experiment id
+func IsValidID(id string) bool {
+	return experimentIDRegexp.MatchString(id)
+}
+
+func newExperiment(spannerExperiment *xspanner.Experiment) (*Experiment, error) {
+	var variants []*Variant
+	if err := json.Unmarshal([]byte(spannerExperiment.Variants), &variants); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	experiment := &Experiment{
+		ID:                spannerExperiment.ID,
+		Name:              spannerExperiment.Name,
+		Variants:          variants,
+		TrafficAllocation: spannerExperiment.TrafficAllocation,
+		StartsAt:          spannerExperiment.StartsAt,
+		EndsAt:            spannerExperiment.EndsAt,
+	}
+	if err := experiment.validate(); err != nil {
+		return nil, err
+	}
+	return experiment, nil
+}
+
+func (e *Experiment) isRunning(now time.Time) bool {
+	return !now.Before(e.StartsAt) && now.Before(e.EndsAt)
+}
+
+func (e *Experiment) validate() error {
+	if !IsValidID(e.ID) {
+		return fmt.Errorf("id must match %s", experimentIDRegexp.String())
+	}
+	if e.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(e.Variants) < 2 {
+		return errors.New("at least 2 variants are required")
+	}
+	variantNameMap := make(map[string]bool, len(e.Variants))
+	for _, variant := range e.Variants {
+		if variant == nil || variant.Name == "" {
+			return errors.New("variant name is required")
+		}
+		if variantNameMap[variant.Name] {
+			return fmt.Errorf("variant name '%s' is duplicated", variant.Name)
+		}
+		variantNameMap[variant.Name] = true
+		if variant.Weight <= 0 {
+			return fmt.Errorf("weight of variant '%s' must be positive", variant.Name)
+		}
+	}
+	if e.TrafficAllocation <= 0 || e.TrafficAllocation > 1 {
+		return errors.New("trafficAllocation must be greater than 0 and less than or equal to 1")
+	}
+	if !e.StartsAt.Before(e.EndsAt) {
+		return errors.New("startsAt must be before endsAt")
+	}
+	return nil
+}
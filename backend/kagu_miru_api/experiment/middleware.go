@@ -0,0 +1,30 @@
+package experiment
+
+import (
+	"net/http"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/tracking"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.uber.org/zap"
+)
+
+// NewMiddleware creates middleware to assign the user to the experiments
+// The session managed by SearchIDManager is used as the unit, so it must be placed after the session is loaded.
+// Since the session cookie isn't persistent and expires after 30 minutes idle, returning users get a new session
+// and can be assigned to another variant. Experiments are analyzed per session, not per user, for this reason.
+// The assignments are set to the context for the resolvers and stamped onto the tracking events.
+func NewMiddleware(client Client, searchIDManager *tracking.SearchIDManager) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			assignments, err := client.GetAssignments(ctx, searchIDManager.GetSearchID(ctx))
+			if err != nil {
+				// the request is served with the default behavior without experiments
+				logging.Logger(ctx).Warn("failed to get experiment assignments", zap.Error(err))
+			}
+			ctx = WithAssignments(ctx, assignments)
+			ctx = tracking.WithExperiments(ctx, assignments.VariantMap())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
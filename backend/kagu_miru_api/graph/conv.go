@@ -11,6 +11,7 @@ import (
 	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
 	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/search"
 	"github.com/k-yomo/kagu-miru/backend/pkg/pointerconv"
 )
@@ -31,26 +32,14 @@ func mapSpannerItemCategoriesToGraphqlItemCategories(itemCategories []*xspanner.
 }
 
 func mapSearchItemToGraphqlItem(item *es.Item) (*gqlmodel.Item, error) {
-	var status gqlmodel.ItemStatus
-	switch item.Status {
-	case xitem.StatusActive:
-		status = gqlmodel.ItemStatusActive
-	case xitem.StatusInactive:
-		status = gqlmodel.ItemStatusInactive
-	default:
-		return nil, fmt.Errorf("unknown status %d, item: %v", item.Status, item)
+	status, err := mapItemStatusToGraphqlItemStatus(item.Status)
+	if err != nil {
+		return nil, fmt.Errorf("%w, item: %v", err, item)
 	}
 
-	var platform gqlmodel.ItemSellingPlatform
-	switch item.Platform {
-	case xitem.PlatformRakuten:
-		platform = gqlmodel.ItemSellingPlatformRakuten
-	case xitem.PlatformYahooShopping:
-		platform = gqlmodel.ItemSellingPlatformYahooShopping
-	case xitem.PlatformPayPayMall:
-		platform = gqlmodel.ItemSellingPlatformPaypayMall
-	default:
-		return nil, fmt.Errorf("unknown platform %s, item: %v", item.Platform, item)
+	platform, err := mapPlatformToGraphqlPlatform(item.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("%w, item: %v", err, item)
 	}
 
 	colors := make([]gqlmodel.ItemColor, 0, len(item.Colors))
@@ -61,6 +50,7 @@ func mapSearchItemToGraphqlItem(item *es.Item) (*gqlmodel.Item, error) {
 		}
 	}
 
+	averageRating, reviewCount := mapReviewsToGraphqlReviews(item.Platform, item.AverageRating, item.ReviewCount)
 	return &gqlmodel.Item{
 		ID:            item.ID,
 		Name:          item.Name,
@@ -70,21 +60,68 @@ func mapSearchItemToGraphqlItem(item *es.Item) (*gqlmodel.Item, error) {
 		AffiliateURL:  item.AffiliateURL,
 		Price:         item.Price,
 		ImageUrls:     item.ImageURLs,
-		AverageRating: item.AverageRating,
-		ReviewCount:   item.ReviewCount,
+		AverageRating: averageRating,
+		ReviewCount:   reviewCount,
 		CategoryID:    item.CategoryID,
 		Colors:        colors,
 		Platform:      platform,
 	}, nil
 }
 
-func mapSearchToGraphqlItems(items []*es.Item) ([]*gqlmodel.Item, error) {
+func mapItemStatusToGraphqlItemStatus(status xitem.Status) (gqlmodel.ItemStatus, error) {
+	switch status {
+	case xitem.StatusActive:
+		return gqlmodel.ItemStatusActive, nil
+	case xitem.StatusInactive:
+		return gqlmodel.ItemStatusInactive, nil
+	default:
+		return "", fmt.Errorf("unknown status %d", status)
+	}
+}
+
+// mapReviewsToGraphqlReviews returns nil for the platform not providing reviews not to display them as no reviews
+func mapReviewsToGraphqlReviews(platform xitem.Platform, averageRating float64, reviewCount int) (*float64, *int) {
+	if !platform.ProvidesReviews() {
+		return nil, nil
+	}
+	return &averageRating, &reviewCount
+}
+
+func mapPlatformToGraphqlPlatform(platform xitem.Platform) (gqlmodel.ItemSellingPlatform, error) {
+	switch platform {
+	case xitem.PlatformRakuten:
+		return gqlmodel.ItemSellingPlatformRakuten, nil
+	case xitem.PlatformYahooShopping:
+		return gqlmodel.ItemSellingPlatformYahooShopping, nil
+	case xitem.PlatformPayPayMall:
+		return gqlmodel.ItemSellingPlatformPaypayMall, nil
+	case xitem.PlatformAmazon:
+		return gqlmodel.ItemSellingPlatformAmazon, nil
+	default:
+		return "", fmt.Errorf("unknown platform %s", platform)
+	}
+}
+
+func mapSearchToGraphqlItems(items []*search.Item) ([]*gqlmodel.Item, error) {
 	gqlItems := make([]*gqlmodel.Item, 0, len(items))
 	for _, item := range items {
-		gqlItem, err := mapSearchItemToGraphqlItem(item)
+		gqlItem, err := mapSearchItemToGraphqlItem(item.Item)
 		if err != nil {
 			return nil, err
 		}
+		for _, sameGroupItem := range item.SameGroupItems {
+			gqlSameGroupItem, err := mapSearchItemToGraphqlItem(sameGroupItem)
+			if err != nil {
+				return nil, err
+			}
+			gqlItem.SameGroupItems = append(gqlItem.SameGroupItems, gqlSameGroupItem)
+		}
+		if item.Highlight != nil {
+			gqlItem.Highlight = &gqlmodel.ItemHighlight{
+				Name:        pointerconv.StringToPointer(item.Highlight.Name),
+				Description: pointerconv.StringToPointer(item.Highlight.Description),
+			}
+		}
 		gqlItems = append(gqlItems, gqlItem)
 	}
 	return gqlItems, nil
@@ -142,16 +179,92 @@ func mapSearchResponseToGraphqlSearchResponse(resp *search.Response, searchID st
 		SearchID: searchID,
 		ItemConnection: &gqlmodel.ItemConnection{
 			PageInfo: &gqlmodel.PageInfo{
-				Page:       resp.Page,
-				TotalPage:  resp.TotalPage,
-				TotalCount: resp.TotalCount,
+				Page:        resp.Page,
+				TotalPage:   resp.TotalPage,
+				TotalCount:  resp.TotalCount,
+				EndCursor:   pointerconv.StringToPointer(resp.EndCursor),
+				HasNextPage: resp.HasNextPage,
 			},
 			Nodes: graphqlItems,
 		},
-		Facets: mapSearchFacetsToGraphqlFacets(resp.Facets),
+		Facets:                mapSearchFacetsToGraphqlFacets(resp.Facets),
+		InterpretedQueryParts: mapSearchInterpretedQueryPartsToGraphqlInterpretedQueryParts(resp.InterpretedQueryParts),
+		Relaxation:            mapSearchRelaxationToGraphqlSearchRelaxation(resp.Relaxation),
+		SpellingSuggestion:    pointerconv.StringToPointer(resp.SpellingSuggestion),
+		AutoCorrected:         resp.AutoCorrected,
+		PinnedItemIds:         resp.PinnedItemIDs,
+		RankingProfile:        mapRankingProfileToGraphqlRankingProfile(resp.RankingProfile),
+		Debug:                 mapSearchDebugToGraphqlSearchDebugInfo(resp.Debug),
 	}, nil
 }
 
+func mapSearchDebugToGraphqlSearchDebugInfo(debug *search.Debug) *gqlmodel.SearchDebugInfo {
+	if debug == nil {
+		return nil
+	}
+	hitExplanations := make([]*gqlmodel.SearchHitExplanation, 0, len(debug.HitExplanations))
+	for _, hitExplanation := range debug.HitExplanations {
+		hitExplanations = append(hitExplanations, &gqlmodel.SearchHitExplanation{
+			ItemID:      hitExplanation.ItemID,
+			Score:       hitExplanation.Score,
+			Explanation: hitExplanation.Explanation,
+		})
+	}
+	return &gqlmodel.SearchDebugInfo{
+		EsRequest:           debug.Request,
+		PostFilters:         debug.PostFilters,
+		MetadataPostFilters: debug.MetadataPostFilters,
+		Aggregations:        debug.Aggregations,
+		HitExplanations:     hitExplanations,
+	}
+}
+
+func mapRankingProfileToGraphqlRankingProfile(profile *ranking.Profile) *gqlmodel.RankingProfile {
+	return &gqlmodel.RankingProfile{
+		Name:    profile.Name,
+		Version: int(profile.Version),
+	}
+}
+
+func mapSearchRelaxationToGraphqlSearchRelaxation(relaxation search.Relaxation) *gqlmodel.SearchRelaxation {
+	var gqlRelaxation gqlmodel.SearchRelaxation
+	switch relaxation {
+	case search.RelaxationMinimumShouldMatch:
+		gqlRelaxation = gqlmodel.SearchRelaxationMinimumShouldMatch
+	case search.RelaxationDropFilters:
+		gqlRelaxation = gqlmodel.SearchRelaxationDropFilters
+	case search.RelaxationCategoryOnly:
+		gqlRelaxation = gqlmodel.SearchRelaxationCategoryOnly
+	default:
+		return nil
+	}
+	return &gqlRelaxation
+}
+
+func mapSearchInterpretedQueryPartsToGraphqlInterpretedQueryParts(parts []*search.InterpretedQueryPart) []*gqlmodel.InterpretedQueryPart {
+	graphqlParts := make([]*gqlmodel.InterpretedQueryPart, 0, len(parts))
+	for _, part := range parts {
+		var partType gqlmodel.InterpretedQueryPartType
+		switch part.Type {
+		case search.InterpretedQueryPartTypeColor:
+			partType = gqlmodel.InterpretedQueryPartTypeColor
+		case search.InterpretedQueryPartTypeBrand:
+			partType = gqlmodel.InterpretedQueryPartTypeBrand
+		case search.InterpretedQueryPartTypePrice:
+			partType = gqlmodel.InterpretedQueryPartTypePrice
+		case search.InterpretedQueryPartTypeDimension:
+			partType = gqlmodel.InterpretedQueryPartTypeDimension
+		default:
+			continue
+		}
+		graphqlParts = append(graphqlParts, &gqlmodel.InterpretedQueryPart{
+			Text: part.Text,
+			Type: partType,
+		})
+	}
+	return graphqlParts
+}
+
 func mapSearchFacetsToGraphqlFacets(facets []*search.Facet) []*gqlmodel.Facet {
 	graphqlFacets := make([]*gqlmodel.Facet, 0, len(facets))
 	for _, facet := range facets {
@@ -159,6 +272,8 @@ func mapSearchFacetsToGraphqlFacets(facets []*search.Facet) []*gqlmodel.Facet {
 		switch facet.FacetType {
 		case search.FacetTypeColors:
 			facetValues = mapSearchFacetColorValuesToGraphqlFacetValues(facet.Values)
+		case search.FacetTypePlatforms:
+			facetValues = mapSearchFacetPlatformValuesToGraphqlFacetValues(facet.Values)
 		default:
 			facetValues = mapSearchFacetValuesToGraphqlFacetValues(facet.Values)
 		}
@@ -167,12 +282,48 @@ func mapSearchFacetsToGraphqlFacets(facets []*search.Facet) []*gqlmodel.Facet {
 			FacetType:  mapSearchFacetTypeToGraphqlFacetType(facet.FacetType),
 			Values:     facetValues,
 			TotalCount: facet.TotalCount,
+			Stats:      mapSearchFacetStatsToGraphqlFacetStats(facet.Stats),
+			Histogram:  mapSearchHistogramToGraphqlHistogram(facet.Histogram),
 		})
 	}
 
 	return graphqlFacets
 }
 
+func mapSearchFacetStatsToGraphqlFacetStats(stats *search.FacetStats) *gqlmodel.FacetStats {
+	if stats == nil {
+		return nil
+	}
+	percentiles := make([]*gqlmodel.Percentile, 0, len(stats.Percentiles))
+	for _, percentile := range stats.Percentiles {
+		percentiles = append(percentiles, &gqlmodel.Percentile{
+			Percent: percentile.Percent,
+			Value:   percentile.Value,
+		})
+	}
+	return &gqlmodel.FacetStats{
+		Min:         stats.Min,
+		Max:         stats.Max,
+		Avg:         stats.Avg,
+		Percentiles: percentiles,
+	}
+}
+
+func mapSearchHistogramToGraphqlHistogram(histogram []*search.HistogramBucket) []*gqlmodel.HistogramBucket {
+	if histogram == nil {
+		return nil
+	}
+	graphqlHistogram := make([]*gqlmodel.HistogramBucket, 0, len(histogram))
+	for _, bucket := range histogram {
+		graphqlHistogram = append(graphqlHistogram, &gqlmodel.HistogramBucket{
+			Min:   bucket.Min,
+			Max:   bucket.Max,
+			Count: bucket.Count,
+		})
+	}
+	return graphqlHistogram
+}
+
 func mapSearchFacetTypeToGraphqlFacetType(facetType search.FacetType) gqlmodel.FacetType {
 	switch facetType {
 	case search.FacetTypeCategoryIDs:
@@ -183,6 +334,16 @@ func mapSearchFacetTypeToGraphqlFacetType(facetType search.FacetType) gqlmodel.F
 		return gqlmodel.FacetTypeColors
 	case search.FacetTypeMetadata:
 		return gqlmodel.FacetTypeMetadata
+	case search.FacetTypePrice:
+		return gqlmodel.FacetTypePrice
+	case search.FacetTypeAverageRating:
+		return gqlmodel.FacetTypeAverageRating
+	case search.FacetTypeReviewCount:
+		return gqlmodel.FacetTypeReviewCount
+	case search.FacetTypePlatforms:
+		return gqlmodel.FacetTypePlatforms
+	case search.FacetTypeShopIDs:
+		return gqlmodel.FacetTypeShopIDS
 	default:
 		return ""
 	}
@@ -192,9 +353,11 @@ func mapSearchFacetValuesToGraphqlFacetValues(facetValues []*search.FacetValue)
 	graphqlFacetValues := make([]*gqlmodel.FacetValue, 0, len(facetValues))
 	for _, facetValue := range facetValues {
 		graphqlFacetValues = append(graphqlFacetValues, &gqlmodel.FacetValue{
-			ID:    facetValue.ID,
-			Name:  facetValue.Name,
-			Count: facetValue.Count,
+			ID:       facetValue.ID,
+			Name:     facetValue.Name,
+			Count:    facetValue.Count,
+			ParentID: pointerconv.StringToPointer(facetValue.ParentID),
+			Children: mapSearchFacetValuesToGraphqlFacetValues(facetValue.Children),
 		})
 	}
 	return graphqlFacetValues
@@ -204,9 +367,27 @@ func mapSearchFacetColorValuesToGraphqlFacetValues(facetValues []*search.FacetVa
 	graphqlFacetValues := make([]*gqlmodel.FacetValue, 0, len(facetValues))
 	for _, facetValue := range facetValues {
 		graphqlFacetValues = append(graphqlFacetValues, &gqlmodel.FacetValue{
-			ID:    mapSearchItemColorToGraphqlItemColor(facetValue.ID).String(),
-			Name:  facetValue.Name,
-			Count: facetValue.Count,
+			ID:       mapSearchItemColorToGraphqlItemColor(facetValue.ID).String(),
+			Name:     facetValue.Name,
+			Count:    facetValue.Count,
+			Children: []*gqlmodel.FacetValue{},
+		})
+	}
+	return graphqlFacetValues
+}
+
+func mapSearchFacetPlatformValuesToGraphqlFacetValues(facetValues []*search.FacetValue) []*gqlmodel.FacetValue {
+	graphqlFacetValues := make([]*gqlmodel.FacetValue, 0, len(facetValues))
+	for _, facetValue := range facetValues {
+		platform, err := mapPlatformToGraphqlPlatform(xitem.Platform(facetValue.ID))
+		if err != nil {
+			continue
+		}
+		graphqlFacetValues = append(graphqlFacetValues, &gqlmodel.FacetValue{
+			ID:       platform.String(),
+			Name:     facetValue.Name,
+			Count:    facetValue.Count,
+			Children: []*gqlmodel.FacetValue{},
 		})
 	}
 	return graphqlFacetValues
@@ -221,12 +402,15 @@ func mapSearchResponseToGraphqlGetSimilarItemsResponse(res *search.Response, sea
 		SearchID: searchID,
 		ItemConnection: &gqlmodel.ItemConnection{
 			PageInfo: &gqlmodel.PageInfo{
-				Page:       int(res.Page),
-				TotalPage:  int(res.TotalPage),
-				TotalCount: int(res.TotalCount),
+				Page:        int(res.Page),
+				TotalPage:   int(res.TotalPage),
+				TotalCount:  int(res.TotalCount),
+				EndCursor:   pointerconv.StringToPointer(res.EndCursor),
+				HasNextPage: res.HasNextPage,
 			},
 			Nodes: graphqlItems,
 		},
+		RankingProfile: mapRankingProfileToGraphqlRankingProfile(res.RankingProfile),
 	}, nil
 }
 
@@ -241,18 +425,13 @@ func mapSpannerItemToGraphqlItem(item *xspanner.Item) (*gqlmodel.Item, error) {
 		return nil, fmt.Errorf("unknown status %d, item: %v", item.Status, item)
 	}
 
-	var platform gqlmodel.ItemSellingPlatform
-	switch item.Platform {
-	case xitem.PlatformRakuten:
-		platform = gqlmodel.ItemSellingPlatformRakuten
-	case xitem.PlatformYahooShopping:
-		platform = gqlmodel.ItemSellingPlatformYahooShopping
-	case xitem.PlatformPayPayMall:
-		platform = gqlmodel.ItemSellingPlatformPaypayMall
-	default:
-		return nil, fmt.Errorf("unknown platform %s, item: %v", item.Platform, item)
+	platform, err := mapPlatformToGraphqlPlatform(item.Platform)
+	if err != nil {
+		return nil, fmt.Errorf("%w, item: %v", err, item)
 	}
 
+	averageRating, reviewCount := mapReviewsToGraphqlReviews(item.Platform, item.AverageRating, int(item.ReviewCount))
+
 	return &gqlmodel.Item{
 		ID:            item.ID,
 		Name:          item.Name,
@@ -262,8 +441,8 @@ func mapSpannerItemToGraphqlItem(item *xspanner.Item) (*gqlmodel.Item, error) {
 		AffiliateURL:  item.AffiliateURL,
 		Price:         int(item.Price),
 		ImageUrls:     item.ImageURLs,
-		AverageRating: item.AverageRating,
-		ReviewCount:   int(item.ReviewCount),
+		AverageRating: averageRating,
+		ReviewCount:   reviewCount,
 		CategoryID:    item.CategoryID,
 		Platform:      platform,
 	}, nil
@@ -273,6 +452,8 @@ func mapFromXErrorType(errType xerror.Type) gqlmodel.ErrorCode {
 	switch errType {
 	case xerror.TypeNotFound:
 		return gqlmodel.ErrorCodeNotFound
+	case xerror.TypeInvalidArgument:
+		return gqlmodel.ErrorCodeInvalidArgument
 	default:
 		return gqlmodel.ErrorCodeInternal
 	}
@@ -0,0 +1,37 @@
+package graph
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/request"
+)
+
+// SearchDebugTokenHeader is the internal header to enable search debug mode in prod
+const SearchDebugTokenHeader = "X-Search-Debug-Token"
+
+// SearchDebugPolicy decides if search debug mode is allowed for the request
+// Since debug info exposes how items are ranked, it's allowed only in non-prod environments or with the internal header.
+type SearchDebugPolicy struct {
+	allowAll bool
+	token    string
+}
+
+// NewSearchDebugPolicy creates SearchDebugPolicy, only allowAll works when token is empty
+func NewSearchDebugPolicy(allowAll bool, token string) *SearchDebugPolicy {
+	return &SearchDebugPolicy{allowAll: allowAll, token: token}
+}
+
+func (p *SearchDebugPolicy) IsAllowed(ctx context.Context) bool {
+	if p.allowAll {
+		return true
+	}
+	if p.token == "" {
+		return false
+	}
+	req, ok := request.GetRequestFromCtx(ctx)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(req.Header.Get(SearchDebugTokenHeader)), []byte(p.token)) == 1
+}
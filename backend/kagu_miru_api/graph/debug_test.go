@@ -0,0 +1,84 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/request"
+)
+
+// newRequestContext returns the context with the request set by the request middleware
+func newRequestContext(t *testing.T, header http.Header) context.Context {
+	t.Helper()
+
+	var ctx context.Context
+	handler := request.NewMiddleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx = r.Context()
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/graphql", nil)
+	req.Header = header
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	return ctx
+}
+
+func TestSearchDebugPolicy_IsAllowed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		allowAll bool
+		token    string
+		ctx      context.Context
+		want     bool
+	}{
+		{
+			name:     "allows all requests",
+			allowAll: true,
+			ctx:      context.Background(),
+			want:     true,
+		},
+		{
+			name:  "doesn't allow the request without token configured",
+			token: "",
+			ctx:   newRequestContext(t, http.Header{SearchDebugTokenHeader: {""}}),
+			want:  false,
+		},
+		{
+			name:  "doesn't allow the request without the header",
+			token: "token",
+			ctx:   newRequestContext(t, http.Header{}),
+			want:  false,
+		},
+		{
+			name:  "doesn't allow the request with the wrong header",
+			token: "token",
+			ctx:   newRequestContext(t, http.Header{SearchDebugTokenHeader: {"wrong"}}),
+			want:  false,
+		},
+		{
+			name:  "doesn't allow the context without the request",
+			token: "token",
+			ctx:   context.Background(),
+			want:  false,
+		},
+		{
+			name:  "allows the request with the correct header",
+			token: "token",
+			ctx:   newRequestContext(t, http.Header{SearchDebugTokenHeader: {"token"}}),
+			want:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			policy := NewSearchDebugPolicy(tt.allowAll, tt.token)
+			if got := policy.IsAllowed(tt.ctx); got != tt.want {
+				t.Errorf("IsAllowed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -47,22 +47,40 @@ type DirectiveRoot struct {
 type ComplexityRoot struct {
 	Facet struct {
 		FacetType  func(childComplexity int) int
+		Histogram  func(childComplexity int) int
+		Stats      func(childComplexity int) int
 		Title      func(childComplexity int) int
 		TotalCount func(childComplexity int) int
 		Values     func(childComplexity int) int
 	}
 
+	FacetStats struct {
+		Avg         func(childComplexity int) int
+		Max         func(childComplexity int) int
+		Min         func(childComplexity int) int
+		Percentiles func(childComplexity int) int
+	}
+
 	FacetValue struct {
-		Count func(childComplexity int) int
-		ID    func(childComplexity int) int
-		Name  func(childComplexity int) int
+		Children func(childComplexity int) int
+		Count    func(childComplexity int) int
+		ID       func(childComplexity int) int
+		Name     func(childComplexity int) int
+		ParentID func(childComplexity int) int
 	}
 
 	GetSimilarItemsResponse struct {
 		ItemConnection func(childComplexity int) int
+		RankingProfile func(childComplexity int) int
 		SearchID       func(childComplexity int) int
 	}
 
+	HistogramBucket struct {
+		Count func(childComplexity int) int
+		Max   func(childComplexity int) int
+		Min   func(childComplexity int) int
+	}
+
 	HomeComponent struct {
 		ID      func(childComplexity int) int
 		Payload func(childComplexity int) int
@@ -92,6 +110,11 @@ type ComplexityRoot struct {
 		Components func(childComplexity int) int
 	}
 
+	InterpretedQueryPart struct {
+		Text func(childComplexity int) int
+		Type func(childComplexity int) int
+	}
+
 	Item struct {
 		AffiliateURL   func(childComplexity int) int
 		AverageRating  func(childComplexity int) int
@@ -99,6 +122,7 @@ type ComplexityRoot struct {
 		Colors         func(childComplexity int) int
 		Description    func(childComplexity int) int
 		GroupID        func(childComplexity int) int
+		Highlight      func(childComplexity int) int
 		ID             func(childComplexity int) int
 		ImageUrls      func(childComplexity int) int
 		Name           func(childComplexity int) int
@@ -125,6 +149,11 @@ type ComplexityRoot struct {
 		PageInfo func(childComplexity int) int
 	}
 
+	ItemHighlight struct {
+		Description func(childComplexity int) int
+		Name        func(childComplexity int) int
+	}
+
 	MediaPost struct {
 		Categories   func(childComplexity int) int
 		Description  func(childComplexity int) int
@@ -144,9 +173,16 @@ type ComplexityRoot struct {
 	}
 
 	PageInfo struct {
-		Page       func(childComplexity int) int
-		TotalCount func(childComplexity int) int
-		TotalPage  func(childComplexity int) int
+		EndCursor   func(childComplexity int) int
+		HasNextPage func(childComplexity int) int
+		Page        func(childComplexity int) int
+		TotalCount  func(childComplexity int) int
+		TotalPage   func(childComplexity int) int
+	}
+
+	Percentile struct {
+		Percent func(childComplexity int) int
+		Value   func(childComplexity int) int
 	}
 
 	Query struct {
@@ -163,10 +199,36 @@ type ComplexityRoot struct {
 		SuggestedQueries func(childComplexity int) int
 	}
 
+	RankingProfile struct {
+		Name    func(childComplexity int) int
+		Version func(childComplexity int) int
+	}
+
+	SearchDebugInfo struct {
+		Aggregations        func(childComplexity int) int
+		EsRequest           func(childComplexity int) int
+		HitExplanations     func(childComplexity int) int
+		MetadataPostFilters func(childComplexity int) int
+		PostFilters         func(childComplexity int) int
+	}
+
+	SearchHitExplanation struct {
+		Explanation func(childComplexity int) int
+		ItemID      func(childComplexity int) int
+		Score       func(childComplexity int) int
+	}
+
 	SearchResponse struct {
-		Facets         func(childComplexity int) int
-		ItemConnection func(childComplexity int) int
-		SearchID       func(childComplexity int) int
+		AutoCorrected         func(childComplexity int) int
+		Debug                 func(childComplexity int) int
+		Facets                func(childComplexity int) int
+		InterpretedQueryParts func(childComplexity int) int
+		ItemConnection        func(childComplexity int) int
+		PinnedItemIds         func(childComplexity int) int
+		RankingProfile        func(childComplexity int) int
+		Relaxation            func(childComplexity int) int
+		SearchID              func(childComplexity int) int
+		SpellingSuggestion    func(childComplexity int) int
 	}
 }
 
@@ -204,6 +266,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Facet.FacetType(childComplexity), true
 
+	case "Facet.histogram":
+		if e.complexity.Facet.Histogram == nil {
+			break
+		}
+
+		return e.complexity.Facet.Histogram(childComplexity), true
+
+	case "Facet.stats":
+		if e.complexity.Facet.Stats == nil {
+			break
+		}
+
+		return e.complexity.Facet.Stats(childComplexity), true
+
 	case "Facet.title":
 		if e.complexity.Facet.Title == nil {
 			break
@@ -225,6 +301,41 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Facet.Values(childComplexity), true
 
+	case "FacetStats.avg":
+		if e.complexity.FacetStats.Avg == nil {
+			break
+		}
+
+		return e.complexity.FacetStats.Avg(childComplexity), true
+
+	case "FacetStats.max":
+		if e.complexity.FacetStats.Max == nil {
+			break
+		}
+
+		return e.complexity.FacetStats.Max(childComplexity), true
+
+	case "FacetStats.min":
+		if e.complexity.FacetStats.Min == nil {
+			break
+		}
+
+		return e.complexity.FacetStats.Min(childComplexity), true
+
+	case "FacetStats.percentiles":
+		if e.complexity.FacetStats.Percentiles == nil {
+			break
+		}
+
+		return e.complexity.FacetStats.Percentiles(childComplexity), true
+
+	case "FacetValue.children":
+		if e.complexity.FacetValue.Children == nil {
+			break
+		}
+
+		return e.complexity.FacetValue.Children(childComplexity), true
+
 	case "FacetValue.count":
 		if e.complexity.FacetValue.Count == nil {
 			break
@@ -246,6 +357,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.FacetValue.Name(childComplexity), true
 
+	case "FacetValue.parentId":
+		if e.complexity.FacetValue.ParentID == nil {
+			break
+		}
+
+		return e.complexity.FacetValue.ParentID(childComplexity), true
+
 	case "GetSimilarItemsResponse.itemConnection":
 		if e.complexity.GetSimilarItemsResponse.ItemConnection == nil {
 			break
@@ -253,6 +371,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.GetSimilarItemsResponse.ItemConnection(childComplexity), true
 
+	case "GetSimilarItemsResponse.rankingProfile":
+		if e.complexity.GetSimilarItemsResponse.RankingProfile == nil {
+			break
+		}
+
+		return e.complexity.GetSimilarItemsResponse.RankingProfile(childComplexity), true
+
 	case "GetSimilarItemsResponse.searchId":
 		if e.complexity.GetSimilarItemsResponse.SearchID == nil {
 			break
@@ -260,6 +385,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.GetSimilarItemsResponse.SearchID(childComplexity), true
 
+	case "HistogramBucket.count":
+		if e.complexity.HistogramBucket.Count == nil {
+			break
+		}
+
+		return e.complexity.HistogramBucket.Count(childComplexity), true
+
+	case "HistogramBucket.max":
+		if e.complexity.HistogramBucket.Max == nil {
+			break
+		}
+
+		return e.complexity.HistogramBucket.Max(childComplexity), true
+
+	case "HistogramBucket.min":
+		if e.complexity.HistogramBucket.Min == nil {
+			break
+		}
+
+		return e.complexity.HistogramBucket.Min(childComplexity), true
+
 	case "HomeComponent.id":
 		if e.complexity.HomeComponent.ID == nil {
 			break
@@ -337,6 +483,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.HomeResponse.Components(childComplexity), true
 
+	case "InterpretedQueryPart.text":
+		if e.complexity.InterpretedQueryPart.Text == nil {
+			break
+		}
+
+		return e.complexity.InterpretedQueryPart.Text(childComplexity), true
+
+	case "InterpretedQueryPart.type":
+		if e.complexity.InterpretedQueryPart.Type == nil {
+			break
+		}
+
+		return e.complexity.InterpretedQueryPart.Type(childComplexity), true
+
 	case "Item.affiliateUrl":
 		if e.complexity.Item.AffiliateURL == nil {
 			break
@@ -379,6 +539,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Item.GroupID(childComplexity), true
 
+	case "Item.highlight":
+		if e.complexity.Item.Highlight == nil {
+			break
+		}
+
+		return e.complexity.Item.Highlight(childComplexity), true
+
 	case "Item.id":
 		if e.complexity.Item.ID == nil {
 			break
@@ -505,6 +672,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ItemConnection.PageInfo(childComplexity), true
 
+	case "ItemHighlight.description":
+		if e.complexity.ItemHighlight.Description == nil {
+			break
+		}
+
+		return e.complexity.ItemHighlight.Description(childComplexity), true
+
+	case "ItemHighlight.name":
+		if e.complexity.ItemHighlight.Name == nil {
+			break
+		}
+
+		return e.complexity.ItemHighlight.Name(childComplexity), true
+
 	case "MediaPost.categories":
 		if e.complexity.MediaPost.Categories == nil {
 			break
@@ -573,6 +754,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Mutation.TrackEvent(childComplexity, args["event"].(gqlmodel.Event)), true
 
+	case "PageInfo.endCursor":
+		if e.complexity.PageInfo.EndCursor == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.EndCursor(childComplexity), true
+
+	case "PageInfo.hasNextPage":
+		if e.complexity.PageInfo.HasNextPage == nil {
+			break
+		}
+
+		return e.complexity.PageInfo.HasNextPage(childComplexity), true
+
 	case "PageInfo.page":
 		if e.complexity.PageInfo.Page == nil {
 			break
@@ -594,6 +789,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.PageInfo.TotalPage(childComplexity), true
 
+	case "Percentile.percent":
+		if e.complexity.Percentile.Percent == nil {
+			break
+		}
+
+		return e.complexity.Percentile.Percent(childComplexity), true
+
+	case "Percentile.value":
+		if e.complexity.Percentile.Value == nil {
+			break
+		}
+
+		return e.complexity.Percentile.Value(childComplexity), true
+
 	case "Query.getAllItemCategories":
 		if e.complexity.Query.GetAllItemCategories == nil {
 			break
@@ -670,6 +879,90 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.QuerySuggestionsResponse.SuggestedQueries(childComplexity), true
 
+	case "RankingProfile.name":
+		if e.complexity.RankingProfile.Name == nil {
+			break
+		}
+
+		return e.complexity.RankingProfile.Name(childComplexity), true
+
+	case "RankingProfile.version":
+		if e.complexity.RankingProfile.Version == nil {
+			break
+		}
+
+		return e.complexity.RankingProfile.Version(childComplexity), true
+
+	case "SearchDebugInfo.aggregations":
+		if e.complexity.SearchDebugInfo.Aggregations == nil {
+			break
+		}
+
+		return e.complexity.SearchDebugInfo.Aggregations(childComplexity), true
+
+	case "SearchDebugInfo.esRequest":
+		if e.complexity.SearchDebugInfo.EsRequest == nil {
+			break
+		}
+
+		return e.complexity.SearchDebugInfo.EsRequest(childComplexity), true
+
+	case "SearchDebugInfo.hitExplanations":
+		if e.complexity.SearchDebugInfo.HitExplanations == nil {
+			break
+		}
+
+		return e.complexity.SearchDebugInfo.HitExplanations(childComplexity), true
+
+	case "SearchDebugInfo.metadataPostFilters":
+		if e.complexity.SearchDebugInfo.MetadataPostFilters == nil {
+			break
+		}
+
+		return e.complexity.SearchDebugInfo.MetadataPostFilters(childComplexity), true
+
+	case "SearchDebugInfo.postFilters":
+		if e.complexity.SearchDebugInfo.PostFilters == nil {
+			break
+		}
+
+		return e.complexity.SearchDebugInfo.PostFilters(childComplexity), true
+
+	case "SearchHitExplanation.explanation":
+		if e.complexity.SearchHitExplanation.Explanation == nil {
+			break
+		}
+
+		return e.complexity.SearchHitExplanation.Explanation(childComplexity), true
+
+	case "SearchHitExplanation.itemId":
+		if e.complexity.SearchHitExplanation.ItemID == nil {
+			break
+		}
+
+		return e.complexity.SearchHitExplanation.ItemID(childComplexity), true
+
+	case "SearchHitExplanation.score":
+		if e.complexity.SearchHitExplanation.Score == nil {
+			break
+		}
+
+		return e.complexity.SearchHitExplanation.Score(childComplexity), true
+
+	case "SearchResponse.autoCorrected":
+		if e.complexity.SearchResponse.AutoCorrected == nil {
+			break
+		}
+
+		return e.complexity.SearchResponse.AutoCorrected(childComplexity), true
+
+	case "SearchResponse.debug":
+		if e.complexity.SearchResponse.Debug == nil {
+			break
+		}
+
+		return e.complexity.SearchResponse.Debug(childComplexity), true
+
 	case "SearchResponse.facets":
 		if e.complexity.SearchResponse.Facets == nil {
 			break
@@ -677,6 +970,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SearchResponse.Facets(childComplexity), true
 
+	case "SearchResponse.interpretedQueryParts":
+		if e.complexity.SearchResponse.InterpretedQueryParts == nil {
+			break
+		}
+
+		return e.complexity.SearchResponse.InterpretedQueryParts(childComplexity), true
+
 	case "SearchResponse.itemConnection":
 		if e.complexity.SearchResponse.ItemConnection == nil {
 			break
@@ -684,6 +984,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SearchResponse.ItemConnection(childComplexity), true
 
+	case "SearchResponse.pinnedItemIds":
+		if e.complexity.SearchResponse.PinnedItemIds == nil {
+			break
+		}
+
+		return e.complexity.SearchResponse.PinnedItemIds(childComplexity), true
+
+	case "SearchResponse.rankingProfile":
+		if e.complexity.SearchResponse.RankingProfile == nil {
+			break
+		}
+
+		return e.complexity.SearchResponse.RankingProfile(childComplexity), true
+
+	case "SearchResponse.relaxation":
+		if e.complexity.SearchResponse.Relaxation == nil {
+			break
+		}
+
+		return e.complexity.SearchResponse.Relaxation(childComplexity), true
+
 	case "SearchResponse.searchId":
 		if e.complexity.SearchResponse.SearchID == nil {
 			break
@@ -691,6 +1012,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.SearchResponse.SearchID(childComplexity), true
 
+	case "SearchResponse.spellingSuggestion":
+		if e.complexity.SearchResponse.SpellingSuggestion == nil {
+			break
+		}
+
+		return e.complexity.SearchResponse.SpellingSuggestion(childComplexity), true
+
 	}
 	return 0, false
 }
@@ -775,6 +1103,7 @@ type Mutation {
 
 enum ErrorCode {
     NOT_FOUND
+    INVALID_ARGUMENT
     INTERNAL
 }
 
@@ -782,6 +1111,9 @@ type PageInfo {
     page: Int!
     totalPage: Int!
     totalCount: Int!
+    # endCursor can be set to ` + "`" + `cursor` + "`" + ` of the input to fetch the next page, which is returned only when paginating with cursor
+    endCursor: String
+    hasNextPage: Boolean!
 }
 
 type ItemCategory {
@@ -803,6 +1135,7 @@ enum ItemSellingPlatform {
     RAKUTEN
     YAHOO_SHOPPING
     PAYPAY_MALL
+    AMAZON
 }
 
 type Item {
@@ -815,13 +1148,23 @@ type Item {
     affiliateUrl: String!
     price: Int!
     imageUrls: [String!]!
-    averageRating: Float!
-    reviewCount: Int!
+    # averageRating and reviewCount are null when the platform doesn't provide reviews e.g. Amazon
+    averageRating: Float
+    reviewCount: Int
     categoryId: ID!
     colors: [ItemColor!]!
     platform: ItemSellingPlatform!
 
     sameGroupItems: [Item!]!
+    # highlight is set only for the items matched by the search query
+    highlight: ItemHighlight
+}
+
+# ItemHighlight has html escaped texts where the terms matched to the query are surrounded by <em> tags
+type ItemHighlight {
+    name: String
+    # description is the sentence best matched to the query in the description
+    description: String
 }
 
 type ItemConnection {
@@ -834,12 +1177,21 @@ enum FacetType {
     BRAND_NAMES
     COLORS
     METADATA
+    PRICE
+    AVERAGE_RATING
+    REVIEW_COUNT
+    PLATFORMS
+    SHOP_IDS
 }
 
 type FacetValue {
     id: ID!
     name: String!
     count: Int!
+    # parentId and children are set only for hierarchical facets (CATEGORY_IDS)
+    # values of the hierarchical facet are top level values and the lower levels can be drilled down with children
+    parentId: ID
+    children: [FacetValue!]!
 }
 
 type Facet {
@@ -847,6 +1199,28 @@ type Facet {
     facetType: FacetType!
     values: [FacetValue!]!
     totalCount: Int!
+    # stats and histogram are set only for numeric facets (PRICE, AVERAGE_RATING, REVIEW_COUNT)
+    stats: FacetStats
+    histogram: [HistogramBucket!]
+}
+
+type FacetStats {
+    min: Float!
+    max: Float!
+    avg: Float!
+    percentiles: [Percentile!]!
+}
+
+type Percentile {
+    percent: Float!
+    value: Float!
+}
+
+# bucket boundaries are adapted to the distribution of the current result set
+type HistogramBucket {
+    min: Float!
+    max: Float!
+    count: Int!
 }
 
 type MediaPostCategory {
@@ -902,11 +1276,74 @@ type SearchResponse {
     searchId: String!
     itemConnection: ItemConnection!
     facets: [Facet!]!
+    # interpretedQueryParts are the parts of the query interpreted as filters
+    # the interpretation can be undone by removing the text from the query
+    interpretedQueryParts: [InterpretedQueryPart!]!
+    # relaxation is set when the search is broadened since the original search returned no items
+    relaxation: SearchRelaxation
+    # spellingSuggestion is the corrected query suggested when the original query scores poorly
+    spellingSuggestion: String
+    # autoCorrected is true when the items are searched with spellingSuggestion instead of the original query
+    autoCorrected: Boolean!
+    # pinnedItemIds are the ids of the items placed at the top by merchandising rules
+    pinnedItemIds: [ID!]!
+    # rankingProfile is the ranking profile used to score items
+    rankingProfile: RankingProfile!
+    # debug is set only when debug is requested and allowed
+    debug: SearchDebugInfo
+}
+
+type SearchDebugInfo {
+    # esRequest is the request body sent to Elasticsearch
+    esRequest: Map!
+    # postFilters is the map of the filtered field to the post filter
+    postFilters: Map!
+    # metadataPostFilters is the map of the metadata name to the post filter
+    metadataPostFilters: Map!
+    # aggregations is the map of the aggregation name to the aggregation for facets
+    aggregations: Map!
+    hitExplanations: [SearchHitExplanation!]!
+}
+
+type SearchHitExplanation {
+    itemId: ID!
+    score: Float!
+    # explanation is the score breakdown returned by Elasticsearch
+    explanation: Map!
+}
+
+enum SearchRelaxation {
+    # matches items containing most of the query terms instead of all of them
+    MINIMUM_SHOULD_MATCH
+    # drops filters except for categories, platforms and prices
+    DROP_FILTERS
+    # matches the query to category names only
+    CATEGORY_ONLY
+}
+
+enum InterpretedQueryPartType {
+    COLOR
+    BRAND
+    PRICE
+    DIMENSION
+}
+
+# RankingProfile is a named and versioned config of how items are scored
+type RankingProfile {
+    name: String!
+    version: Int!
+}
+
+type InterpretedQueryPart {
+    text: String!
+    type: InterpretedQueryPartType!
 }
 
 type GetSimilarItemsResponse {
     searchId: String!
     itemConnection: ItemConnection!
+    # rankingProfile is the ranking profile used to score items
+    rankingProfile: RankingProfile!
 }
 
 type QuerySuggestionsResponse {
@@ -928,12 +1365,24 @@ input SearchInput {
     filter: SearchFilter
     page: Int
     pageSize: Int
+    # cursor is used to paginate with consistent results instead of page when set, an empty string starts from the first page
+    cursor: String
+    # disableAutoCorrect disables searching with the spelling suggestion instead of the query
+    disableAutoCorrect: Boolean
+    # rankingProfile is the name of the ranking profile, the default profile is used when null
+    rankingProfile: String
+    # debug returns the debug info, which is allowed only in non-prod environments or with the internal header
+    debug: Boolean
 }
 
 input GetSimilarItemsInput {
     itemId: ID!
     page: Int
     pageSize: Int
+    # cursor is used to paginate with consistent results instead of page when set, an empty string starts from the first page
+    cursor: String
+    # rankingProfile is the name of the ranking profile, the default profile is used when null
+    rankingProfile: String
 }
 
 enum ItemColor {
@@ -965,14 +1414,31 @@ input AppliedMetadata {
 input SearchFilter {
     categoryIds: [ID!]
     platforms: [ItemSellingPlatform!]
+    shopIds: [ID!]
     brandNames: [String!]
     colors: [ItemColor!]
     minPrice: Int
     maxPrice: Int
     minRating: Int
+    # min/max dimensions in cm, items whose dimension range is within the given range are matched
+    minWidth: Int
+    maxWidth: Int
+    minDepth: Int
+    maxDepth: Int
+    minHeight: Int
+    maxHeight: Int
+    # fitsWithin matches items which may fit in the given space
+    fitsWithin: DimensionsInput
     metadata: [AppliedMetadata!]
 }
 
+# dimensions in cm
+input DimensionsInput {
+    width: Int
+    depth: Int
+    height: Int
+}
+
 enum EventID {
     HOME
     SEARCH
@@ -983,6 +1449,8 @@ enum EventID {
 enum Action {
     DISPLAY
     CLICK_ITEM
+    # ZERO_RESULT is recorded on backend when the search returns no items
+    ZERO_RESULT
 }
 
 input Event {
@@ -1009,6 +1477,26 @@ input SearchDisplayItemsActionParams {
     searchFrom: SearchFrom!
     searchInput: SearchInput!
     itemIds: [ID!]! # Must be ranking's descending order
+    # pinnedPositions are 0-based positions in itemIds of the items pinned by merchandising rules
+    # pinned items should be excluded from CTR analysis since they are not ranked by relevance
+    pinnedPositions: [Int!]
+    # rankingProfile must be the rankingProfile in the response
+    rankingProfile: RankingProfileInput
+}
+
+input RankingProfileInput {
+    name: String!
+    version: Int!
+}
+
+# SearchZeroResultActionParams is recorded on backend
+input SearchZeroResultActionParams {
+    searchId: String!
+    searchInput: SearchInput!
+    # relaxation is the relaxation which recovered the search, null when not recovered
+    relaxation: SearchRelaxation
+    recovered: Boolean!
+    rankingProfile: RankingProfileInput
 }
 
 input SearchClickItemActionParams {
@@ -1030,6 +1518,8 @@ input SimilarItemsDisplayItemsActionParams {
     searchId: String!
     getSimilarItemsInput: GetSimilarItemsInput!
     itemIds: [ID!]! # Must be ranking's descending order
+    # rankingProfile must be the rankingProfile in the response
+    rankingProfile: RankingProfileInput
 }
 `, BuiltIn: false},
 }
@@ -1129,21 +1619,6 @@ func (ec *executionContext) field_Query_search_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
-func (ec *executionContext) field___Field_args_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 *bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
-	}
-	args["includeDeprecated"] = arg0
-	return args, nil
-}
-
 func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
@@ -1322,7 +1797,7 @@ func (ec *executionContext) _Facet_totalCount(ctx context.Context, field graphql
 	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _FacetValue_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _Facet_stats(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Facet) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1330,7 +1805,7 @@ func (ec *executionContext) _FacetValue_id(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "FacetValue",
+		Object:     "Facet",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1340,24 +1815,21 @@ func (ec *executionContext) _FacetValue_id(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Stats, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*gqlmodel.FacetStats)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalOFacetStats2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐFacetStats(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _FacetValue_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _Facet_histogram(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Facet) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1365,7 +1837,7 @@ func (ec *executionContext) _FacetValue_name(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "FacetValue",
+		Object:     "Facet",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1375,24 +1847,21 @@ func (ec *executionContext) _FacetValue_name(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Histogram, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*gqlmodel.HistogramBucket)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOHistogramBucket2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHistogramBucketᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _FacetValue_count(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetStats_min(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetStats) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1400,7 +1869,7 @@ func (ec *executionContext) _FacetValue_count(ctx context.Context, field graphql
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "FacetValue",
+		Object:     "FacetStats",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1410,7 +1879,7 @@ func (ec *executionContext) _FacetValue_count(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Count, nil
+		return obj.Min, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1422,12 +1891,12 @@ func (ec *executionContext) _FacetValue_count(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _GetSimilarItemsResponse_searchId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.GetSimilarItemsResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetStats_max(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetStats) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1435,7 +1904,7 @@ func (ec *executionContext) _GetSimilarItemsResponse_searchId(ctx context.Contex
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "GetSimilarItemsResponse",
+		Object:     "FacetStats",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1445,7 +1914,7 @@ func (ec *executionContext) _GetSimilarItemsResponse_searchId(ctx context.Contex
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SearchID, nil
+		return obj.Max, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1457,12 +1926,12 @@ func (ec *executionContext) _GetSimilarItemsResponse_searchId(ctx context.Contex
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _GetSimilarItemsResponse_itemConnection(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.GetSimilarItemsResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetStats_avg(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetStats) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1470,7 +1939,7 @@ func (ec *executionContext) _GetSimilarItemsResponse_itemConnection(ctx context.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "GetSimilarItemsResponse",
+		Object:     "FacetStats",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1480,7 +1949,7 @@ func (ec *executionContext) _GetSimilarItemsResponse_itemConnection(ctx context.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ItemConnection, nil
+		return obj.Avg, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1492,12 +1961,12 @@ func (ec *executionContext) _GetSimilarItemsResponse_itemConnection(ctx context.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.ItemConnection)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNItemConnection2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemConnection(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponent_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponent) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetStats_percentiles(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetStats) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1505,7 +1974,7 @@ func (ec *executionContext) _HomeComponent_id(ctx context.Context, field graphql
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponent",
+		Object:     "FacetStats",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1515,7 +1984,7 @@ func (ec *executionContext) _HomeComponent_id(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Percentiles, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1527,12 +1996,12 @@ func (ec *executionContext) _HomeComponent_id(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*gqlmodel.Percentile)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNPercentile2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐPercentileᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponent_payload(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponent) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetValue_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetValue) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1540,7 +2009,7 @@ func (ec *executionContext) _HomeComponent_payload(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponent",
+		Object:     "FacetValue",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1550,7 +2019,7 @@ func (ec *executionContext) _HomeComponent_payload(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Payload, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1562,12 +2031,12 @@ func (ec *executionContext) _HomeComponent_payload(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(gqlmodel.HomeComponentPayload)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNHomeComponentPayload2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeComponentPayload(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponentPayloadCategories_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadCategories) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetValue_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetValue) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1575,7 +2044,7 @@ func (ec *executionContext) _HomeComponentPayloadCategories_title(ctx context.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponentPayloadCategories",
+		Object:     "FacetValue",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1585,7 +2054,7 @@ func (ec *executionContext) _HomeComponentPayloadCategories_title(ctx context.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1602,7 +2071,7 @@ func (ec *executionContext) _HomeComponentPayloadCategories_title(ctx context.Co
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponentPayloadCategories_categories(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadCategories) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetValue_count(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetValue) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1610,7 +2079,7 @@ func (ec *executionContext) _HomeComponentPayloadCategories_categories(ctx conte
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponentPayloadCategories",
+		Object:     "FacetValue",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1620,7 +2089,7 @@ func (ec *executionContext) _HomeComponentPayloadCategories_categories(ctx conte
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Categories, nil
+		return obj.Count, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1632,12 +2101,12 @@ func (ec *executionContext) _HomeComponentPayloadCategories_categories(ctx conte
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.ItemCategory)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNItemCategory2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemCategoryᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponentPayloadItemGroups_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadItemGroups) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetValue_parentId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetValue) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1645,7 +2114,7 @@ func (ec *executionContext) _HomeComponentPayloadItemGroups_title(ctx context.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponentPayloadItemGroups",
+		Object:     "FacetValue",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1655,24 +2124,21 @@ func (ec *executionContext) _HomeComponentPayloadItemGroups_title(ctx context.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.ParentID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponentPayloadItemGroups_payload(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadItemGroups) (ret graphql.Marshaler) {
+func (ec *executionContext) _FacetValue_children(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.FacetValue) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1680,7 +2146,7 @@ func (ec *executionContext) _HomeComponentPayloadItemGroups_payload(ctx context.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponentPayloadItemGroups",
+		Object:     "FacetValue",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1690,7 +2156,7 @@ func (ec *executionContext) _HomeComponentPayloadItemGroups_payload(ctx context.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Payload, nil
+		return obj.Children, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1702,12 +2168,12 @@ func (ec *executionContext) _HomeComponentPayloadItemGroups_payload(ctx context.
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.HomeComponentPayloadItems)
+	res := resTmp.([]*gqlmodel.FacetValue)
 	fc.Result = res
-	return ec.marshalNHomeComponentPayloadItems2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeComponentPayloadItemsᚄ(ctx, field.Selections, res)
+	return ec.marshalNFacetValue2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐFacetValueᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponentPayloadItems_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadItems) (ret graphql.Marshaler) {
+func (ec *executionContext) _GetSimilarItemsResponse_searchId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.GetSimilarItemsResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1715,7 +2181,7 @@ func (ec *executionContext) _HomeComponentPayloadItems_title(ctx context.Context
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponentPayloadItems",
+		Object:     "GetSimilarItemsResponse",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1725,7 +2191,7 @@ func (ec *executionContext) _HomeComponentPayloadItems_title(ctx context.Context
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.SearchID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1742,7 +2208,7 @@ func (ec *executionContext) _HomeComponentPayloadItems_title(ctx context.Context
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponentPayloadItems_items(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadItems) (ret graphql.Marshaler) {
+func (ec *executionContext) _GetSimilarItemsResponse_itemConnection(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.GetSimilarItemsResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1750,7 +2216,7 @@ func (ec *executionContext) _HomeComponentPayloadItems_items(ctx context.Context
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponentPayloadItems",
+		Object:     "GetSimilarItemsResponse",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1760,7 +2226,7 @@ func (ec *executionContext) _HomeComponentPayloadItems_items(ctx context.Context
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Items, nil
+		return obj.ItemConnection, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1772,12 +2238,12 @@ func (ec *executionContext) _HomeComponentPayloadItems_items(ctx context.Context
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.Item)
+	res := resTmp.(*gqlmodel.ItemConnection)
 	fc.Result = res
-	return ec.marshalNItem2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemᚄ(ctx, field.Selections, res)
+	return ec.marshalNItemConnection2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemConnection(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponentPayloadMediaPosts_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadMediaPosts) (ret graphql.Marshaler) {
+func (ec *executionContext) _GetSimilarItemsResponse_rankingProfile(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.GetSimilarItemsResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1785,7 +2251,7 @@ func (ec *executionContext) _HomeComponentPayloadMediaPosts_title(ctx context.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponentPayloadMediaPosts",
+		Object:     "GetSimilarItemsResponse",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1795,7 +2261,7 @@ func (ec *executionContext) _HomeComponentPayloadMediaPosts_title(ctx context.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.RankingProfile, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1807,12 +2273,12 @@ func (ec *executionContext) _HomeComponentPayloadMediaPosts_title(ctx context.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*gqlmodel.RankingProfile)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNRankingProfile2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐRankingProfile(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeComponentPayloadMediaPosts_posts(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadMediaPosts) (ret graphql.Marshaler) {
+func (ec *executionContext) _HistogramBucket_min(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HistogramBucket) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1820,7 +2286,7 @@ func (ec *executionContext) _HomeComponentPayloadMediaPosts_posts(ctx context.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeComponentPayloadMediaPosts",
+		Object:     "HistogramBucket",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1830,7 +2296,7 @@ func (ec *executionContext) _HomeComponentPayloadMediaPosts_posts(ctx context.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Posts, nil
+		return obj.Min, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1842,12 +2308,12 @@ func (ec *executionContext) _HomeComponentPayloadMediaPosts_posts(ctx context.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.MediaPost)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNMediaPost2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐMediaPostᚄ(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _HomeResponse_components(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _HistogramBucket_max(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HistogramBucket) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1855,7 +2321,7 @@ func (ec *executionContext) _HomeResponse_components(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "HomeResponse",
+		Object:     "HistogramBucket",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1865,7 +2331,7 @@ func (ec *executionContext) _HomeResponse_components(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Components, nil
+		return obj.Max, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1877,12 +2343,12 @@ func (ec *executionContext) _HomeResponse_components(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.HomeComponent)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNHomeComponent2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeComponentᚄ(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HistogramBucket_count(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HistogramBucket) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1890,7 +2356,7 @@ func (ec *executionContext) _Item_id(ctx context.Context, field graphql.Collecte
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HistogramBucket",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1900,7 +2366,7 @@ func (ec *executionContext) _Item_id(ctx context.Context, field graphql.Collecte
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Count, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1912,12 +2378,12 @@ func (ec *executionContext) _Item_id(ctx context.Context, field graphql.Collecte
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_groupID(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponent_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponent) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1925,7 +2391,7 @@ func (ec *executionContext) _Item_groupID(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponent",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1935,7 +2401,7 @@ func (ec *executionContext) _Item_groupID(ctx context.Context, field graphql.Col
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.GroupID, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1952,7 +2418,7 @@ func (ec *executionContext) _Item_groupID(ctx context.Context, field graphql.Col
 	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponent_payload(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponent) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1960,7 +2426,7 @@ func (ec *executionContext) _Item_name(ctx context.Context, field graphql.Collec
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponent",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -1970,7 +2436,7 @@ func (ec *executionContext) _Item_name(ctx context.Context, field graphql.Collec
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Payload, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -1982,12 +2448,12 @@ func (ec *executionContext) _Item_name(ctx context.Context, field graphql.Collec
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(gqlmodel.HomeComponentPayload)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNHomeComponentPayload2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeComponentPayload(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_description(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponentPayloadCategories_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadCategories) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -1995,7 +2461,7 @@ func (ec *executionContext) _Item_description(ctx context.Context, field graphql
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponentPayloadCategories",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2005,7 +2471,7 @@ func (ec *executionContext) _Item_description(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2022,7 +2488,7 @@ func (ec *executionContext) _Item_description(ctx context.Context, field graphql
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_status(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponentPayloadCategories_categories(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadCategories) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2030,7 +2496,7 @@ func (ec *executionContext) _Item_status(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponentPayloadCategories",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2040,7 +2506,7 @@ func (ec *executionContext) _Item_status(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Status, nil
+		return obj.Categories, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2052,12 +2518,12 @@ func (ec *executionContext) _Item_status(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.(gqlmodel.ItemStatus)
+	res := resTmp.([]*gqlmodel.ItemCategory)
 	fc.Result = res
-	return ec.marshalNItemStatus2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemStatus(ctx, field.Selections, res)
+	return ec.marshalNItemCategory2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemCategoryᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_url(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponentPayloadItemGroups_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadItemGroups) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2065,7 +2531,7 @@ func (ec *executionContext) _Item_url(ctx context.Context, field graphql.Collect
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponentPayloadItemGroups",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2075,7 +2541,7 @@ func (ec *executionContext) _Item_url(ctx context.Context, field graphql.Collect
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.URL, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2092,7 +2558,7 @@ func (ec *executionContext) _Item_url(ctx context.Context, field graphql.Collect
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_affiliateUrl(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponentPayloadItemGroups_payload(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadItemGroups) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2100,7 +2566,7 @@ func (ec *executionContext) _Item_affiliateUrl(ctx context.Context, field graphq
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponentPayloadItemGroups",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2110,7 +2576,7 @@ func (ec *executionContext) _Item_affiliateUrl(ctx context.Context, field graphq
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.AffiliateURL, nil
+		return obj.Payload, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2122,12 +2588,12 @@ func (ec *executionContext) _Item_affiliateUrl(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*gqlmodel.HomeComponentPayloadItems)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNHomeComponentPayloadItems2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeComponentPayloadItemsᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_price(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponentPayloadItems_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadItems) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2135,7 +2601,7 @@ func (ec *executionContext) _Item_price(ctx context.Context, field graphql.Colle
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponentPayloadItems",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2145,7 +2611,7 @@ func (ec *executionContext) _Item_price(ctx context.Context, field graphql.Colle
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Price, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2157,12 +2623,12 @@ func (ec *executionContext) _Item_price(ctx context.Context, field graphql.Colle
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_imageUrls(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponentPayloadItems_items(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadItems) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2170,7 +2636,7 @@ func (ec *executionContext) _Item_imageUrls(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponentPayloadItems",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2180,7 +2646,7 @@ func (ec *executionContext) _Item_imageUrls(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ImageUrls, nil
+		return obj.Items, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2192,12 +2658,12 @@ func (ec *executionContext) _Item_imageUrls(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.([]*gqlmodel.Item)
 	fc.Result = res
-	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalNItem2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_averageRating(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponentPayloadMediaPosts_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadMediaPosts) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2205,7 +2671,7 @@ func (ec *executionContext) _Item_averageRating(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponentPayloadMediaPosts",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2215,7 +2681,7 @@ func (ec *executionContext) _Item_averageRating(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.AverageRating, nil
+		return obj.Title, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2227,12 +2693,12 @@ func (ec *executionContext) _Item_averageRating(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_reviewCount(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeComponentPayloadMediaPosts_posts(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeComponentPayloadMediaPosts) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2240,7 +2706,7 @@ func (ec *executionContext) _Item_reviewCount(ctx context.Context, field graphql
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeComponentPayloadMediaPosts",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2250,7 +2716,7 @@ func (ec *executionContext) _Item_reviewCount(ctx context.Context, field graphql
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ReviewCount, nil
+		return obj.Posts, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2262,12 +2728,12 @@ func (ec *executionContext) _Item_reviewCount(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.([]*gqlmodel.MediaPost)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNMediaPost2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐMediaPostᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_categoryId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _HomeResponse_components(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.HomeResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2275,7 +2741,7 @@ func (ec *executionContext) _Item_categoryId(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "HomeResponse",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2285,7 +2751,7 @@ func (ec *executionContext) _Item_categoryId(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.CategoryID, nil
+		return obj.Components, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2297,12 +2763,12 @@ func (ec *executionContext) _Item_categoryId(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*gqlmodel.HomeComponent)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNHomeComponent2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeComponentᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_colors(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _InterpretedQueryPart_text(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.InterpretedQueryPart) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2310,7 +2776,7 @@ func (ec *executionContext) _Item_colors(ctx context.Context, field graphql.Coll
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "InterpretedQueryPart",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2320,7 +2786,7 @@ func (ec *executionContext) _Item_colors(ctx context.Context, field graphql.Coll
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Colors, nil
+		return obj.Text, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2332,12 +2798,12 @@ func (ec *executionContext) _Item_colors(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]gqlmodel.ItemColor)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNItemColor2ᚕgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemColorᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_platform(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _InterpretedQueryPart_type(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.InterpretedQueryPart) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2345,7 +2811,7 @@ func (ec *executionContext) _Item_platform(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Item",
+		Object:     "InterpretedQueryPart",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2355,7 +2821,7 @@ func (ec *executionContext) _Item_platform(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Platform, nil
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2367,12 +2833,12 @@ func (ec *executionContext) _Item_platform(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(gqlmodel.ItemSellingPlatform)
+	res := resTmp.(gqlmodel.InterpretedQueryPartType)
 	fc.Result = res
-	return ec.marshalNItemSellingPlatform2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemSellingPlatform(ctx, field.Selections, res)
+	return ec.marshalNInterpretedQueryPartType2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐInterpretedQueryPartType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Item_sameGroupItems(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2390,7 +2856,7 @@ func (ec *executionContext) _Item_sameGroupItems(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SameGroupItems, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2402,12 +2868,12 @@ func (ec *executionContext) _Item_sameGroupItems(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.Item)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNItem2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemᚄ(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemCategory_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_groupID(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2415,7 +2881,7 @@ func (ec *executionContext) _ItemCategory_id(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemCategory",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2425,7 +2891,7 @@ func (ec *executionContext) _ItemCategory_id(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.GroupID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2442,7 +2908,7 @@ func (ec *executionContext) _ItemCategory_id(ctx context.Context, field graphql.
 	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemCategory_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2450,7 +2916,7 @@ func (ec *executionContext) _ItemCategory_name(ctx context.Context, field graphq
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemCategory",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2477,7 +2943,7 @@ func (ec *executionContext) _ItemCategory_name(ctx context.Context, field graphq
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemCategory_level(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_description(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2485,7 +2951,7 @@ func (ec *executionContext) _ItemCategory_level(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemCategory",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2495,7 +2961,7 @@ func (ec *executionContext) _ItemCategory_level(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Level, nil
+		return obj.Description, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2507,12 +2973,12 @@ func (ec *executionContext) _ItemCategory_level(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemCategory_parentId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_status(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2520,7 +2986,7 @@ func (ec *executionContext) _ItemCategory_parentId(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemCategory",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2530,21 +2996,24 @@ func (ec *executionContext) _ItemCategory_parentId(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ParentID, nil
+		return obj.Status, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(gqlmodel.ItemStatus)
 	fc.Result = res
-	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNItemStatus2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemStatus(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemCategory_imageUrl(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_url(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2552,7 +3021,7 @@ func (ec *executionContext) _ItemCategory_imageUrl(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemCategory",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2562,21 +3031,24 @@ func (ec *executionContext) _ItemCategory_imageUrl(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ImageURL, nil
+		return obj.URL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemCategory_parent(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_affiliateUrl(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2584,7 +3056,7 @@ func (ec *executionContext) _ItemCategory_parent(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemCategory",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2594,21 +3066,24 @@ func (ec *executionContext) _ItemCategory_parent(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Parent, nil
+		return obj.AffiliateURL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.ItemCategory)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOItemCategory2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemCategory(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemCategory_children(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_price(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2616,7 +3091,7 @@ func (ec *executionContext) _ItemCategory_children(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemCategory",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2626,7 +3101,7 @@ func (ec *executionContext) _ItemCategory_children(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Children, nil
+		return obj.Price, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2638,12 +3113,12 @@ func (ec *executionContext) _ItemCategory_children(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.ItemCategory)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNItemCategory2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemCategoryᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemConnection) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_imageUrls(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2651,7 +3126,7 @@ func (ec *executionContext) _ItemConnection_pageInfo(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemConnection",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2661,7 +3136,7 @@ func (ec *executionContext) _ItemConnection_pageInfo(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PageInfo, nil
+		return obj.ImageUrls, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2673,12 +3148,12 @@ func (ec *executionContext) _ItemConnection_pageInfo(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.PageInfo)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐPageInfo(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _ItemConnection_nodes(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemConnection) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_averageRating(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2686,7 +3161,7 @@ func (ec *executionContext) _ItemConnection_nodes(ctx context.Context, field gra
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "ItemConnection",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2696,24 +3171,53 @@ func (ec *executionContext) _ItemConnection_nodes(ctx context.Context, field gra
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Nodes, nil
+		return obj.AverageRating, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
+		return graphql.Null
+	}
+	res := resTmp.(*float64)
+	fc.Result = res
+	return ec.marshalOFloat2ᚖfloat64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Item_reviewCount(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
 		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Item",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ReviewCount, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.Item)
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalNItem2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemᚄ(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _MediaPost_slug(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_categoryId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2721,7 +3225,7 @@ func (ec *executionContext) _MediaPost_slug(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "MediaPost",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2731,7 +3235,7 @@ func (ec *executionContext) _MediaPost_slug(ctx context.Context, field graphql.C
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Slug, nil
+		return obj.CategoryID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2748,7 +3252,7 @@ func (ec *executionContext) _MediaPost_slug(ctx context.Context, field graphql.C
 	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _MediaPost_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+func (ec *executionContext) _Item_colors(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2756,7 +3260,7 @@ func (ec *executionContext) _MediaPost_title(ctx context.Context, field graphql.
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "MediaPost",
+		Object:     "Item",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2766,7 +3270,144 @@ func (ec *executionContext) _MediaPost_title(ctx context.Context, field graphql.
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Title, nil
+		return obj.Colors, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]gqlmodel.ItemColor)
+	fc.Result = res
+	return ec.marshalNItemColor2ᚕgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemColorᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Item_platform(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Item",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Platform, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(gqlmodel.ItemSellingPlatform)
+	fc.Result = res
+	return ec.marshalNItemSellingPlatform2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemSellingPlatform(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Item_sameGroupItems(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Item",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SameGroupItems, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*gqlmodel.Item)
+	fc.Result = res
+	return ec.marshalNItem2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Item_highlight(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Item) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Item",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Highlight, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*gqlmodel.ItemHighlight)
+	fc.Result = res
+	return ec.marshalOItemHighlight2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemHighlight(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemCategory_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemCategory",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2780,10 +3421,10 @@ func (ec *executionContext) _MediaPost_title(ctx context.Context, field graphql.
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _MediaPost_description(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+func (ec *executionContext) _ItemCategory_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2791,7 +3432,7 @@ func (ec *executionContext) _MediaPost_description(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "MediaPost",
+		Object:     "ItemCategory",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2801,7 +3442,7 @@ func (ec *executionContext) _MediaPost_description(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2818,7 +3459,1180 @@ func (ec *executionContext) _MediaPost_description(ctx context.Context, field gr
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _MediaPost_mainImageUrl(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+func (ec *executionContext) _ItemCategory_level(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemCategory",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Level, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemCategory_parentId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemCategory",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ParentID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemCategory_imageUrl(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemCategory",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ImageURL, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemCategory_parent(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemCategory",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Parent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*gqlmodel.ItemCategory)
+	fc.Result = res
+	return ec.marshalOItemCategory2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemCategory(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemCategory_children(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemCategory) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemCategory",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Children, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*gqlmodel.ItemCategory)
+	fc.Result = res
+	return ec.marshalNItemCategory2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemCategoryᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemConnection_pageInfo(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemConnection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemConnection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PageInfo, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*gqlmodel.PageInfo)
+	fc.Result = res
+	return ec.marshalNPageInfo2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐPageInfo(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemConnection_nodes(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemConnection) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemConnection",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Nodes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*gqlmodel.Item)
+	fc.Result = res
+	return ec.marshalNItem2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemHighlight_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemHighlight) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemHighlight",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _ItemHighlight_description(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.ItemHighlight) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "ItemHighlight",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _MediaPost_slug(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "MediaPost",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Slug, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _MediaPost_title(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "MediaPost",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Title, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _MediaPost_description(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "MediaPost",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _MediaPost_mainImageUrl(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "MediaPost",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MainImageURL, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _MediaPost_publishedAt(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "MediaPost",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.PublishedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _MediaPost_categories(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "MediaPost",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Categories, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*gqlmodel.MediaPostCategory)
+	fc.Result = res
+	return ec.marshalNMediaPostCategory2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐMediaPostCategoryᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _MediaPostCategory_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPostCategory) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "MediaPostCategory",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _MediaPostCategory_names(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPostCategory) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "MediaPostCategory",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Names, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Mutation_trackEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Mutation_trackEvent_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Mutation().TrackEvent(rctx, args["event"].(gqlmodel.Event))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PageInfo_page(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.PageInfo) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Page, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PageInfo_totalPage(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.PageInfo) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalPage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PageInfo_totalCount(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.PageInfo) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.TotalCount, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PageInfo_endCursor(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.PageInfo) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.EndCursor, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _PageInfo_hasNextPage(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.PageInfo) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "PageInfo",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HasNextPage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(bool)
+	fc.Result = res
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Percentile_percent(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Percentile) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Percentile",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Percent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Percentile_value(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Percentile) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Percentile",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   false,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Value, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_home(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Home(rctx)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*gqlmodel.HomeResponse)
+	fc.Result = res
+	return ec.marshalNHomeResponse2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeResponse(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_search(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_search_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().Search(rctx, args["input"].(gqlmodel.SearchInput))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*gqlmodel.SearchResponse)
+	fc.Result = res
+	return ec.marshalNSearchResponse2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchResponse(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_getSimilarItems(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_getSimilarItems_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().GetSimilarItems(rctx, args["input"].(gqlmodel.GetSimilarItemsInput))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*gqlmodel.GetSimilarItemsResponse)
+	fc.Result = res
+	return ec.marshalNGetSimilarItemsResponse2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐGetSimilarItemsResponse(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_getQuerySuggestions(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_getQuerySuggestions_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().GetQuerySuggestions(rctx, args["query"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*gqlmodel.QuerySuggestionsResponse)
+	fc.Result = res
+	return ec.marshalNQuerySuggestionsResponse2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐQuerySuggestionsResponse(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_getItem(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query_getItem_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().GetItem(rctx, args["id"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*gqlmodel.Item)
+	fc.Result = res
+	return ec.marshalNItem2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItem(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query_getAllItemCategories(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: true,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Query().GetAllItemCategories(rctx)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*gqlmodel.ItemCategory)
+	fc.Result = res
+	return ec.marshalNItemCategory2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemCategoryᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	rawArgs := field.ArgumentMap(ec.Variables)
+	args, err := ec.field_Query___type_args(ctx, rawArgs)
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	fc.Args = args
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectType(args["name"].(string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Type)
+	fc.Result = res
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "Query",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.introspectSchema()
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*introspection.Schema)
+	fc.Result = res
+	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) _QuerySuggestionsResponse_query(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.QuerySuggestionsResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2826,7 +4640,7 @@ func (ec *executionContext) _MediaPost_mainImageUrl(ctx context.Context, field g
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "MediaPost",
+		Object:     "QuerySuggestionsResponse",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2836,7 +4650,7 @@ func (ec *executionContext) _MediaPost_mainImageUrl(ctx context.Context, field g
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MainImageURL, nil
+		return obj.Query, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2853,7 +4667,7 @@ func (ec *executionContext) _MediaPost_mainImageUrl(ctx context.Context, field g
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _MediaPost_publishedAt(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+func (ec *executionContext) _QuerySuggestionsResponse_suggestedQueries(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.QuerySuggestionsResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2861,7 +4675,7 @@ func (ec *executionContext) _MediaPost_publishedAt(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "MediaPost",
+		Object:     "QuerySuggestionsResponse",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2871,7 +4685,7 @@ func (ec *executionContext) _MediaPost_publishedAt(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PublishedAt, nil
+		return obj.SuggestedQueries, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2883,12 +4697,12 @@ func (ec *executionContext) _MediaPost_publishedAt(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _MediaPost_categories(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPost) (ret graphql.Marshaler) {
+func (ec *executionContext) _RankingProfile_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.RankingProfile) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2896,7 +4710,7 @@ func (ec *executionContext) _MediaPost_categories(ctx context.Context, field gra
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "MediaPost",
+		Object:     "RankingProfile",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2906,7 +4720,7 @@ func (ec *executionContext) _MediaPost_categories(ctx context.Context, field gra
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Categories, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2918,12 +4732,12 @@ func (ec *executionContext) _MediaPost_categories(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.MediaPostCategory)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNMediaPostCategory2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐMediaPostCategoryᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _MediaPostCategory_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPostCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _RankingProfile_version(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.RankingProfile) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2931,7 +4745,7 @@ func (ec *executionContext) _MediaPostCategory_id(ctx context.Context, field gra
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "MediaPostCategory",
+		Object:     "RankingProfile",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2941,7 +4755,7 @@ func (ec *executionContext) _MediaPostCategory_id(ctx context.Context, field gra
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Version, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2953,12 +4767,12 @@ func (ec *executionContext) _MediaPostCategory_id(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _MediaPostCategory_names(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MediaPostCategory) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchDebugInfo_esRequest(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchDebugInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -2966,7 +4780,7 @@ func (ec *executionContext) _MediaPostCategory_names(ctx context.Context, field
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "MediaPostCategory",
+		Object:     "SearchDebugInfo",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -2976,7 +4790,7 @@ func (ec *executionContext) _MediaPostCategory_names(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Names, nil
+		return obj.EsRequest, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -2988,12 +4802,12 @@ func (ec *executionContext) _MediaPostCategory_names(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalNMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Mutation_trackEvent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchDebugInfo_postFilters(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchDebugInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3001,24 +4815,17 @@ func (ec *executionContext) _Mutation_trackEvent(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "SearchDebugInfo",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Mutation_trackEvent_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().TrackEvent(rctx, args["event"].(gqlmodel.Event))
+		return obj.PostFilters, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3030,12 +4837,12 @@ func (ec *executionContext) _Mutation_trackEvent(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _PageInfo_page(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.PageInfo) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchDebugInfo_metadataPostFilters(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchDebugInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3043,7 +4850,7 @@ func (ec *executionContext) _PageInfo_page(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "SearchDebugInfo",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3053,7 +4860,7 @@ func (ec *executionContext) _PageInfo_page(ctx context.Context, field graphql.Co
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Page, nil
+		return obj.MetadataPostFilters, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3065,12 +4872,12 @@ func (ec *executionContext) _PageInfo_page(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _PageInfo_totalPage(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.PageInfo) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchDebugInfo_aggregations(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchDebugInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3078,7 +4885,7 @@ func (ec *executionContext) _PageInfo_totalPage(ctx context.Context, field graph
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "SearchDebugInfo",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3088,7 +4895,7 @@ func (ec *executionContext) _PageInfo_totalPage(ctx context.Context, field graph
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalPage, nil
+		return obj.Aggregations, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3100,12 +4907,12 @@ func (ec *executionContext) _PageInfo_totalPage(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _PageInfo_totalCount(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.PageInfo) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchDebugInfo_hitExplanations(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchDebugInfo) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3113,7 +4920,7 @@ func (ec *executionContext) _PageInfo_totalCount(ctx context.Context, field grap
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "PageInfo",
+		Object:     "SearchDebugInfo",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3123,7 +4930,7 @@ func (ec *executionContext) _PageInfo_totalCount(ctx context.Context, field grap
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TotalCount, nil
+		return obj.HitExplanations, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3135,12 +4942,12 @@ func (ec *executionContext) _PageInfo_totalCount(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.([]*gqlmodel.SearchHitExplanation)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNSearchHitExplanation2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchHitExplanationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_home(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchHitExplanation_itemId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchHitExplanation) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3148,17 +4955,17 @@ func (ec *executionContext) _Query_home(ctx context.Context, field graphql.Colle
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "SearchHitExplanation",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Home(rctx)
+		return obj.ItemID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3170,12 +4977,12 @@ func (ec *executionContext) _Query_home(ctx context.Context, field graphql.Colle
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.HomeResponse)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNHomeResponse2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeResponse(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_search(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchHitExplanation_score(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchHitExplanation) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3183,24 +4990,17 @@ func (ec *executionContext) _Query_search(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "SearchHitExplanation",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_search_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Search(rctx, args["input"].(gqlmodel.SearchInput))
+		return obj.Score, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3212,12 +5012,12 @@ func (ec *executionContext) _Query_search(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.SearchResponse)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNSearchResponse2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchResponse(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_getSimilarItems(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchHitExplanation_explanation(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchHitExplanation) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3225,24 +5025,17 @@ func (ec *executionContext) _Query_getSimilarItems(ctx context.Context, field gr
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "SearchHitExplanation",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_getSimilarItems_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().GetSimilarItems(rctx, args["input"].(gqlmodel.GetSimilarItemsInput))
+		return obj.Explanation, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3254,12 +5047,12 @@ func (ec *executionContext) _Query_getSimilarItems(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.GetSimilarItemsResponse)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalNGetSimilarItemsResponse2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐGetSimilarItemsResponse(ctx, field.Selections, res)
+	return ec.marshalNMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_getQuerySuggestions(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_searchId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3267,24 +5060,17 @@ func (ec *executionContext) _Query_getQuerySuggestions(ctx context.Context, fiel
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "SearchResponse",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_getQuerySuggestions_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().GetQuerySuggestions(rctx, args["query"].(string))
+		return obj.SearchID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3296,12 +5082,12 @@ func (ec *executionContext) _Query_getQuerySuggestions(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.QuerySuggestionsResponse)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNQuerySuggestionsResponse2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐQuerySuggestionsResponse(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_getItem(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_itemConnection(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3309,24 +5095,17 @@ func (ec *executionContext) _Query_getItem(ctx context.Context, field graphql.Co
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "SearchResponse",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query_getItem_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().GetItem(rctx, args["id"].(string))
+		return obj.ItemConnection, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3338,12 +5117,12 @@ func (ec *executionContext) _Query_getItem(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.Item)
+	res := resTmp.(*gqlmodel.ItemConnection)
 	fc.Result = res
-	return ec.marshalNItem2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItem(ctx, field.Selections, res)
+	return ec.marshalNItemConnection2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemConnection(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query_getAllItemCategories(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_facets(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3351,17 +5130,17 @@ func (ec *executionContext) _Query_getAllItemCategories(ctx context.Context, fie
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "SearchResponse",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().GetAllItemCategories(rctx)
+		return obj.Facets, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3373,12 +5152,12 @@ func (ec *executionContext) _Query_getAllItemCategories(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.ItemCategory)
+	res := resTmp.([]*gqlmodel.Facet)
 	fc.Result = res
-	return ec.marshalNItemCategory2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemCategoryᚄ(ctx, field.Selections, res)
+	return ec.marshalNFacet2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐFacetᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_interpretedQueryParts(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3386,38 +5165,34 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "SearchResponse",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field_Query___type_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectType(args["name"].(string))
+		return obj.InterpretedQueryParts, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.([]*gqlmodel.InterpretedQueryPart)
 	fc.Result = res
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNInterpretedQueryPart2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐInterpretedQueryPartᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_relaxation(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3425,17 +5200,17 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "SearchResponse",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectSchema()
+		return obj.Relaxation, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3444,12 +5219,12 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(*gqlmodel.SearchRelaxation)
 	fc.Result = res
-	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+	return ec.marshalOSearchRelaxation2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchRelaxation(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _QuerySuggestionsResponse_query(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.QuerySuggestionsResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_spellingSuggestion(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3457,7 +5232,7 @@ func (ec *executionContext) _QuerySuggestionsResponse_query(ctx context.Context,
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "QuerySuggestionsResponse",
+		Object:     "SearchResponse",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3467,24 +5242,21 @@ func (ec *executionContext) _QuerySuggestionsResponse_query(ctx context.Context,
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Query, nil
+		return obj.SpellingSuggestion, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _QuerySuggestionsResponse_suggestedQueries(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.QuerySuggestionsResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_autoCorrected(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3492,7 +5264,7 @@ func (ec *executionContext) _QuerySuggestionsResponse_suggestedQueries(ctx conte
 		}
 	}()
 	fc := &graphql.FieldContext{
-		Object:     "QuerySuggestionsResponse",
+		Object:     "SearchResponse",
 		Field:      field,
 		Args:       nil,
 		IsMethod:   false,
@@ -3502,7 +5274,7 @@ func (ec *executionContext) _QuerySuggestionsResponse_suggestedQueries(ctx conte
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SuggestedQueries, nil
+		return obj.AutoCorrected, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3514,12 +5286,12 @@ func (ec *executionContext) _QuerySuggestionsResponse_suggestedQueries(ctx conte
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _SearchResponse_searchId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_pinnedItemIds(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3537,7 +5309,7 @@ func (ec *executionContext) _SearchResponse_searchId(ctx context.Context, field
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SearchID, nil
+		return obj.PinnedItemIds, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3549,12 +5321,12 @@ func (ec *executionContext) _SearchResponse_searchId(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNID2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _SearchResponse_itemConnection(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_rankingProfile(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3572,7 +5344,7 @@ func (ec *executionContext) _SearchResponse_itemConnection(ctx context.Context,
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ItemConnection, nil
+		return obj.RankingProfile, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3584,12 +5356,12 @@ func (ec *executionContext) _SearchResponse_itemConnection(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*gqlmodel.ItemConnection)
+	res := resTmp.(*gqlmodel.RankingProfile)
 	fc.Result = res
-	return ec.marshalNItemConnection2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemConnection(ctx, field.Selections, res)
+	return ec.marshalNRankingProfile2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐRankingProfile(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) _SearchResponse_facets(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
+func (ec *executionContext) _SearchResponse_debug(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.SearchResponse) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
 			ec.Error(ctx, ec.Recover(ctx, r))
@@ -3607,21 +5379,18 @@ func (ec *executionContext) _SearchResponse_facets(ctx context.Context, field gr
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Facets, nil
+		return obj.Debug, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*gqlmodel.Facet)
+	res := resTmp.(*gqlmodel.SearchDebugInfo)
 	fc.Result = res
-	return ec.marshalNFacet2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐFacetᚄ(ctx, field.Selections, res)
+	return ec.marshalOSearchDebugInfo2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchDebugInfo(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
@@ -3670,14 +5439,14 @@ func (ec *executionContext) ___Directive_description(ctx context.Context, field
 		Object:     "__Directive",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
+		IsMethod:   true,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Description(), nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3686,9 +5455,9 @@ func (ec *executionContext) ___Directive_description(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) ___Directive_locations(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
@@ -3842,14 +5611,14 @@ func (ec *executionContext) ___EnumValue_description(ctx context.Context, field
 		Object:     "__EnumValue",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
+		IsMethod:   true,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Description(), nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3858,9 +5627,9 @@ func (ec *executionContext) ___EnumValue_description(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) ___EnumValue_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
@@ -3976,14 +5745,14 @@ func (ec *executionContext) ___Field_description(ctx context.Context, field grap
 		Object:     "__Field",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
+		IsMethod:   true,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Description(), nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3992,9 +5761,9 @@ func (ec *executionContext) ___Field_description(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) ___Field_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
@@ -4012,14 +5781,7 @@ func (ec *executionContext) ___Field_args(ctx context.Context, field graphql.Col
 		IsResolver: false,
 	}
 
-	ctx = graphql.WithFieldContext(ctx, fc)
-	rawArgs := field.ArgumentMap(ec.Variables)
-	args, err := ec.field___Field_args_args(ctx, rawArgs)
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
-	}
-	fc.Args = args
+	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
 		return obj.Args, nil
@@ -4187,14 +5949,14 @@ func (ec *executionContext) ___InputValue_description(ctx context.Context, field
 		Object:     "__InputValue",
 		Field:      field,
 		Args:       nil,
-		IsMethod:   false,
+		IsMethod:   true,
 		IsResolver: false,
 	}
 
 	ctx = graphql.WithFieldContext(ctx, fc)
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Description(), nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4203,9 +5965,9 @@ func (ec *executionContext) ___InputValue_description(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) ___InputValue_type(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
@@ -4275,6 +6037,38 @@ func (ec *executionContext) ___InputValue_defaultValue(ctx context.Context, fiel
 	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) ___Schema_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "__Schema",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Description(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
 func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -4538,9 +6332,9 @@ func (ec *executionContext) ___Type_description(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
 func (ec *executionContext) ___Type_fields(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
@@ -4749,6 +6543,38 @@ func (ec *executionContext) ___Type_ofType(ctx context.Context, field graphql.Co
 	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
 }
 
+func (ec *executionContext) ___Type_specifiedByURL(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	fc := &graphql.FieldContext{
+		Object:     "__Type",
+		Field:      field,
+		Args:       nil,
+		IsMethod:   true,
+		IsResolver: false,
+	}
+
+	ctx = graphql.WithFieldContext(ctx, fc)
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.SpecifiedByURL(), nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
 // endregion **************************** field.gotpl *****************************
 
 // region    **************************** input.gotpl *****************************
@@ -4784,6 +6610,45 @@ func (ec *executionContext) unmarshalInputAppliedMetadata(ctx context.Context, o
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputDimensionsInput(ctx context.Context, obj interface{}) (gqlmodel.DimensionsInput, error) {
+	var it gqlmodel.DimensionsInput
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "width":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("width"))
+			it.Width, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "depth":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("depth"))
+			it.Depth, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "height":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("height"))
+			it.Height, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputEvent(ctx context.Context, obj interface{}) (gqlmodel.Event, error) {
 	var it gqlmodel.Event
 	asMap := map[string]interface{}{}
@@ -4864,6 +6729,22 @@ func (ec *executionContext) unmarshalInputGetSimilarItemsInput(ctx context.Conte
 			if err != nil {
 				return it, err
 			}
+		case "cursor":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("cursor"))
+			it.Cursor, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "rankingProfile":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("rankingProfile"))
+			it.RankingProfile, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -4932,6 +6813,37 @@ func (ec *executionContext) unmarshalInputQuerySuggestionsDisplayActionParams(ct
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputRankingProfileInput(ctx context.Context, obj interface{}) (gqlmodel.RankingProfileInput, error) {
+	var it gqlmodel.RankingProfileInput
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "name":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+			it.Name, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "version":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("version"))
+			it.Version, err = ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputSearchClickItemActionParams(ctx context.Context, obj interface{}) (gqlmodel.SearchClickItemActionParams, error) {
 	var it gqlmodel.SearchClickItemActionParams
 	asMap := map[string]interface{}{}
@@ -5004,6 +6916,22 @@ func (ec *executionContext) unmarshalInputSearchDisplayItemsActionParams(ctx con
 			if err != nil {
 				return it, err
 			}
+		case "pinnedPositions":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pinnedPositions"))
+			it.PinnedPositions, err = ec.unmarshalOInt2ᚕintᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "rankingProfile":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("rankingProfile"))
+			it.RankingProfile, err = ec.unmarshalORankingProfileInput2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐRankingProfileInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -5035,6 +6963,14 @@ func (ec *executionContext) unmarshalInputSearchFilter(ctx context.Context, obj
 			if err != nil {
 				return it, err
 			}
+		case "shopIds":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("shopIds"))
+			it.ShopIds, err = ec.unmarshalOID2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		case "brandNames":
 			var err error
 
@@ -5075,6 +7011,62 @@ func (ec *executionContext) unmarshalInputSearchFilter(ctx context.Context, obj
 			if err != nil {
 				return it, err
 			}
+		case "minWidth":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("minWidth"))
+			it.MinWidth, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "maxWidth":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxWidth"))
+			it.MaxWidth, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "minDepth":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("minDepth"))
+			it.MinDepth, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "maxDepth":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxDepth"))
+			it.MaxDepth, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "minHeight":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("minHeight"))
+			it.MinHeight, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "maxHeight":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxHeight"))
+			it.MaxHeight, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "fitsWithin":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("fitsWithin"))
+			it.FitsWithin, err = ec.unmarshalODimensionsInput2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐDimensionsInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		case "metadata":
 			var err error
 
@@ -5122,19 +7114,106 @@ func (ec *executionContext) unmarshalInputSearchInput(ctx context.Context, obj i
 			if err != nil {
 				return it, err
 			}
-		case "page":
+		case "page":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("page"))
+			it.Page, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "pageSize":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pageSize"))
+			it.PageSize, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "cursor":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("cursor"))
+			it.Cursor, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "disableAutoCorrect":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("disableAutoCorrect"))
+			it.DisableAutoCorrect, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "rankingProfile":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("rankingProfile"))
+			it.RankingProfile, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "debug":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("debug"))
+			it.Debug, err = ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputSearchZeroResultActionParams(ctx context.Context, obj interface{}) (gqlmodel.SearchZeroResultActionParams, error) {
+	var it gqlmodel.SearchZeroResultActionParams
+	asMap := map[string]interface{}{}
+	for k, v := range obj.(map[string]interface{}) {
+		asMap[k] = v
+	}
+
+	for k, v := range asMap {
+		switch k {
+		case "searchId":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("searchId"))
+			it.SearchID, err = ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "searchInput":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("searchInput"))
+			it.SearchInput, err = ec.unmarshalNSearchInput2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "relaxation":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("relaxation"))
+			it.Relaxation, err = ec.unmarshalOSearchRelaxation2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchRelaxation(ctx, v)
+			if err != nil {
+				return it, err
+			}
+		case "recovered":
 			var err error
 
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("page"))
-			it.Page, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("recovered"))
+			it.Recovered, err = ec.unmarshalNBoolean2bool(ctx, v)
 			if err != nil {
 				return it, err
 			}
-		case "pageSize":
+		case "rankingProfile":
 			var err error
 
-			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pageSize"))
-			it.PageSize, err = ec.unmarshalOInt2ᚖint(ctx, v)
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("rankingProfile"))
+			it.RankingProfile, err = ec.unmarshalORankingProfileInput2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐRankingProfileInput(ctx, v)
 			if err != nil {
 				return it, err
 			}
@@ -5177,6 +7256,14 @@ func (ec *executionContext) unmarshalInputSimilarItemsDisplayItemsActionParams(c
 			if err != nil {
 				return it, err
 			}
+		case "rankingProfile":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("rankingProfile"))
+			it.RankingProfile, err = ec.unmarshalORankingProfileInput2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐRankingProfileInput(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -5275,6 +7362,81 @@ func (ec *executionContext) _Facet(ctx context.Context, sel ast.SelectionSet, ob
 
 			out.Values[i] = innerFunc(ctx)
 
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "stats":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Facet_stats(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		case "histogram":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Facet_histogram(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var facetStatsImplementors = []string{"FacetStats"}
+
+func (ec *executionContext) _FacetStats(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.FacetStats) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, facetStatsImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("FacetStats")
+		case "min":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._FacetStats_min(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "max":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._FacetStats_max(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "avg":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._FacetStats_avg(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "percentiles":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._FacetStats_percentiles(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
@@ -5326,6 +7488,23 @@ func (ec *executionContext) _FacetValue(ctx context.Context, sel ast.SelectionSe
 
 			out.Values[i] = innerFunc(ctx)
 
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "parentId":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._FacetValue_parentId(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		case "children":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._FacetValue_children(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
@@ -5367,6 +7546,67 @@ func (ec *executionContext) _GetSimilarItemsResponse(ctx context.Context, sel as
 
 			out.Values[i] = innerFunc(ctx)
 
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "rankingProfile":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._GetSimilarItemsResponse_rankingProfile(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var histogramBucketImplementors = []string{"HistogramBucket"}
+
+func (ec *executionContext) _HistogramBucket(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.HistogramBucket) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, histogramBucketImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("HistogramBucket")
+		case "min":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._HistogramBucket_min(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "max":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._HistogramBucket_max(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "count":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._HistogramBucket_count(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
@@ -5617,6 +7857,47 @@ func (ec *executionContext) _HomeResponse(ctx context.Context, sel ast.Selection
 	return out
 }
 
+var interpretedQueryPartImplementors = []string{"InterpretedQueryPart"}
+
+func (ec *executionContext) _InterpretedQueryPart(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.InterpretedQueryPart) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, interpretedQueryPartImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("InterpretedQueryPart")
+		case "text":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._InterpretedQueryPart_text(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "type":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._InterpretedQueryPart_type(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var itemImplementors = []string{"Item"}
 
 func (ec *executionContext) _Item(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.Item) graphql.Marshaler {
@@ -5724,9 +8005,6 @@ func (ec *executionContext) _Item(ctx context.Context, sel ast.SelectionSet, obj
 
 			out.Values[i] = innerFunc(ctx)
 
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
 		case "reviewCount":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Item_reviewCount(ctx, field, obj)
@@ -5734,9 +8012,6 @@ func (ec *executionContext) _Item(ctx context.Context, sel ast.SelectionSet, obj
 
 			out.Values[i] = innerFunc(ctx)
 
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
 		case "categoryId":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Item_categoryId(ctx, field, obj)
@@ -5777,6 +8052,13 @@ func (ec *executionContext) _Item(ctx context.Context, sel ast.SelectionSet, obj
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "highlight":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Item_highlight(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -5911,6 +8193,41 @@ func (ec *executionContext) _ItemConnection(ctx context.Context, sel ast.Selecti
 	return out
 }
 
+var itemHighlightImplementors = []string{"ItemHighlight"}
+
+func (ec *executionContext) _ItemHighlight(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.ItemHighlight) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, itemHighlightImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ItemHighlight")
+		case "name":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._ItemHighlight_name(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		case "description":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._ItemHighlight_description(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var mediaPostImplementors = []string{"MediaPost"}
 
 func (ec *executionContext) _MediaPost(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.MediaPost) graphql.Marshaler {
@@ -6095,7 +8412,65 @@ func (ec *executionContext) _PageInfo(ctx context.Context, sel ast.SelectionSet,
 			}
 		case "totalPage":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._PageInfo_totalPage(ctx, field, obj)
+				return ec._PageInfo_totalPage(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "totalCount":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._PageInfo_totalCount(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "endCursor":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._PageInfo_endCursor(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		case "hasNextPage":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._PageInfo_hasNextPage(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var percentileImplementors = []string{"Percentile"}
+
+func (ec *executionContext) _Percentile(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.Percentile) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, percentileImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Percentile")
+		case "percent":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Percentile_percent(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -6103,9 +8478,9 @@ func (ec *executionContext) _PageInfo(ctx context.Context, sel ast.SelectionSet,
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
-		case "totalCount":
+		case "value":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._PageInfo_totalCount(ctx, field, obj)
+				return ec._Percentile_value(ctx, field, obj)
 			}
 
 			out.Values[i] = innerFunc(ctx)
@@ -6347,6 +8722,169 @@ func (ec *executionContext) _QuerySuggestionsResponse(ctx context.Context, sel a
 	return out
 }
 
+var rankingProfileImplementors = []string{"RankingProfile"}
+
+func (ec *executionContext) _RankingProfile(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.RankingProfile) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rankingProfileImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RankingProfile")
+		case "name":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._RankingProfile_name(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "version":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._RankingProfile_version(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var searchDebugInfoImplementors = []string{"SearchDebugInfo"}
+
+func (ec *executionContext) _SearchDebugInfo(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.SearchDebugInfo) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, searchDebugInfoImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SearchDebugInfo")
+		case "esRequest":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchDebugInfo_esRequest(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "postFilters":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchDebugInfo_postFilters(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "metadataPostFilters":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchDebugInfo_metadataPostFilters(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "aggregations":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchDebugInfo_aggregations(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "hitExplanations":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchDebugInfo_hitExplanations(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var searchHitExplanationImplementors = []string{"SearchHitExplanation"}
+
+func (ec *executionContext) _SearchHitExplanation(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.SearchHitExplanation) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, searchHitExplanationImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SearchHitExplanation")
+		case "itemId":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchHitExplanation_itemId(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "score":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchHitExplanation_score(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "explanation":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchHitExplanation_explanation(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var searchResponseImplementors = []string{"SearchResponse"}
 
 func (ec *executionContext) _SearchResponse(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.SearchResponse) graphql.Marshaler {
@@ -6387,6 +8925,67 @@ func (ec *executionContext) _SearchResponse(ctx context.Context, sel ast.Selecti
 			if out.Values[i] == graphql.Null {
 				invalids++
 			}
+		case "interpretedQueryParts":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchResponse_interpretedQueryParts(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "relaxation":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchResponse_relaxation(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		case "spellingSuggestion":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchResponse_spellingSuggestion(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+		case "autoCorrected":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchResponse_autoCorrected(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "pinnedItemIds":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchResponse_pinnedItemIds(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "rankingProfile":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchResponse_rankingProfile(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "debug":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._SearchResponse_debug(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -6661,6 +9260,13 @@ func (ec *executionContext) ___Schema(ctx context.Context, sel ast.SelectionSet,
 		switch field.Name {
 		case "__typename":
 			out.Values[i] = graphql.MarshalString("__Schema")
+		case "description":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec.___Schema_description(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 		case "types":
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
 				return ec.___Schema_types(ctx, field, obj)
@@ -6792,6 +9398,13 @@ func (ec *executionContext) ___Type(ctx context.Context, sel ast.SelectionSet, o
 
 			out.Values[i] = innerFunc(ctx)
 
+		case "specifiedByURL":
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				return ec.___Type_specifiedByURL(ctx, field, obj)
+			}
+
+			out.Values[i] = innerFunc(ctx)
+
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -7009,6 +9622,16 @@ func (ec *executionContext) marshalNGetSimilarItemsResponse2ᚖgithubᚗcomᚋk
 	return ec._GetSimilarItemsResponse(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNHistogramBucket2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHistogramBucket(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.HistogramBucket) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._HistogramBucket(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNHomeComponent2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHomeComponentᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.HomeComponent) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -7193,14 +9816,78 @@ func (ec *executionContext) unmarshalNInt2int(ctx context.Context, v interface{}
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.SelectionSet, v int) graphql.Marshaler {
-	res := graphql.MarshalInt(v)
-	if res == graphql.Null {
+func (ec *executionContext) marshalNInt2int(ctx context.Context, sel ast.SelectionSet, v int) graphql.Marshaler {
+	res := graphql.MarshalInt(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) marshalNInterpretedQueryPart2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐInterpretedQueryPartᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.InterpretedQueryPart) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNInterpretedQueryPart2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐInterpretedQueryPart(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNInterpretedQueryPart2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐInterpretedQueryPart(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.InterpretedQueryPart) graphql.Marshaler {
+	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "must not be null")
 		}
+		return graphql.Null
 	}
-	return res
+	return ec._InterpretedQueryPart(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNInterpretedQueryPartType2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐInterpretedQueryPartType(ctx context.Context, v interface{}) (gqlmodel.InterpretedQueryPartType, error) {
+	var res gqlmodel.InterpretedQueryPartType
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNInterpretedQueryPartType2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐInterpretedQueryPartType(ctx context.Context, sel ast.SelectionSet, v gqlmodel.InterpretedQueryPartType) graphql.Marshaler {
+	return v
 }
 
 func (ec *executionContext) marshalNItem2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItem(ctx context.Context, sel ast.SelectionSet, v gqlmodel.Item) graphql.Marshaler {
@@ -7555,6 +10242,60 @@ func (ec *executionContext) marshalNPageInfo2ᚖgithubᚗcomᚋkᚑyomoᚋkagu
 	return ec._PageInfo(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNPercentile2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐPercentileᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.Percentile) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNPercentile2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐPercentile(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNPercentile2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐPercentile(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.Percentile) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._Percentile(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNQuerySuggestionsResponse2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐQuerySuggestionsResponse(ctx context.Context, sel ast.SelectionSet, v gqlmodel.QuerySuggestionsResponse) graphql.Marshaler {
 	return ec._QuerySuggestionsResponse(ctx, sel, &v)
 }
@@ -7569,6 +10310,16 @@ func (ec *executionContext) marshalNQuerySuggestionsResponse2ᚖgithubᚗcomᚋk
 	return ec._QuerySuggestionsResponse(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNRankingProfile2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐRankingProfile(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.RankingProfile) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._RankingProfile(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNSearchFrom2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchFrom(ctx context.Context, v interface{}) (gqlmodel.SearchFrom, error) {
 	var res gqlmodel.SearchFrom
 	err := res.UnmarshalGQL(v)
@@ -7579,6 +10330,60 @@ func (ec *executionContext) marshalNSearchFrom2githubᚗcomᚋkᚑyomoᚋkaguᚑ
 	return v
 }
 
+func (ec *executionContext) marshalNSearchHitExplanation2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchHitExplanationᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.SearchHitExplanation) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNSearchHitExplanation2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchHitExplanation(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNSearchHitExplanation2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchHitExplanation(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.SearchHitExplanation) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	return ec._SearchHitExplanation(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNSearchInput2githubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchInput(ctx context.Context, v interface{}) (gqlmodel.SearchInput, error) {
 	res, err := ec.unmarshalInputSearchInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -7964,6 +10769,84 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) unmarshalODimensionsInput2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐDimensionsInput(ctx context.Context, v interface{}) (*gqlmodel.DimensionsInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputDimensionsInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOFacetStats2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐFacetStats(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.FacetStats) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._FacetStats(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalOFloat2ᚖfloat64(ctx context.Context, v interface{}) (*float64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := graphql.UnmarshalFloatContext(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOFloat2ᚖfloat64(ctx context.Context, sel ast.SelectionSet, v *float64) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	res := graphql.MarshalFloatContext(*v)
+	return graphql.WrapContextMarshaler(ctx, res)
+}
+
+func (ec *executionContext) marshalOHistogramBucket2ᚕᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHistogramBucketᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.HistogramBucket) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNHistogramBucket2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐHistogramBucket(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOID2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
 	if v == nil {
 		return nil, nil
@@ -8018,6 +10901,44 @@ func (ec *executionContext) marshalOID2ᚖstring(ctx context.Context, sel ast.Se
 	return res
 }
 
+func (ec *executionContext) unmarshalOInt2ᚕintᚄ(ctx context.Context, v interface{}) ([]int, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]int, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNInt2int(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOInt2ᚕintᚄ(ctx context.Context, sel ast.SelectionSet, v []int) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNInt2int(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v interface{}) (*int, error) {
 	if v == nil {
 		return nil, nil
@@ -8108,6 +11029,13 @@ func (ec *executionContext) marshalOItemColor2ᚕgithubᚗcomᚋkᚑyomoᚋkagu
 	return ret
 }
 
+func (ec *executionContext) marshalOItemHighlight2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemHighlight(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.ItemHighlight) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._ItemHighlight(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOItemSellingPlatform2ᚕgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐItemSellingPlatformᚄ(ctx context.Context, v interface{}) ([]gqlmodel.ItemSellingPlatform, error) {
 	if v == nil {
 		return nil, nil
@@ -8175,6 +11103,21 @@ func (ec *executionContext) marshalOItemSellingPlatform2ᚕgithubᚗcomᚋkᚑyo
 	return ret
 }
 
+func (ec *executionContext) unmarshalORankingProfileInput2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐRankingProfileInput(ctx context.Context, v interface{}) (*gqlmodel.RankingProfileInput, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputRankingProfileInput(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOSearchDebugInfo2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchDebugInfo(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.SearchDebugInfo) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._SearchDebugInfo(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOSearchFilter2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchFilter(ctx context.Context, v interface{}) (*gqlmodel.SearchFilter, error) {
 	if v == nil {
 		return nil, nil
@@ -8183,30 +11126,36 @@ func (ec *executionContext) unmarshalOSearchFilter2ᚖgithubᚗcomᚋkᚑyomoᚋ
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) unmarshalOSearchSortType2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchSortType(ctx context.Context, v interface{}) (*gqlmodel.SearchSortType, error) {
+func (ec *executionContext) unmarshalOSearchRelaxation2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchRelaxation(ctx context.Context, v interface{}) (*gqlmodel.SearchRelaxation, error) {
 	if v == nil {
 		return nil, nil
 	}
-	var res = new(gqlmodel.SearchSortType)
+	var res = new(gqlmodel.SearchRelaxation)
 	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalOSearchSortType2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchSortType(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.SearchSortType) graphql.Marshaler {
+func (ec *executionContext) marshalOSearchRelaxation2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchRelaxation(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.SearchRelaxation) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
 	return v
 }
 
-func (ec *executionContext) unmarshalOString2string(ctx context.Context, v interface{}) (string, error) {
-	res, err := graphql.UnmarshalString(v)
+func (ec *executionContext) unmarshalOSearchSortType2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchSortType(ctx context.Context, v interface{}) (*gqlmodel.SearchSortType, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(gqlmodel.SearchSortType)
+	err := res.UnmarshalGQL(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalOString2string(ctx context.Context, sel ast.SelectionSet, v string) graphql.Marshaler {
-	res := graphql.MarshalString(v)
-	return res
+func (ec *executionContext) marshalOSearchSortType2ᚖgithubᚗcomᚋkᚑyomoᚋkaguᚑmiruᚋbackendᚋkagu_miru_apiᚋgraphᚋgqlmodelᚐSearchSortType(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.SearchSortType) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
 }
 
 func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v interface{}) ([]string, error) {
@@ -18,6 +18,12 @@ type AppliedMetadata struct {
 	Values []string `json:"values"`
 }
 
+type DimensionsInput struct {
+	Width  *int `json:"width"`
+	Depth  *int `json:"depth"`
+	Height *int `json:"height"`
+}
+
 type Event struct {
 	ID        EventID                `json:"id"`
 	Action    Action                 `json:"action"`
@@ -26,27 +32,47 @@ type Event struct {
 }
 
 type Facet struct {
-	Title      string        `json:"title"`
-	FacetType  FacetType     `json:"facetType"`
-	Values     []*FacetValue `json:"values"`
-	TotalCount int           `json:"totalCount"`
+	Title      string             `json:"title"`
+	FacetType  FacetType          `json:"facetType"`
+	Values     []*FacetValue      `json:"values"`
+	TotalCount int                `json:"totalCount"`
+	Stats      *FacetStats        `json:"stats"`
+	Histogram  []*HistogramBucket `json:"histogram"`
+}
+
+type FacetStats struct {
+	Min         float64       `json:"min"`
+	Max         float64       `json:"max"`
+	Avg         float64       `json:"avg"`
+	Percentiles []*Percentile `json:"percentiles"`
 }
 
 type FacetValue struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Count int    `json:"count"`
+	ID       string        `json:"id"`
+	Name     string        `json:"name"`
+	Count    int           `json:"count"`
+	ParentID *string       `json:"parentId"`
+	Children []*FacetValue `json:"children"`
 }
 
 type GetSimilarItemsInput struct {
-	ItemID   string `json:"itemId"`
-	Page     *int   `json:"page"`
-	PageSize *int   `json:"pageSize"`
+	ItemID         string  `json:"itemId"`
+	Page           *int    `json:"page"`
+	PageSize       *int    `json:"pageSize"`
+	Cursor         *string `json:"cursor"`
+	RankingProfile *string `json:"rankingProfile"`
 }
 
 type GetSimilarItemsResponse struct {
 	SearchID       string          `json:"searchId"`
 	ItemConnection *ItemConnection `json:"itemConnection"`
+	RankingProfile *RankingProfile `json:"rankingProfile"`
+}
+
+type HistogramBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
 }
 
 type HomeClickItemActionParams struct {
@@ -91,6 +117,11 @@ type HomeResponse struct {
 	Components []*HomeComponent `json:"components"`
 }
 
+type InterpretedQueryPart struct {
+	Text string                   `json:"text"`
+	Type InterpretedQueryPartType `json:"type"`
+}
+
 type Item struct {
 	ID             string              `json:"id"`
 	GroupID        string              `json:"groupID"`
@@ -101,12 +132,13 @@ type Item struct {
 	AffiliateURL   string              `json:"affiliateUrl"`
 	Price          int                 `json:"price"`
 	ImageUrls      []string            `json:"imageUrls"`
-	AverageRating  float64             `json:"averageRating"`
-	ReviewCount    int                 `json:"reviewCount"`
+	AverageRating  *float64            `json:"averageRating"`
+	ReviewCount    *int                `json:"reviewCount"`
 	CategoryID     string              `json:"categoryId"`
 	Colors         []ItemColor         `json:"colors"`
 	Platform       ItemSellingPlatform `json:"platform"`
 	SameGroupItems []*Item             `json:"sameGroupItems"`
+	Highlight      *ItemHighlight      `json:"highlight"`
 }
 
 type ItemCategory struct {
@@ -124,6 +156,11 @@ type ItemConnection struct {
 	Nodes    []*Item   `json:"nodes"`
 }
 
+type ItemHighlight struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
 type MediaPost struct {
 	Slug         string               `json:"slug"`
 	Title        string               `json:"title"`
@@ -139,9 +176,16 @@ type MediaPostCategory struct {
 }
 
 type PageInfo struct {
-	Page       int `json:"page"`
-	TotalPage  int `json:"totalPage"`
-	TotalCount int `json:"totalCount"`
+	Page        int     `json:"page"`
+	TotalPage   int     `json:"totalPage"`
+	TotalCount  int     `json:"totalCount"`
+	EndCursor   *string `json:"endCursor"`
+	HasNextPage bool    `json:"hasNextPage"`
+}
+
+type Percentile struct {
+	Percent float64 `json:"percent"`
+	Value   float64 `json:"value"`
 }
 
 type QuerySuggestionsDisplayActionParams struct {
@@ -154,64 +198,120 @@ type QuerySuggestionsResponse struct {
 	SuggestedQueries []string `json:"suggestedQueries"`
 }
 
+type RankingProfile struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
+type RankingProfileInput struct {
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+}
+
 type SearchClickItemActionParams struct {
 	SearchID string `json:"searchId"`
 	ItemID   string `json:"itemId"`
 }
 
+type SearchDebugInfo struct {
+	EsRequest           map[string]interface{}  `json:"esRequest"`
+	PostFilters         map[string]interface{}  `json:"postFilters"`
+	MetadataPostFilters map[string]interface{}  `json:"metadataPostFilters"`
+	Aggregations        map[string]interface{}  `json:"aggregations"`
+	HitExplanations     []*SearchHitExplanation `json:"hitExplanations"`
+}
+
 type SearchDisplayItemsActionParams struct {
-	SearchID    string       `json:"searchId"`
-	SearchFrom  SearchFrom   `json:"searchFrom"`
-	SearchInput *SearchInput `json:"searchInput"`
-	ItemIds     []string     `json:"itemIds"`
+	SearchID        string               `json:"searchId"`
+	SearchFrom      SearchFrom           `json:"searchFrom"`
+	SearchInput     *SearchInput         `json:"searchInput"`
+	ItemIds         []string             `json:"itemIds"`
+	PinnedPositions []int                `json:"pinnedPositions"`
+	RankingProfile  *RankingProfileInput `json:"rankingProfile"`
 }
 
 type SearchFilter struct {
 	CategoryIds []string              `json:"categoryIds"`
 	Platforms   []ItemSellingPlatform `json:"platforms"`
+	ShopIds     []string              `json:"shopIds"`
 	BrandNames  []string              `json:"brandNames"`
 	Colors      []ItemColor           `json:"colors"`
 	MinPrice    *int                  `json:"minPrice"`
 	MaxPrice    *int                  `json:"maxPrice"`
 	MinRating   *int                  `json:"minRating"`
+	MinWidth    *int                  `json:"minWidth"`
+	MaxWidth    *int                  `json:"maxWidth"`
+	MinDepth    *int                  `json:"minDepth"`
+	MaxDepth    *int                  `json:"maxDepth"`
+	MinHeight   *int                  `json:"minHeight"`
+	MaxHeight   *int                  `json:"maxHeight"`
+	FitsWithin  *DimensionsInput      `json:"fitsWithin"`
 	Metadata    []*AppliedMetadata    `json:"metadata"`
 }
 
+type SearchHitExplanation struct {
+	ItemID      string                 `json:"itemId"`
+	Score       float64                `json:"score"`
+	Explanation map[string]interface{} `json:"explanation"`
+}
+
 type SearchInput struct {
-	Query    string          `json:"query"`
-	SortType *SearchSortType `json:"sortType"`
-	Filter   *SearchFilter   `json:"filter"`
-	Page     *int            `json:"page"`
-	PageSize *int            `json:"pageSize"`
+	Query              string          `json:"query"`
+	SortType           *SearchSortType `json:"sortType"`
+	Filter             *SearchFilter   `json:"filter"`
+	Page               *int            `json:"page"`
+	PageSize           *int            `json:"pageSize"`
+	Cursor             *string         `json:"cursor"`
+	DisableAutoCorrect *bool           `json:"disableAutoCorrect"`
+	RankingProfile     *string         `json:"rankingProfile"`
+	Debug              *bool           `json:"debug"`
 }
 
 type SearchResponse struct {
-	SearchID       string          `json:"searchId"`
-	ItemConnection *ItemConnection `json:"itemConnection"`
-	Facets         []*Facet        `json:"facets"`
+	SearchID              string                  `json:"searchId"`
+	ItemConnection        *ItemConnection         `json:"itemConnection"`
+	Facets                []*Facet                `json:"facets"`
+	InterpretedQueryParts []*InterpretedQueryPart `json:"interpretedQueryParts"`
+	Relaxation            *SearchRelaxation       `json:"relaxation"`
+	SpellingSuggestion    *string                 `json:"spellingSuggestion"`
+	AutoCorrected         bool                    `json:"autoCorrected"`
+	PinnedItemIds         []string                `json:"pinnedItemIds"`
+	RankingProfile        *RankingProfile         `json:"rankingProfile"`
+	Debug                 *SearchDebugInfo        `json:"debug"`
+}
+
+type SearchZeroResultActionParams struct {
+	SearchID       string               `json:"searchId"`
+	SearchInput    *SearchInput         `json:"searchInput"`
+	Relaxation     *SearchRelaxation    `json:"relaxation"`
+	Recovered      bool                 `json:"recovered"`
+	RankingProfile *RankingProfileInput `json:"rankingProfile"`
 }
 
 type SimilarItemsDisplayItemsActionParams struct {
 	SearchID             string                `json:"searchId"`
 	GetSimilarItemsInput *GetSimilarItemsInput `json:"getSimilarItemsInput"`
 	ItemIds              []string              `json:"itemIds"`
+	RankingProfile       *RankingProfileInput  `json:"rankingProfile"`
 }
 
 type Action string
 
 const (
-	ActionDisplay   Action = "DISPLAY"
-	ActionClickItem Action = "CLICK_ITEM"
+	ActionDisplay    Action = "DISPLAY"
+	ActionClickItem  Action = "CLICK_ITEM"
+	ActionZeroResult Action = "ZERO_RESULT"
 )
 
 var AllAction = []Action{
 	ActionDisplay,
 	ActionClickItem,
+	ActionZeroResult,
 }
 
 func (e Action) IsValid() bool {
 	switch e {
-	case ActionDisplay, ActionClickItem:
+	case ActionDisplay, ActionClickItem, ActionZeroResult:
 		return true
 	}
 	return false
@@ -241,18 +341,20 @@ func (e Action) MarshalGQL(w io.Writer) {
 type ErrorCode string
 
 const (
-	ErrorCodeNotFound ErrorCode = "NOT_FOUND"
-	ErrorCodeInternal ErrorCode = "INTERNAL"
+	ErrorCodeNotFound        ErrorCode = "NOT_FOUND"
+	ErrorCodeInvalidArgument ErrorCode = "INVALID_ARGUMENT"
+	ErrorCodeInternal        ErrorCode = "INTERNAL"
 )
 
 var AllErrorCode = []ErrorCode{
 	ErrorCodeNotFound,
+	ErrorCodeInvalidArgument,
 	ErrorCodeInternal,
 }
 
 func (e ErrorCode) IsValid() bool {
 	switch e {
-	case ErrorCodeNotFound, ErrorCodeInternal:
+	case ErrorCodeNotFound, ErrorCodeInvalidArgument, ErrorCodeInternal:
 		return true
 	}
 	return false
@@ -327,10 +429,15 @@ func (e EventID) MarshalGQL(w io.Writer) {
 type FacetType string
 
 const (
-	FacetTypeCategoryIDS FacetType = "CATEGORY_IDS"
-	FacetTypeBrandNames  FacetType = "BRAND_NAMES"
-	FacetTypeColors      FacetType = "COLORS"
-	FacetTypeMetadata    FacetType = "METADATA"
+	FacetTypeCategoryIDS   FacetType = "CATEGORY_IDS"
+	FacetTypeBrandNames    FacetType = "BRAND_NAMES"
+	FacetTypeColors        FacetType = "COLORS"
+	FacetTypeMetadata      FacetType = "METADATA"
+	FacetTypePrice         FacetType = "PRICE"
+	FacetTypeAverageRating FacetType = "AVERAGE_RATING"
+	FacetTypeReviewCount   FacetType = "REVIEW_COUNT"
+	FacetTypePlatforms     FacetType = "PLATFORMS"
+	FacetTypeShopIDS       FacetType = "SHOP_IDS"
 )
 
 var AllFacetType = []FacetType{
@@ -338,11 +445,16 @@ var AllFacetType = []FacetType{
 	FacetTypeBrandNames,
 	FacetTypeColors,
 	FacetTypeMetadata,
+	FacetTypePrice,
+	FacetTypeAverageRating,
+	FacetTypeReviewCount,
+	FacetTypePlatforms,
+	FacetTypeShopIDS,
 }
 
 func (e FacetType) IsValid() bool {
 	switch e {
-	case FacetTypeCategoryIDS, FacetTypeBrandNames, FacetTypeColors, FacetTypeMetadata:
+	case FacetTypeCategoryIDS, FacetTypeBrandNames, FacetTypeColors, FacetTypeMetadata, FacetTypePrice, FacetTypeAverageRating, FacetTypeReviewCount, FacetTypePlatforms, FacetTypeShopIDS:
 		return true
 	}
 	return false
@@ -369,6 +481,51 @@ func (e FacetType) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type InterpretedQueryPartType string
+
+const (
+	InterpretedQueryPartTypeColor     InterpretedQueryPartType = "COLOR"
+	InterpretedQueryPartTypeBrand     InterpretedQueryPartType = "BRAND"
+	InterpretedQueryPartTypePrice     InterpretedQueryPartType = "PRICE"
+	InterpretedQueryPartTypeDimension InterpretedQueryPartType = "DIMENSION"
+)
+
+var AllInterpretedQueryPartType = []InterpretedQueryPartType{
+	InterpretedQueryPartTypeColor,
+	InterpretedQueryPartTypeBrand,
+	InterpretedQueryPartTypePrice,
+	InterpretedQueryPartTypeDimension,
+}
+
+func (e InterpretedQueryPartType) IsValid() bool {
+	switch e {
+	case InterpretedQueryPartTypeColor, InterpretedQueryPartTypeBrand, InterpretedQueryPartTypePrice, InterpretedQueryPartTypeDimension:
+		return true
+	}
+	return false
+}
+
+func (e InterpretedQueryPartType) String() string {
+	return string(e)
+}
+
+func (e *InterpretedQueryPartType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = InterpretedQueryPartType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid InterpretedQueryPartType", str)
+	}
+	return nil
+}
+
+func (e InterpretedQueryPartType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type ItemColor string
 
 const (
@@ -448,17 +605,19 @@ const (
 	ItemSellingPlatformRakuten       ItemSellingPlatform = "RAKUTEN"
 	ItemSellingPlatformYahooShopping ItemSellingPlatform = "YAHOO_SHOPPING"
 	ItemSellingPlatformPaypayMall    ItemSellingPlatform = "PAYPAY_MALL"
+	ItemSellingPlatformAmazon        ItemSellingPlatform = "AMAZON"
 )
 
 var AllItemSellingPlatform = []ItemSellingPlatform{
 	ItemSellingPlatformRakuten,
 	ItemSellingPlatformYahooShopping,
 	ItemSellingPlatformPaypayMall,
+	ItemSellingPlatformAmazon,
 }
 
 func (e ItemSellingPlatform) IsValid() bool {
 	switch e {
-	case ItemSellingPlatformRakuten, ItemSellingPlatformYahooShopping, ItemSellingPlatformPaypayMall:
+	case ItemSellingPlatformRakuten, ItemSellingPlatformYahooShopping, ItemSellingPlatformPaypayMall, ItemSellingPlatformAmazon:
 		return true
 	}
 	return false
@@ -577,6 +736,49 @@ func (e SearchFrom) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
+type SearchRelaxation string
+
+const (
+	SearchRelaxationMinimumShouldMatch SearchRelaxation = "MINIMUM_SHOULD_MATCH"
+	SearchRelaxationDropFilters        SearchRelaxation = "DROP_FILTERS"
+	SearchRelaxationCategoryOnly       SearchRelaxation = "CATEGORY_ONLY"
+)
+
+var AllSearchRelaxation = []SearchRelaxation{
+	SearchRelaxationMinimumShouldMatch,
+	SearchRelaxationDropFilters,
+	SearchRelaxationCategoryOnly,
+}
+
+func (e SearchRelaxation) IsValid() bool {
+	switch e {
+	case SearchRelaxationMinimumShouldMatch, SearchRelaxationDropFilters, SearchRelaxationCategoryOnly:
+		return true
+	}
+	return false
+}
+
+func (e SearchRelaxation) String() string {
+	return string(e)
+}
+
+func (e *SearchRelaxation) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SearchRelaxation(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SearchRelaxation", str)
+	}
+	return nil
+}
+
+func (e SearchRelaxation) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
 type SearchSortType string
 
 const (
@@ -1,11 +1,23 @@
 package graph
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/cms"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/experiment"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/merchandising"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/queryclassifier"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/search"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/tracking"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"github.com/k-yomo/kagu-miru/backend/pkg/pointerconv"
+	"go.uber.org/zap"
 )
 
 //go:generate go run github.com/99designs/gqlgen
@@ -14,25 +26,112 @@ type Resolver struct {
 	DBClient              db.Client
 	SearchClient          search.Client
 	QueryClassifierClient queryclassifier.QueryClassifier
+	MerchandisingClient   merchandising.Client
+	RankingClient         ranking.Client
 	CMSClient             cms.Client
 	SearchIDManager       *tracking.SearchIDManager
 	EventLoader           tracking.EventLoader
+	SearchDebugPolicy     *SearchDebugPolicy
 }
 
 func NewResolver(
 	dbClient db.Client,
 	searchClient search.Client,
 	queryClassifierClient queryclassifier.QueryClassifier,
+	merchandisingClient merchandising.Client,
+	rankingClient ranking.Client,
 	searchIDManager *tracking.SearchIDManager,
 	cmsClient cms.Client,
 	eventLoader tracking.EventLoader,
+	searchDebugPolicy *SearchDebugPolicy,
 ) *Resolver {
 	return &Resolver{
 		DBClient:              dbClient,
 		SearchClient:          searchClient,
 		QueryClassifierClient: queryClassifierClient,
+		MerchandisingClient:   merchandisingClient,
+		RankingClient:         rankingClient,
 		SearchIDManager:       searchIDManager,
 		CMSClient:             cmsClient,
 		EventLoader:           eventLoader,
+		SearchDebugPolicy:     searchDebugPolicy,
+	}
+}
+
+// getRankingProfile returns the requested ranking profile, or the profile of the experiment when not requested
+func (r *Resolver) getRankingProfile(ctx context.Context, requested *string, experimentProfile string) (*ranking.Profile, error) {
+	if requested == nil && experimentProfile != "" {
+		profile, err := r.RankingClient.GetProfile(ctx, experimentProfile)
+		if err == nil {
+			return profile, nil
+		}
+		// the experiment must not break the request even if the profile is deleted while running
+		logging.Logger(ctx).Warn("failed to get ranking profile of the experiment", zap.String("profile", experimentProfile), zap.Error(err))
+	}
+	profile, err := r.RankingClient.GetProfile(ctx, pointerconv.PointerToString(requested))
+	if err != nil {
+		return nil, fmt.Errorf("RankingClient.GetProfile: %w", err)
+	}
+	return profile, nil
+}
+
+// search searches items with the request's ranking profile and merchandising
+// trackZeroResult should be false for the searches not requested by users directly e.g. home components,
+// not to mix them into the zero result analysis.
+func (r *Resolver) search(ctx context.Context, input gqlmodel.SearchInput, trackZeroResult bool) (*gqlmodel.SearchResponse, error) {
+	if input.Filter == nil {
+		input.Filter = &gqlmodel.SearchFilter{}
+	}
+	rankingProfile, err := r.getRankingProfile(ctx, input.RankingProfile, experiment.GetAssignmentsFromCtx(ctx).SearchRankingProfile())
+	if err != nil {
+		return nil, err
+	}
+	searchOpts := &search.SearchItemsOptions{RankingProfile: rankingProfile}
+	if input.Debug != nil && *input.Debug {
+		if !r.SearchDebugPolicy.IsAllowed(ctx) {
+			return nil, xerror.NewInvalidArgument(errors.New("debug mode is not allowed"))
+		}
+		searchOpts.Debug = true
+	}
+	// predicted categories are boosted instead of filtered not to hide items when the prediction is wrong
+	if input.Query != "" && len(input.Filter.CategoryIds) == 0 {
+		categoryIDs, err := r.QueryClassifierClient.CategorizeQuery(ctx, input.Query)
+		if err != nil {
+			logging.Logger(ctx).Warn("failed to predict query's category", zap.Error(err))
+		}
+		searchOpts.BoostCategoryIDs = categoryIDs
+	}
+	merchandising, err := r.MerchandisingClient.GetMerchandising(ctx, input.Query, input.Filter.CategoryIds)
+	if err != nil {
+		// search result can be still returned without merchandising
+		logging.Logger(ctx).Warn("failed to get merchandising", zap.Error(err))
+	} else {
+		searchOpts.PinnedItemIDs = merchandising.PinnedItemIDs
+		searchOpts.BuriedItemIDs = merchandising.BuriedItemIDs
+		searchOpts.BuriedGroupIDs = merchandising.BuriedGroupIDs
+	}
+
+	resp, err := r.SearchClient.SearchItems(ctx, &input, searchOpts)
+	if err != nil {
+		return nil, fmt.Errorf("SearchClient.SearchItems: %w", err)
+	}
+
+	searchID := r.SearchIDManager.GetSearchID(ctx)
+	gqlRes, err := mapSearchResponseToGraphqlSearchResponse(resp, searchID)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("mapSearchResponseToGraphqlGetSimilarItemsResponse: %w", err))
+	}
+	if trackZeroResult && (resp.Relaxation != search.RelaxationNone || resp.TotalCount == 0) {
+		r.EventLoader.Load(ctx, tracking.NewSearchZeroResultEvent(ctx, &tracking.SearchZeroResultActionParams{
+			SearchID:    searchID,
+			SearchInput: &input,
+			Relaxation:  gqlRes.Relaxation,
+			Recovered:   resp.TotalCount > 0,
+			RankingProfile: &gqlmodel.RankingProfileInput{
+				Name:    gqlRes.RankingProfile.Name,
+				Version: gqlRes.RankingProfile.Version,
+			},
+		}))
 	}
+	return gqlRes, nil
 }
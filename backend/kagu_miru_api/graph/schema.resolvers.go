@@ -10,6 +10,7 @@ import (
 
 	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/cms"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/experiment"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlgen"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/tracking"
@@ -29,39 +30,52 @@ func (r *queryResolver) Home(ctx context.Context) (*gqlmodel.HomeResponse, error
 	var rakutenItemsRes *gqlmodel.SearchResponse
 	eg.Go(func() error {
 		var err error
-		rakutenItemsRes, err = r.Search(ctx, gqlmodel.SearchInput{
+		rakutenItemsRes, err = r.search(ctx, gqlmodel.SearchInput{
 			Filter: &gqlmodel.SearchFilter{
 				Platforms: []gqlmodel.ItemSellingPlatform{gqlmodel.ItemSellingPlatformRakuten},
 			},
 			SortType: &sortType,
 			PageSize: func() *int { i := 20; return &i }(),
-		})
+		}, false)
 		return err
 	})
 
 	var yahooShoppingItemsRes *gqlmodel.SearchResponse
 	eg.Go(func() error {
 		var err error
-		yahooShoppingItemsRes, err = r.Search(ctx, gqlmodel.SearchInput{
+		yahooShoppingItemsRes, err = r.search(ctx, gqlmodel.SearchInput{
 			Filter: &gqlmodel.SearchFilter{
 				Platforms: []gqlmodel.ItemSellingPlatform{gqlmodel.ItemSellingPlatformYahooShopping},
 			},
 			SortType: &sortType,
 			PageSize: func() *int { i := 20; return &i }(),
-		})
+		}, false)
 		return err
 	})
 
 	var paypayMallItemsRes *gqlmodel.SearchResponse
 	eg.Go(func() error {
 		var err error
-		paypayMallItemsRes, err = r.Search(ctx, gqlmodel.SearchInput{
+		paypayMallItemsRes, err = r.search(ctx, gqlmodel.SearchInput{
 			Filter: &gqlmodel.SearchFilter{
 				Platforms: []gqlmodel.ItemSellingPlatform{gqlmodel.ItemSellingPlatformPaypayMall},
 			},
 			SortType: &sortType,
 			PageSize: func() *int { i := 20; return &i }(),
-		})
+		}, false)
+		return err
+	})
+
+	var amazonItemsRes *gqlmodel.SearchResponse
+	eg.Go(func() error {
+		var err error
+		// Amazon items are sorted by the best match since review count is not available
+		amazonItemsRes, err = r.search(ctx, gqlmodel.SearchInput{
+			Filter: &gqlmodel.SearchFilter{
+				Platforms: []gqlmodel.ItemSellingPlatform{gqlmodel.ItemSellingPlatformAmazon},
+			},
+			PageSize: func() *int { i := 20; return &i }(),
+		}, false)
 		return err
 	})
 
@@ -93,24 +107,32 @@ func (r *queryResolver) Home(ctx context.Context) (*gqlmodel.HomeResponse, error
 		return nil, err
 	}
 
+	platformPopularItems := []*gqlmodel.HomeComponentPayloadItems{
+		{
+			Title: "楽天",
+			Items: rakutenItemsRes.ItemConnection.Nodes,
+		},
+		{
+			Title: "Yahooショッピング",
+			Items: yahooShoppingItemsRes.ItemConnection.Nodes,
+		},
+		{
+			Title: "PayPayモール",
+			Items: paypayMallItemsRes.ItemConnection.Nodes,
+		},
+	}
+	// Amazon items are displayed only after they are indexed not to show the empty group
+	if len(amazonItemsRes.ItemConnection.Nodes) > 0 {
+		platformPopularItems = append(platformPopularItems, &gqlmodel.HomeComponentPayloadItems{
+			Title: "Amazon",
+			Items: amazonItemsRes.ItemConnection.Nodes,
+		})
+	}
 	platformPopularItemGroupsComponent := &gqlmodel.HomeComponent{
 		ID: "platformPopularItemGroups",
 		Payload: gqlmodel.HomeComponentPayloadItemGroups{
-			Title: "EC人気アイテム",
-			Payload: []*gqlmodel.HomeComponentPayloadItems{
-				{
-					Title: "楽天",
-					Items: rakutenItemsRes.ItemConnection.Nodes,
-				},
-				{
-					Title: "Yahooショッピング",
-					Items: yahooShoppingItemsRes.ItemConnection.Nodes,
-				},
-				{
-					Title: "PayPayモール",
-					Items: paypayMallItemsRes.ItemConnection.Nodes,
-				},
-			},
+			Title:   "EC人気アイテム",
+			Payload: platformPopularItems,
 		},
 	}
 
@@ -138,28 +160,7 @@ func (r *queryResolver) Home(ctx context.Context) (*gqlmodel.HomeResponse, error
 }
 
 func (r *queryResolver) Search(ctx context.Context, input gqlmodel.SearchInput) (*gqlmodel.SearchResponse, error) {
-	if input.Filter == nil {
-		input.Filter = &gqlmodel.SearchFilter{}
-	}
-	// Categorizing Query is slow (~ 1sec), so disabling for the time being
-	// if input.Query != "" && len(input.Filter.CategoryIds) == 0 {
-	// 	categoryIDs, err := r.QueryClassifierClient.CategorizeQuery(ctx, input.Query)
-	// 	if err != nil {
-	// 		logging.Logger(ctx).Error("failed to predict query's category", zap.Error(err))
-	// 	}
-	// 	input.Filter.CategoryIds = categoryIDs
-	// }
-
-	resp, err := r.SearchClient.SearchItems(ctx, &input)
-	if err != nil {
-		return nil, fmt.Errorf("SearchClient.SearchItems: %w", err)
-	}
-
-	gqlRes, err := mapSearchResponseToGraphqlSearchResponse(resp, r.SearchIDManager.GetSearchID(ctx))
-	if err != nil {
-		return nil, logging.Error(ctx, fmt.Errorf("mapSearchResponseToGraphqlGetSimilarItemsResponse: %w", err))
-	}
-	return gqlRes, nil
+	return r.search(ctx, input, true)
 }
 
 func (r *queryResolver) GetSimilarItems(ctx context.Context, input gqlmodel.GetSimilarItemsInput) (*gqlmodel.GetSimilarItemsResponse, error) {
@@ -167,7 +168,11 @@ func (r *queryResolver) GetSimilarItems(ctx context.Context, input gqlmodel.GetS
 	if err != nil {
 		return nil, fmt.Errorf("DBClient.GetItem: %w", err)
 	}
-	resp, err := r.SearchClient.GetSimilarItems(ctx, &input, item)
+	rankingProfile, err := r.getRankingProfile(ctx, input.RankingProfile, experiment.GetAssignmentsFromCtx(ctx).SimilarItemsRankingProfile())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.SearchClient.GetSimilarItems(ctx, &input, item, rankingProfile)
 	if err != nil {
 		return nil, fmt.Errorf("SearchClient.GetSimilarItems: %w", err)
 	}
@@ -209,6 +214,13 @@ func (r *queryResolver) GetItem(ctx context.Context, id string) (*gqlmodel.Item,
 		return nil, xerror.NewNotFound(fmt.Errorf("item '%s' is not found", id))
 	}
 
+	// items are sorted before mapping since the rating is null for the platform not providing reviews
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].AverageRating == items[j].AverageRating {
+			return items[i].ReviewCount > items[j].ReviewCount
+		}
+		return items[i].AverageRating > items[j].AverageRating
+	})
 	var targetItem *gqlmodel.Item
 	var sameGroupItems []*gqlmodel.Item
 	for _, item := range items {
@@ -222,12 +234,6 @@ func (r *queryResolver) GetItem(ctx context.Context, id string) (*gqlmodel.Item,
 			sameGroupItems = append(sameGroupItems, gqlItem)
 		}
 	}
-	sort.Slice(sameGroupItems, func(i, j int) bool {
-		if sameGroupItems[i].AverageRating == sameGroupItems[j].AverageRating {
-			return sameGroupItems[i].ReviewCount > sameGroupItems[j].ReviewCount
-		}
-		return sameGroupItems[i].AverageRating > sameGroupItems[j].AverageRating
-	})
 	targetItem.SameGroupItems = sameGroupItems
 
 	return targetItem, nil
@@ -9,10 +9,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/admin"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/cms"
 	sanity "github.com/sanity-io/client-go"
 
 	aiplatform "cloud.google.com/go/aiplatform/apiv1"
+	"cloud.google.com/go/bigquery"
 	"cloud.google.com/go/profiler"
 	"cloud.google.com/go/pubsub"
 	"cloud.google.com/go/spanner"
@@ -20,13 +22,18 @@ import (
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/config"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/experiment"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlgen"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/merchandising"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/queryclassifier"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/request"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/search"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/synonym"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/tracking"
 	"github.com/k-yomo/kagu-miru/backend/pkg/csrf"
 	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
@@ -92,16 +99,37 @@ func main() {
 		logger.Fatal("failed to initialize elasticsearch client", zap.Error(err))
 	}
 	searchClient := search.NewSearchClient(cfg.ItemsIndexName, cfg.ItemsQuerySuggestionsIndexName, esClient, dbClient)
-
-	predictionClient, err := aiplatform.NewPredictionClient(
-		context.Background(),
-		option.WithEndpoint("us-central1-aiplatform.googleapis.com:443"),
-		option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor())),
-	)
-	if err != nil {
-		logger.Fatal("failed to initialize elasticsearch client", zap.Error(err))
+	synonymClient := synonym.NewSynonymClient(cfg.ItemsIndexName, esClient, dbClient)
+	merchandisingClient := merchandising.NewMerchandisingClient(dbClient)
+	rankingClient := ranking.NewRankingClient(dbClient)
+	experimentClient := experiment.NewExperimentClient(dbClient, rankingClient)
+
+	var clickStatsFetcher queryclassifier.ClickStatsFetcher
+	if cfg.BigQueryEventsTable != "" {
+		bqClient, err := bigquery.NewClient(context.Background(), cfg.GCPProjectID)
+		if err != nil {
+			logger.Fatal("failed to initialize bigquery client", zap.Error(err))
+		}
+		clickStatsFetcher = queryclassifier.NewBigQueryClickStatsFetcher(bqClient, cfg.BigQueryEventsTable, dbClient)
+	}
+	localQueryClassifier := queryclassifier.NewLocalQueryClassifier(dbClient, clickStatsFetcher)
+	go localQueryClassifier.Run(ctxzap.ToContext(context.Background(), logger))
+
+	var queryClassifierClient queryclassifier.QueryClassifier = localQueryClassifier
+	if cfg.VertexAICategoryClassificationEndpointID != "" {
+		predictionClient, err := aiplatform.NewPredictionClient(
+			context.Background(),
+			option.WithEndpoint("us-central1-aiplatform.googleapis.com:443"),
+			option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor())),
+		)
+		if err != nil {
+			logger.Fatal("failed to initialize prediction client", zap.Error(err))
+		}
+		queryClassifierClient = queryclassifier.NewFallbackQueryClassifier(
+			localQueryClassifier,
+			queryclassifier.NewQueryClassifierClient(predictionClient, cfg.GCPProjectID, cfg.VertexAICategoryClassificationEndpointID),
+		)
 	}
-	queryClassifierClient := queryclassifier.NewQueryClassifierClient(predictionClient, cfg.GCPProjectID, cfg.VertexAICategoryClassificationEndpointID)
 
 	sanityClient, err := sanity.New(cfg.SanityProjectID, sanity.WithDataset(cfg.SanityDatasetID))
 	if err != nil {
@@ -123,7 +151,7 @@ func main() {
 	searchIDManager := tracking.NewSearchIDManager(cfg.Env.IsDeployed())
 
 	gqlConfig := gqlgen.Config{
-		Resolvers: graph.NewResolver(dbClient, searchClient, queryClassifierClient, searchIDManager, cmsClient, eventLoader),
+		Resolvers: graph.NewResolver(dbClient, searchClient, queryClassifierClient, merchandisingClient, rankingClient, searchIDManager, cmsClient, eventLoader, graph.NewSearchDebugPolicy(cfg.Env != config.EnvProd, cfg.SearchDebugToken)),
 	}
 	gqlServer := handler.NewDefaultServer(gqlgen.NewExecutableSchema(gqlConfig))
 	gqlServer.Use(tracing.GraphqlExtension{})
@@ -133,7 +161,10 @@ func main() {
 	r := newBaseRouter(cfg, logger, searchIDManager)
 	r.Route("/api", func(r chi.Router) {
 		r.Handle("/graphql/playground", playground.Handler("GraphQL playground", "/api/graphql"))
-		r.Handle("/graphql", gqlServer)
+		r.With(experiment.NewMiddleware(experimentClient, searchIDManager)).Handle("/graphql", gqlServer)
+		if cfg.AdminAPIToken != "" {
+			r.Mount("/admin", admin.NewHandler(cfg.AdminAPIToken, synonymClient, merchandisingClient, rankingClient, experimentClient).Routes())
+		}
 	})
 
 	httpServer := &http.Server{
@@ -0,0 +1,270 @@
+package merchandising
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"github.com/k-yomo/kagu-miru/backend/pkg/strutil"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	rulesCacheTTL = 1 * time.Minute
+	// maxPinnedItemsPerRule is limited since pinned items occupy the top of the search result
+	maxPinnedItemsPerRule = 20
+	maxBuriedItemsPerRule = 1000
+	// maxPinnedItems is the max number of ids the pinned query accepts
+	maxPinnedItems = 100
+)
+
+type Client interface {
+	GetAllRules(ctx context.Context) ([]*xspanner.MerchandisingRule, error)
+	SaveRule(ctx context.Context, rule *xspanner.MerchandisingRule) (*xspanner.MerchandisingRule, error)
+	DeleteRule(ctx context.Context, ruleID string) error
+	// GetMerchandising returns the items to pin and bury for the query and the filtered categories
+	GetMerchandising(ctx context.Context, query string, categoryIDs []string) (*Merchandising, error)
+}
+
+// Merchandising is the merged result of the rules matched to the search
+type Merchandising struct {
+	RuleIDs []string
+	// PinnedItemIDs are in the order to be displayed
+	PinnedItemIDs  []string
+	BuriedItemIDs  []string
+	BuriedGroupIDs []string
+}
+
+type merchandisingClient struct {
+	dbClient db.Client
+
+	mu        sync.RWMutex
+	rules     []*rule
+	expiresAt time.Time
+}
+
+// rule is the merchandising rule with pinned groups resolved to the items
+type rule struct {
+	*xspanner.MerchandisingRule
+	pinnedItemIDs []string
+}
+
+func NewMerchandisingClient(dbClient db.Client) Client {
+	return &merchandisingClient{dbClient: dbClient}
+}
+
+func (m *merchandisingClient) GetAllRules(ctx context.Context) ([]*xspanner.MerchandisingRule, error) {
+	return m.dbClient.GetAllMerchandisingRules(ctx)
+}
+
+func (m *merchandisingClient) SaveRule(ctx context.Context, rule *xspanner.MerchandisingRule) (*xspanner.MerchandisingRule, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "merchandising.merchandisingClient_SaveRule")
+	defer span.End()
+
+	normalized, err := normalizeRule(rule)
+	if err != nil {
+		return nil, xerror.NewInvalidArgument(err)
+	}
+	saved, err := m.dbClient.SaveMerchandisingRule(ctx, normalized)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("dbClient.SaveMerchandisingRule: %w", err))
+	}
+	m.invalidateCache()
+	return saved, nil
+}
+
+func (m *merchandisingClient) DeleteRule(ctx context.Context, ruleID string) error {
+	ctx, span := otel.Tracer("").Start(ctx, "merchandising.merchandisingClient_DeleteRule")
+	defer span.End()
+
+	if err := m.dbClient.DeleteMerchandisingRule(ctx, ruleID); err != nil {
+		if xerror.IsErrorType(err, xerror.TypeNotFound) {
+			return err
+		}
+		return logging.Error(ctx, fmt.Errorf("dbClient.DeleteMerchandisingRule: %w", err))
+	}
+	m.invalidateCache()
+	return nil
+}
+
+func (m *merchandisingClient) GetMerchandising(ctx context.Context, query string, categoryIDs []string) (*Merchandising, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "merchandising.merchandisingClient_GetMerchandising")
+	defer span.End()
+
+	rules, err := m.getRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	merchandising := matchRules(rules, xitem.NormalizeQuery(query), categoryIDs, time.Now())
+	span.SetAttributes(attribute.StringSlice("ruleIds", merchandising.RuleIDs))
+	return merchandising, nil
+}
+
+// getRules returns the cached unexpired rules
+// Since rules are cached on each server, changes are reflected to the other servers after the cache expires.
+func (m *merchandisingClient) getRules(ctx context.Context) ([]*rule, error) {
+	m.mu.RLock()
+	rules, expiresAt := m.rules, m.expiresAt
+	m.mu.RUnlock()
+	if rules != nil && time.Now().Before(expiresAt) {
+		return rules, nil
+	}
+
+	rules, err := m.fetchRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m.mu.Lock()
+	m.rules = rules
+	m.expiresAt = time.Now().Add(rulesCacheTTL)
+	m.mu.Unlock()
+	return rules, nil
+}
+
+func (m *merchandisingClient) fetchRules(ctx context.Context) ([]*rule, error) {
+	spannerRules, err := m.dbClient.GetUnexpiredMerchandisingRules(ctx, time.Now())
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("dbClient.GetUnexpiredMerchandisingRules: %w", err))
+	}
+
+	var groupIDs []string
+	for _, spannerRule := range spannerRules {
+		groupIDs = append(groupIDs, spannerRule.PinnedGroupIDs...)
+	}
+	itemIDsMap := make(map[string][]string)
+	if len(groupIDs) > 0 {
+		itemIDsMap, err = m.dbClient.GetItemIDsMapByGroupIDs(ctx, groupIDs)
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("dbClient.GetItemIDsMapByGroupIDs: %w", err))
+		}
+	}
+
+	rules := make([]*rule, 0, len(spannerRules))
+	for _, spannerRule := range spannerRules {
+		rules = append(rules, &rule{MerchandisingRule: spannerRule, pinnedItemIDs: expandPinnedItemIDs(spannerRule, itemIDsMap)})
+	}
+	return rules, nil
+}
+
+// expandPinnedItemIDs returns the pinned items followed by the items in the pinned groups
+// A group can have many items, so the items are capped again after the expansion.
+func expandPinnedItemIDs(r *xspanner.MerchandisingRule, itemIDsMap map[string][]string) []string {
+	pinnedItemIDs := append([]string{}, r.PinnedItemIDs...)
+	for _, groupID := range r.PinnedGroupIDs {
+		pinnedItemIDs = append(pinnedItemIDs, itemIDsMap[groupID]...)
+	}
+	pinnedItemIDs = dedupStrings(pinnedItemIDs)
+	if len(pinnedItemIDs) > maxPinnedItemsPerRule {
+		pinnedItemIDs = pinnedItemIDs[:maxPinnedItemsPerRule]
+	}
+	return pinnedItemIDs
+}
+
+func (m *merchandisingClient) invalidateCache() {
+	m.mu.Lock()
+	m.expiresAt = time.Time{}
+	m.mu.Unlock()
+}
+
+// matchRules merges the rules in effect matched to the query and categories
+// Rule's query and category must match when they are set, and item pinned by any rule is never buried.
+func matchRules(rules []*rule, query string, categoryIDs []string, now time.Time) *Merchandising {
+	merchandising := &Merchandising{}
+	var buriedItemIDs []string
+	for _, r := range rules {
+		if !r.IsActive || now.Before(r.StartsAt) || !now.Before(r.EndsAt) {
+			continue
+		}
+		if r.Query.Valid && r.Query.StringVal != query {
+			continue
+		}
+		if r.CategoryID.Valid && !strutil.Contains(categoryIDs, r.CategoryID.StringVal) {
+			continue
+		}
+		merchandising.RuleIDs = append(merchandising.RuleIDs, r.ID)
+		merchandising.PinnedItemIDs = append(merchandising.PinnedItemIDs, r.pinnedItemIDs...)
+		buriedItemIDs = append(buriedItemIDs, r.BuriedItemIDs...)
+		merchandising.BuriedGroupIDs = append(merchandising.BuriedGroupIDs, r.BuriedGroupIDs...)
+	}
+	// items pinned by the earlier rules are kept when the merged items exceed the limit
+	merchandising.PinnedItemIDs = dedupStrings(merchandising.PinnedItemIDs)
+	if len(merchandising.PinnedItemIDs) > maxPinnedItems {
+		merchandising.PinnedItemIDs = merchandising.PinnedItemIDs[:maxPinnedItems]
+	}
+	for _, itemID := range dedupStrings(buriedItemIDs) {
+		if !strutil.Contains(merchandising.PinnedItemIDs, itemID) {
+			merchandising.BuriedItemIDs = append(merchandising.BuriedItemIDs, itemID)
+		}
+	}
+	merchandising.BuriedGroupIDs = dedupStrings(merchandising.BuriedGroupIDs)
+	return merchandising
+}
+
+// normalizeRule validates the rule and normalizes the query and item ids
+func normalizeRule(r *xspanner.MerchandisingRule) (*xspanner.MerchandisingRule, error) {
+	normalized := *r
+	if strings.TrimSpace(normalized.Name) == "" {
+		return nil, errors.New("name is required")
+	}
+	normalized.Query = spanner.NullString{}
+	if query := xitem.NormalizeQuery(r.Query.StringVal); r.Query.Valid && query != "" {
+		normalized.Query = spanner.NullString{StringVal: query, Valid: true}
+	}
+	normalized.CategoryID = spanner.NullString{}
+	if categoryID := strings.TrimSpace(r.CategoryID.StringVal); r.CategoryID.Valid && categoryID != "" {
+		normalized.CategoryID = spanner.NullString{StringVal: categoryID, Valid: true}
+	}
+	if !normalized.Query.Valid && !normalized.CategoryID.Valid {
+		return nil, errors.New("query or category id is required")
+	}
+
+	normalized.PinnedItemIDs = dedupStrings(r.PinnedItemIDs)
+	normalized.PinnedGroupIDs = dedupStrings(r.PinnedGroupIDs)
+	normalized.BuriedItemIDs = dedupStrings(r.BuriedItemIDs)
+	normalized.BuriedGroupIDs = dedupStrings(r.BuriedGroupIDs)
+	pinnedCount := len(normalized.PinnedItemIDs) + len(normalized.PinnedGroupIDs)
+	buriedCount := len(normalized.BuriedItemIDs) + len(normalized.BuriedGroupIDs)
+	if pinnedCount == 0 && buriedCount == 0 {
+		return nil, errors.New("at least one item or group must be pinned or buried")
+	}
+	if pinnedCount > maxPinnedItemsPerRule {
+		return nil, fmt.Errorf("up to %d items and groups can be pinned", maxPinnedItemsPerRule)
+	}
+	if buriedCount > maxBuriedItemsPerRule {
+		return nil, fmt.Errorf("up to %d items and groups can be buried", maxBuriedItemsPerRule)
+	}
+
+	if normalized.StartsAt.IsZero() || normalized.EndsAt.IsZero() {
+		return nil, errors.New("startsAt and endsAt are required")
+	}
+	if !normalized.StartsAt.Before(normalized.EndsAt) {
+		return nil, errors.New("startsAt must be before endsAt")
+	}
+	return &normalized, nil
+}
+
+// dedupStrings trims and removes empty and duplicated strings keeping the order
+// Returned slice is never nil since array columns are not nullable.
+func dedupStrings(strs []string) []string {
+	seen := make(map[string]bool, len(strs))
+	deduped := make([]string, 0, len(strs))
+	for _, s := range strs {
+		s = strings.TrimSpace(s)
+		if s == "" || seen[s] {
+			continue
+		}
+		seen[s] = true
+		deduped = append(deduped, s)
+	}
+	return deduped
+}
@@ -0,0 +1,245 @@
+package merchandising
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+func Test_matchRules(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	newRule := func(id string, query string, categoryID string, pinnedItemIDs []string, buriedItemIDs []string) *rule {
+		r := &rule{
+			MerchandisingRule: &xspanner.MerchandisingRule{
+				ID:             id,
+				BuriedItemIDs:  buriedItemIDs,
+				BuriedGroupIDs: []string{},
+				StartsAt:       now.Add(-time.Hour),
+				EndsAt:         now.Add(time.Hour),
+				IsActive:       true,
+			},
+			pinnedItemIDs: pinnedItemIDs,
+		}
+		if query != "" {
+			r.Query = spanner.NullString{StringVal: query, Valid: true}
+		}
+		if categoryID != "" {
+			r.CategoryID = spanner.NullString{StringVal: categoryID, Valid: true}
+		}
+		return r
+	}
+	newItemIDs := func(prefix string, n int) []string {
+		itemIDs := make([]string, 0, n)
+		for i := 0; i < n; i++ {
+			itemIDs = append(itemIDs, fmt.Sprintf("%s%d", prefix, i))
+		}
+		return itemIDs
+	}
+	var manyPinningRules []*rule
+	var manyPinnedItemIDs []string
+	for i := 0; i < 6; i++ {
+		itemIDs := newItemIDs(fmt.Sprintf("rule%d_", i), maxPinnedItemsPerRule)
+		manyPinningRules = append(manyPinningRules, newRule(fmt.Sprintf("rule%d", i), "ソファ", "", itemIDs, nil))
+		manyPinnedItemIDs = append(manyPinnedItemIDs, itemIDs...)
+	}
+	expiredRule := newRule("expired", "ソファ", "", []string{"expired"}, nil)
+	expiredRule.EndsAt = now
+	notStartedRule := newRule("not_started", "ソファ", "", []string{"not_started"}, nil)
+	notStartedRule.StartsAt = now.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		rules       []*rule
+		query       string
+		categoryIDs []string
+		want        *Merchandising
+	}{
+		{
+			name: "merges rules matched to the query and category",
+			rules: []*rule{
+				newRule("query", "ニトリ ソファ", "", []string{"a", "b"}, []string{"x"}),
+				newRule("category", "", "100", []string{"b", "c"}, []string{"y"}),
+				newRule("query_and_category", "ニトリ ソファ", "200", []string{"d"}, nil),
+				newRule("other_query", "ベッド", "", []string{"e"}, nil),
+			},
+			query:       "ニトリ ソファ",
+			categoryIDs: []string{"100"},
+			want: &Merchandising{
+				RuleIDs:        []string{"query", "category"},
+				PinnedItemIDs:  []string{"a", "b", "c"},
+				BuriedItemIDs:  []string{"x", "y"},
+				BuriedGroupIDs: []string{},
+			},
+		},
+		{
+			name: "pinned item is not buried",
+			rules: []*rule{
+				newRule("pin", "ソファ", "", []string{"a"}, nil),
+				newRule("bury", "ソファ", "", nil, []string{"a", "b"}),
+			},
+			query: "ソファ",
+			want: &Merchandising{
+				RuleIDs:        []string{"pin", "bury"},
+				PinnedItemIDs:  []string{"a"},
+				BuriedItemIDs:  []string{"b"},
+				BuriedGroupIDs: []string{},
+			},
+		},
+		{
+			name:  "caps merged pinned items",
+			rules: manyPinningRules,
+			query: "ソファ",
+			want: &Merchandising{
+				RuleIDs:        []string{"rule0", "rule1", "rule2", "rule3", "rule4", "rule5"},
+				PinnedItemIDs:  manyPinnedItemIDs[:maxPinnedItems],
+				BuriedGroupIDs: []string{},
+			},
+		},
+		{
+			name:  "ignores rules out of the period",
+			rules: []*rule{expiredRule, notStartedRule},
+			query: "ソファ",
+			want: &Merchandising{
+				PinnedItemIDs:  []string{},
+				BuriedGroupIDs: []string{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := matchRules(tt.rules, tt.query, tt.categoryIDs, now)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("matchRules(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_expandPinnedItemIDs(t *testing.T) {
+	t.Parallel()
+
+	manyItemIDs := make([]string, 0, maxPinnedItemsPerRule+5)
+	for i := 0; i < maxPinnedItemsPerRule+5; i++ {
+		manyItemIDs = append(manyItemIDs, fmt.Sprintf("rakuten:%d", i))
+	}
+	itemIDsMap := map[string][]string{
+		"group1": {"rakuten:a", "yahoo_shopping:a"},
+		"group2": manyItemIDs,
+	}
+
+	tests := []struct {
+		name string
+		rule *xspanner.MerchandisingRule
+		want []string
+	}{
+		{
+			name: "expands pinned groups into the items after the pinned items",
+			rule: &xspanner.MerchandisingRule{
+				PinnedItemIDs:  []string{"amazon:a", "rakuten:a"},
+				PinnedGroupIDs: []string{"group1"},
+			},
+			want: []string{"amazon:a", "rakuten:a", "yahoo_shopping:a"},
+		},
+		{
+			name: "caps the pinned items after expanding groups",
+			rule: &xspanner.MerchandisingRule{
+				PinnedItemIDs:  []string{"amazon:a"},
+				PinnedGroupIDs: []string{"group2"},
+			},
+			want: append([]string{"amazon:a"}, manyItemIDs[:maxPinnedItemsPerRule-1]...),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := expandPinnedItemIDs(tt.rule, itemIDsMap)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("expandPinnedItemIDs(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_normalizeRule(t *testing.T) {
+	t.Parallel()
+
+	startsAt := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	endsAt := startsAt.Add(24 * time.Hour)
+	tests := []struct {
+		name    string
+		rule    *xspanner.MerchandisingRule
+		want    *xspanner.MerchandisingRule
+		wantErr bool
+	}{
+		{
+			name: "normalizes query and item ids",
+			rule: &xspanner.MerchandisingRule{
+				ID:            "rule",
+				Name:          "ニトリ ソファ",
+				Query:         spanner.NullString{StringVal: " ニトリ　ＳＯＦＡ ", Valid: true},
+				PinnedItemIDs: []string{"a", " a", "b"},
+				StartsAt:      startsAt,
+				EndsAt:        endsAt,
+			},
+			want: &xspanner.MerchandisingRule{
+				ID:             "rule",
+				Name:           "ニトリ ソファ",
+				Query:          spanner.NullString{StringVal: "ニトリ sofa", Valid: true},
+				PinnedItemIDs:  []string{"a", "b"},
+				PinnedGroupIDs: []string{},
+				BuriedItemIDs:  []string{},
+				BuriedGroupIDs: []string{},
+				StartsAt:       startsAt,
+				EndsAt:         endsAt,
+			},
+		},
+		{
+			name: "returns error when neither query nor category is set",
+			rule: &xspanner.MerchandisingRule{
+				Name:          "all",
+				Query:         spanner.NullString{StringVal: " ", Valid: true},
+				PinnedItemIDs: []string{"a"},
+				StartsAt:      startsAt,
+				EndsAt:        endsAt,
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns error when period is invalid",
+			rule: &xspanner.MerchandisingRule{
+				Name:          "invalid period",
+				CategoryID:    spanner.NullString{StringVal: "100", Valid: true},
+				PinnedItemIDs: []string{"a"},
+				StartsAt:      endsAt,
+				EndsAt:        startsAt,
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := normalizeRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("normalizeRule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("normalizeRule(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package queryclassifier
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/api/iterator"
+)
+
+const (
+	clickStatsPeriodDays = 90
+	// getCategoryIDsBatchSize is the number of items to get categories at once
+	getCategoryIDsBatchSize = 1000
+)
+
+// ClickStatsFetcher fetches the historical clicks on items in each category per query
+type ClickStatsFetcher interface {
+	FetchQueryCategoryClicks(ctx context.Context) (QueryCategoryClicks, error)
+}
+
+type bigQueryClickStatsFetcher struct {
+	bqClient *bigquery.Client
+	// eventsTable is the fully qualified table name of tracking events e.g. "project.dataset.events"
+	eventsTable string
+	dbClient    db.Client
+}
+
+func NewBigQueryClickStatsFetcher(bqClient *bigquery.Client, eventsTable string, dbClient db.Client) ClickStatsFetcher {
+	return &bigQueryClickStatsFetcher{
+		bqClient:    bqClient,
+		eventsTable: eventsTable,
+		dbClient:    dbClient,
+	}
+}
+
+type queryItemClicks struct {
+	Query  string `bigquery:"query"`
+	ItemID string `bigquery:"item_id"`
+	Clicks int64  `bigquery:"clicks"`
+}
+
+// FetchQueryCategoryClicks aggregates clicks of search results by joining click events with display events by search id
+func (b *bigQueryClickStatsFetcher) FetchQueryCategoryClicks(ctx context.Context) (QueryCategoryClicks, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "queryclassifier.bigQueryClickStatsFetcher_FetchQueryCategoryClicks")
+	defer span.End()
+
+	q := b.bqClient.Query(fmt.Sprintf(`
+WITH displays AS (
+	SELECT DISTINCT
+		JSON_VALUE(params, '$.searchId') AS search_id,
+		LOWER(JSON_VALUE(params, '$.searchInput.query')) AS query
+	FROM %[1]s
+	WHERE id = 'SEARCH' AND action = 'DISPLAY' AND created_at >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL @days DAY)
+), clicks AS (
+	SELECT
+		JSON_VALUE(params, '$.searchId') AS search_id,
+		JSON_VALUE(params, '$.itemId') AS item_id
+	FROM %[1]s
+	WHERE id = 'SEARCH' AND action = 'CLICK_ITEM' AND created_at >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL @days DAY)
+)
+SELECT displays.query, clicks.item_id, COUNT(*) AS clicks
+FROM clicks
+JOIN displays USING (search_id)
+WHERE displays.query != ''
+GROUP BY displays.query, clicks.item_id
+`, fmt.Sprintf("`%s`", b.eventsTable)))
+	q.Parameters = []bigquery.QueryParameter{{Name: "days", Value: clickStatsPeriodDays}}
+	iter, err := q.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("query.Read: %w", err)
+	}
+
+	var rows []*queryItemClicks
+	itemIDMap := make(map[string]bool)
+	for {
+		var row queryItemClicks
+		err := iter.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("iter.Next: %w", err)
+		}
+		rows = append(rows, &row)
+		itemIDMap[row.ItemID] = true
+	}
+
+	itemIDs := make([]string, 0, len(itemIDMap))
+	for itemID := range itemIDMap {
+		itemIDs = append(itemIDs, itemID)
+	}
+	categoryIDMap := make(map[string]string, len(itemIDs))
+	for i := 0; i < len(itemIDs); i += getCategoryIDsBatchSize {
+		end := i + getCategoryIDsBatchSize
+		if end > len(itemIDs) {
+			end = len(itemIDs)
+		}
+		m, err := b.dbClient.GetCategoryIDMapByItemIDs(ctx, itemIDs[i:end])
+		if err != nil {
+			return nil, fmt.Errorf("dbClient.GetCategoryIDMapByItemIDs: %w", err)
+		}
+		for itemID, categoryID := range m {
+			categoryIDMap[itemID] = categoryID
+		}
+	}
+
+	clicks := make(QueryCategoryClicks)
+	for _, row := range rows {
+		categoryID, ok := categoryIDMap[row.ItemID]
+		if !ok {
+			continue
+		}
+		if clicks[row.Query] == nil {
+			clicks[row.Query] = make(map[string]int64)
+		}
+		clicks[row.Query][categoryID] += row.Clicks
+	}
+	return clicks, nil
+}
@@ -0,0 +1,159 @@
+package queryclassifier
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/k-yomo/kagu-miru/backend/pkg/strutil"
+)
+
+const (
+	// scores of the category term matched to the query
+	exactMatchScore   = 1.0
+	tokenMatchScore   = 0.8
+	partialMatchScore = 0.5
+
+	// minClicksForClickThrough is the min number of clicks of the query to use click-through
+	minClicksForClickThrough = 5
+	// maxPredictedCategories is the max number of categories predicted for a query
+	maxPredictedCategories = 3
+	minCategoryTermLength  = 2
+)
+
+// QueryCategoryClicks is the number of clicks on items in the category for the query
+// key is the normalized query, and the value is the map of category id to the number of clicks
+type QueryCategoryClicks map[string]map[string]int64
+
+type categoryPrediction struct {
+	CategoryID string
+	Score      float64
+}
+
+// categoryDictionary scores categories from category names, synonyms and click-through
+type categoryDictionary struct {
+	// key is the normalized term
+	termCategoryIDs map[string][]string
+	clicks          QueryCategoryClicks
+}
+
+func newCategoryDictionary(categories []*xspanner.ItemCategory, synonymSets []*xspanner.SynonymSet, clicks QueryCategoryClicks) *categoryDictionary {
+	termCategoryIDs := make(map[string][]string)
+	addTerm := func(term string, categoryID string) {
+		term = xitem.NormalizeQuery(term)
+		if len([]rune(term)) < minCategoryTermLength {
+			return
+		}
+		for _, id := range termCategoryIDs[term] {
+			if id == categoryID {
+				return
+			}
+		}
+		termCategoryIDs[term] = append(termCategoryIDs[term], categoryID)
+	}
+
+	synonymMap := make(map[string][]string)
+	for _, synonymSet := range synonymSets {
+		if !synonymSet.IsActive {
+			continue
+		}
+		for _, synonym := range synonymSet.Synonyms {
+			synonymMap[xitem.NormalizeQuery(synonym)] = synonymSet.Synonyms
+		}
+	}
+
+	for _, category := range categories {
+		terms := append([]string{category.Name}, xitem.SplitCategoryName(category.Name)...)
+		for _, term := range terms {
+			addTerm(term, category.ID)
+			for _, synonym := range synonymMap[xitem.NormalizeQuery(term)] {
+				addTerm(synonym, category.ID)
+			}
+		}
+	}
+
+	normalizedClicks := make(QueryCategoryClicks, len(clicks))
+	for query, categoryClicks := range clicks {
+		query = xitem.NormalizeQuery(query)
+		if normalizedClicks[query] == nil {
+			normalizedClicks[query] = make(map[string]int64)
+		}
+		for categoryID, count := range categoryClicks {
+			normalizedClicks[query][categoryID] += count
+		}
+	}
+
+	return &categoryDictionary{
+		termCategoryIDs: termCategoryIDs,
+		clicks:          normalizedClicks,
+	}
+}
+
+// predict returns categories scored over the threshold in descending order of the score
+// Score from the dictionary and score from click-through are combined as independent evidences.
+func (d *categoryDictionary) predict(query string, threshold float64) []*categoryPrediction {
+	query = xitem.NormalizeQuery(query)
+	if query == "" {
+		return nil
+	}
+	tokens := strings.Fields(query)
+
+	termScores := make(map[string]float64)
+	for term, categoryIDs := range d.termCategoryIDs {
+		var score float64
+		switch {
+		case term == query:
+			score = exactMatchScore
+		case strutil.Contains(tokens, term):
+			score = tokenMatchScore
+		case strings.Contains(query, term):
+			score = partialMatchScore
+		default:
+			continue
+		}
+		// ambiguous term is less reliable
+		score /= float64(len(categoryIDs))
+		for _, categoryID := range categoryIDs {
+			if score > termScores[categoryID] {
+				termScores[categoryID] = score
+			}
+		}
+	}
+
+	clickScores := make(map[string]float64)
+	var totalClicks int64
+	for _, count := range d.clicks[query] {
+		totalClicks += count
+	}
+	if totalClicks >= minClicksForClickThrough {
+		for categoryID, count := range d.clicks[query] {
+			clickScores[categoryID] = float64(count) / float64(totalClicks)
+		}
+	}
+
+	scores := make(map[string]float64)
+	for categoryID, score := range termScores {
+		scores[categoryID] = score
+	}
+	for categoryID, clickScore := range clickScores {
+		scores[categoryID] = 1 - (1-scores[categoryID])*(1-clickScore)
+	}
+
+	var predictions []*categoryPrediction
+	for categoryID, score := range scores {
+		if score >= threshold {
+			predictions = append(predictions, &categoryPrediction{CategoryID: categoryID, Score: score})
+		}
+	}
+	sort.Slice(predictions, func(i, j int) bool {
+		if predictions[i].Score == predictions[j].Score {
+			return predictions[i].CategoryID < predictions[j].CategoryID
+		}
+		return predictions[i].Score > predictions[j].Score
+	})
+	if len(predictions) > maxPredictedCategories {
+		predictions = predictions[:maxPredictedCategories]
+	}
+	return predictions
+}
@@ -0,0 +1,74 @@
+package queryclassifier
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+func Test_categoryDictionary_predict(t *testing.T) {
+	t.Parallel()
+
+	categories := []*xspanner.ItemCategory{
+		{ID: "1", Name: "ソファ・カウチ"},
+		{ID: "2", Name: "ソファベッド"},
+		{ID: "3", Name: "テレビ台"},
+		{ID: "4", Name: "ローボード"},
+	}
+	synonymSets := []*xspanner.SynonymSet{
+		{Synonyms: []string{"テレビ台", "TVボード"}, IsActive: true},
+		{Synonyms: []string{"ソファ", "sofa"}, IsActive: false},
+	}
+	clicks := QueryCategoryClicks{
+		"ソファ": {"1": 2, "2": 8},
+		"カウチ": {"1": 3},
+	}
+	dictionary := newCategoryDictionary(categories, synonymSets, clicks)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []*categoryPrediction
+	}{
+		{
+			name:  "predicts category from both name and click-through",
+			query: "ソファ",
+			want: []*categoryPrediction{
+				{CategoryID: "1", Score: 1},
+				{CategoryID: "2", Score: 0.8},
+			},
+		},
+		{
+			name:  "predicts category from synonym",
+			query: "ＴＶボード 白",
+			want:  []*categoryPrediction{{CategoryID: "3", Score: 0.8}},
+		},
+		{
+			name:  "doesn't use click-through with few clicks",
+			query: "カウチ",
+			want:  []*categoryPrediction{{CategoryID: "1", Score: 1}},
+		},
+		{
+			name:  "doesn't predict from partial match only",
+			query: "北欧ローボード",
+			want:  nil,
+		},
+		{
+			name:  "doesn't predict from inactive synonym",
+			query: "sofa",
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := dictionary.predict(tt.query, scoreThreshold)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("predict(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
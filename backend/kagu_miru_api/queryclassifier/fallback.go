@@ -0,0 +1,90 @@
+package queryclassifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	fallbackTimeout  = 300 * time.Millisecond
+	fallbackCacheTTL = 24 * time.Hour
+	// fallbackErrorCacheTTL is the ttl of the failed query not to call the failing fallback classifier for every search
+	fallbackErrorCacheTTL = 1 * time.Minute
+	fallbackCacheMaxItems = 10000
+)
+
+// fallbackQueryClassifier uses the fallback classifier only when the primary classifier predicts no categories
+// Since the fallback classifier (e.g. Vertex AI) is slow, it's called with timeout and the result is cached.
+// Failed queries are cached as no categories for a short time not to wait for the timeout on every search while it's failing.
+type fallbackQueryClassifier struct {
+	primary  QueryClassifier
+	fallback QueryClassifier
+
+	mu    sync.Mutex
+	cache map[string]*cachedCategoryIDs
+}
+
+type cachedCategoryIDs struct {
+	categoryIDs []string
+	expiresAt   time.Time
+}
+
+func NewFallbackQueryClassifier(primary QueryClassifier, fallback QueryClassifier) QueryClassifier {
+	return &fallbackQueryClassifier{
+		primary:  primary,
+		fallback: fallback,
+		cache:    make(map[string]*cachedCategoryIDs),
+	}
+}
+
+func (f *fallbackQueryClassifier) CategorizeQuery(ctx context.Context, query string) ([]string, error) {
+	categoryIDs, err := f.primary.CategorizeQuery(ctx, query)
+	if err != nil || len(categoryIDs) > 0 {
+		return categoryIDs, err
+	}
+
+	now := time.Now()
+	f.mu.Lock()
+	cached, ok := f.cache[query]
+	f.mu.Unlock()
+	if ok && now.Before(cached.expiresAt) {
+		return cached.categoryIDs, nil
+	}
+
+	fallbackCtx, cancel := context.WithTimeout(ctx, fallbackTimeout)
+	defer cancel()
+	categoryIDs, err = f.fallback.CategorizeQuery(fallbackCtx, query)
+	if err != nil {
+		// the error caused by the canceled request is not cached since the fallback classifier is not failing
+		if ctx.Err() == nil {
+			f.setCache(query, nil, now.Add(fallbackErrorCacheTTL))
+		}
+		return nil, fmt.Errorf("fallback.CategorizeQuery: %w", err)
+	}
+	f.setCache(query, categoryIDs, now.Add(fallbackCacheTTL))
+	return categoryIDs, nil
+}
+
+func (f *fallbackQueryClassifier) setCache(query string, categoryIDs []string, expiresAt time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.cache) >= fallbackCacheMaxItems {
+		f.evictExpired(time.Now())
+	}
+	// cache is reset when it's still full to limit memory usage
+	if len(f.cache) >= fallbackCacheMaxItems {
+		f.cache = make(map[string]*cachedCategoryIDs)
+	}
+	f.cache[query] = &cachedCategoryIDs{categoryIDs: categoryIDs, expiresAt: expiresAt}
+}
+
+// evictExpired must be called with lock
+func (f *fallbackQueryClassifier) evictExpired(now time.Time) {
+	for query, cached := range f.cache {
+		if now.After(cached.expiresAt) {
+			delete(f.cache, query)
+		}
+	}
+}
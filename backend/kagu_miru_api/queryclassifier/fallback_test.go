@@ -0,0 +1,123 @@
+package queryclassifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type fakeQueryClassifier struct {
+	categoryIDs []string
+	err         error
+	calls       int
+}
+
+func (c *fakeQueryClassifier) CategorizeQuery(ctx context.Context, query string) ([]string, error) {
+	c.calls++
+	return c.categoryIDs, c.err
+}
+
+func TestFallbackQueryClassifier_CategorizeQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		primary         *fakeQueryClassifier
+		fallback        *fakeQueryClassifier
+		cache           map[string]*cachedCategoryIDs
+		want            []string
+		wantErr         bool
+		wantFallbacks   int
+		wantCachedUntil time.Duration
+	}{
+		{
+			name:          "doesn't call the fallback when the primary predicts categories",
+			primary:       &fakeQueryClassifier{categoryIDs: []string{"1"}},
+			fallback:      &fakeQueryClassifier{categoryIDs: []string{"2"}},
+			want:          []string{"1"},
+			wantFallbacks: 0,
+		},
+		{
+			name:            "caches the categories of the fallback",
+			primary:         &fakeQueryClassifier{},
+			fallback:        &fakeQueryClassifier{categoryIDs: []string{"2"}},
+			want:            []string{"2"},
+			wantFallbacks:   1,
+			wantCachedUntil: fallbackCacheTTL,
+		},
+		{
+			name:            "caches the failed query for a short time",
+			primary:         &fakeQueryClassifier{},
+			fallback:        &fakeQueryClassifier{err: context.DeadlineExceeded},
+			wantErr:         true,
+			wantFallbacks:   1,
+			wantCachedUntil: fallbackErrorCacheTTL,
+		},
+		{
+			name:          "returns the cached categories without calling the fallback",
+			primary:       &fakeQueryClassifier{},
+			fallback:      &fakeQueryClassifier{err: errors.New("error")},
+			cache:         map[string]*cachedCategoryIDs{"ソファ": {expiresAt: time.Now().Add(time.Minute)}},
+			wantFallbacks: 0,
+		},
+		{
+			name:          "calls the fallback when the cache is expired",
+			primary:       &fakeQueryClassifier{},
+			fallback:      &fakeQueryClassifier{categoryIDs: []string{"2"}},
+			cache:         map[string]*cachedCategoryIDs{"ソファ": {expiresAt: time.Now().Add(-time.Minute)}},
+			want:          []string{"2"},
+			wantFallbacks: 1,
+			// the cache is updated with the new result
+			wantCachedUntil: fallbackCacheTTL,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			classifier := NewFallbackQueryClassifier(tt.primary, tt.fallback).(*fallbackQueryClassifier)
+			if tt.cache != nil {
+				classifier.cache = tt.cache
+			}
+			now := time.Now()
+			got, err := classifier.CategorizeQuery(context.Background(), "ソファ")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CategorizeQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("CategorizeQuery(), (-want +got): %s", diff)
+			}
+			if tt.fallback.calls != tt.wantFallbacks {
+				t.Errorf("fallback called %d times, want %d", tt.fallback.calls, tt.wantFallbacks)
+			}
+			if tt.wantCachedUntil > 0 {
+				cached, ok := classifier.cache["ソファ"]
+				if !ok {
+					t.Fatalf("query is not cached")
+				}
+				// the expiry is computed from the time just before calling CategorizeQuery
+				if cached.expiresAt.Before(now.Add(tt.wantCachedUntil)) || cached.expiresAt.After(time.Now().Add(tt.wantCachedUntil)) {
+					t.Errorf("cache expires at %v, want %v later", cached.expiresAt, tt.wantCachedUntil)
+				}
+			}
+		})
+	}
+}
+
+func TestFallbackQueryClassifier_CategorizeQuery_canceled(t *testing.T) {
+	t.Parallel()
+
+	classifier := NewFallbackQueryClassifier(&fakeQueryClassifier{}, &fakeQueryClassifier{err: context.Canceled}).(*fallbackQueryClassifier)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := classifier.CategorizeQuery(ctx, "ソファ"); err == nil {
+		t.Errorf("CategorizeQuery() error = nil, want error")
+	}
+	if _, ok := classifier.cache["ソファ"]; ok {
+		t.Errorf("the query failed by the canceled request must not be cached")
+	}
+}
@@ -0,0 +1,99 @@
+package queryclassifier
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+const dictionaryRefreshInterval = 1 * time.Hour
+
+// localQueryClassifier categorizes query with the dictionary on memory
+// It returns no categories until the dictionary is loaded by Run.
+type localQueryClassifier struct {
+	dbClient db.Client
+	// clickStatsFetcher is optional
+	clickStatsFetcher ClickStatsFetcher
+
+	mu         sync.RWMutex
+	dictionary *categoryDictionary
+}
+
+func NewLocalQueryClassifier(dbClient db.Client, clickStatsFetcher ClickStatsFetcher) *localQueryClassifier {
+	return &localQueryClassifier{
+		dbClient:          dbClient,
+		clickStatsFetcher: clickStatsFetcher,
+	}
+}
+
+// Run loads the dictionary and refreshes it periodically until the context is canceled
+func (l *localQueryClassifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(dictionaryRefreshInterval)
+	defer ticker.Stop()
+	for {
+		if err := l.refreshDictionary(ctx); err != nil {
+			logging.Logger(ctx).Error("refreshDictionary failed", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *localQueryClassifier) refreshDictionary(ctx context.Context) error {
+	ctx, span := otel.Tracer("").Start(ctx, "queryclassifier.localQueryClassifier_refreshDictionary")
+	defer span.End()
+
+	categories, err := l.dbClient.GetAllActiveItemCategories(ctx)
+	if err != nil {
+		return fmt.Errorf("dbClient.GetAllActiveItemCategories: %w", err)
+	}
+	synonymSets, err := l.dbClient.GetAllSynonymSets(ctx)
+	if err != nil {
+		return fmt.Errorf("dbClient.GetAllSynonymSets: %w", err)
+	}
+	var clicks QueryCategoryClicks
+	if l.clickStatsFetcher != nil {
+		clicks, err = l.clickStatsFetcher.FetchQueryCategoryClicks(ctx)
+		if err != nil {
+			// categories can be still predicted from the names
+			logging.Logger(ctx).Warn("FetchQueryCategoryClicks failed", zap.Error(err))
+		}
+	}
+
+	dictionary := newCategoryDictionary(categories, synonymSets, clicks)
+	l.mu.Lock()
+	l.dictionary = dictionary
+	l.mu.Unlock()
+	return nil
+}
+
+// CategorizeQuery predicts the query's intended categories and returns the list of ids.
+func (l *localQueryClassifier) CategorizeQuery(ctx context.Context, query string) ([]string, error) {
+	_, span := otel.Tracer("").Start(ctx, "queryclassifier.localQueryClassifier_CategorizeQuery")
+	defer span.End()
+
+	l.mu.RLock()
+	dictionary := l.dictionary
+	l.mu.RUnlock()
+	if dictionary == nil {
+		return nil, nil
+	}
+
+	var categoryIDs []string
+	for _, prediction := range dictionary.predict(query, scoreThreshold) {
+		categoryIDs = append(categoryIDs, prediction.CategoryID)
+	}
+
+	span.SetAttributes(attribute.StringSlice("categoryIds", categoryIDs))
+	return categoryIDs, nil
+}
@@ -0,0 +1,142 @@
+package ranking
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+// profilesCacheTTL is the interval to reflect the changed profiles without redeploy
+const profilesCacheTTL = 1 * time.Minute
+
+type Client interface {
+	// GetProfile returns the latest version of the profile, or the default profile when the name is empty
+	GetProfile(ctx context.Context, name string) (*Profile, error)
+	GetAllProfiles(ctx context.Context) ([]*xspanner.RankingProfile, error)
+	GetProfileVersions(ctx context.Context, name string) ([]*xspanner.RankingProfile, error)
+	// SaveProfile validates the config and saves it as the new version of the profile
+	SaveProfile(ctx context.Context, name string, config string) (*xspanner.RankingProfile, error)
+}
+
+type rankingClient struct {
+	dbClient db.Client
+
+	mu        sync.RWMutex
+	profiles  map[string]*Profile
+	expiresAt time.Time
+}
+
+func NewRankingClient(dbClient db.Client) Client {
+	return &rankingClient{dbClient: dbClient}
+}
+
+func (r *rankingClient) GetProfile(ctx context.Context, name string) (*Profile, error) {
+	if name == "" {
+		name = DefaultProfileName
+	}
+	profiles, err := r.getProfiles(ctx)
+	if err != nil {
+		if name == DefaultProfileName {
+			// search must not fail even if the profiles are unavailable
+			logging.Logger(ctx).Warn("getProfiles failed, using built-in default profile", zap.Error(err))
+			return DefaultProfile(), nil
+		}
+		return nil, err
+	}
+	if profile, ok := profiles[name]; ok {
+		return profile, nil
+	}
+	if name == DefaultProfileName {
+		return DefaultProfile(), nil
+	}
+	return nil, xerror.NewInvalidArgument(fmt.Errorf("ranking profile '%s' is not found", name))
+}
+
+func (r *rankingClient) GetAllProfiles(ctx context.Context) ([]*xspanner.RankingProfile, error) {
+	return r.dbClient.GetLatestRankingProfiles(ctx)
+}
+
+func (r *rankingClient) GetProfileVersions(ctx context.Context, name string) ([]*xspanner.RankingProfile, error) {
+	return r.dbClient.GetRankingProfileVersions(ctx, name)
+}
+
+func (r *rankingClient) SaveProfile(ctx context.Context, name string, config string) (*xspanner.RankingProfile, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "ranking.rankingClient_SaveProfile")
+	defer span.End()
+
+	if name == "" {
+		return nil, xerror.NewInvalidArgument(errors.New("name is required"))
+	}
+	profile, err := parseProfileConfig(name, 0, config)
+	if err != nil {
+		return nil, xerror.NewInvalidArgument(fmt.Errorf("invalid config: %w", err))
+	}
+	// config is saved in the canonical form
+	canonicalConfig, err := json.Marshal(profile)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("json.Marshal: %w", err))
+	}
+	saved, err := r.dbClient.InsertRankingProfileVersion(ctx, name, string(canonicalConfig))
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("dbClient.InsertRankingProfileVersion: %w", err))
+	}
+	r.mu.Lock()
+	r.expiresAt = time.Time{}
+	r.mu.Unlock()
+	return saved, nil
+}
+
+func (r *rankingClient) getProfiles(ctx context.Context) (map[string]*Profile, error) {
+	r.mu.RLock()
+	profiles, expiresAt := r.profiles, r.expiresAt
+	r.mu.RUnlock()
+	if profiles != nil && time.Now().Before(expiresAt) {
+		return profiles, nil
+	}
+
+	profiles, err := r.fetchProfiles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	r.profiles = profiles
+	r.expiresAt = time.Now().Add(profilesCacheTTL)
+	r.mu.Unlock()
+	return profiles, nil
+}
+
+func (r *rankingClient) fetchProfiles(ctx context.Context) (map[string]*Profile, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "ranking.rankingClient_fetchProfiles")
+	defer span.End()
+
+	spannerProfiles, err := r.dbClient.GetLatestRankingProfiles(ctx)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("dbClient.GetLatestRankingProfiles: %w", err))
+	}
+	profiles := make(map[string]*Profile, len(spannerProfiles))
+	for _, spannerProfile := range spannerProfiles {
+		profile, err := parseProfileConfig(spannerProfile.Name, spannerProfile.Version, spannerProfile.Config)
+		if err != nil {
+			// config is validated on save, so this happens only when the config is broken manually
+			logging.Logger(ctx).Error(
+				"invalid ranking profile config",
+				zap.String("name", spannerProfile.Name),
+				zap.Int64("version", spannerProfile.Version),
+				zap.Error(err),
+			)
+			continue
+		}
+		profiles[profile.Name] = profile
+	}
+	return profiles, nil
+}
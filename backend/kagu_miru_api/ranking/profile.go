@@ -0,0 +1,222 @@
+package ranking
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+	"github.com/k-yomo/kagu-miru/backend/pkg/xesquery"
+)
+
+// DefaultProfileName is the name of the profile used when no profile is selected
+const DefaultProfileName = "default"
+
+// Profile is a named and versioned config of how items are scored
+type Profile struct {
+	Name string `json:"-"`
+	// Version is 0 for the built-in default profile
+	Version int64 `json:"-"`
+
+	Search       *ScoringConfig `json:"search"`
+	SimilarItems *ScoringConfig `json:"similarItems"`
+	// Diversification is the map of the search sort type to the diversification config
+	// Search results sorted by the sort type not in the map are not diversified.
+	Diversification map[gqlmodel.SearchSortType]*DiversificationConfig `json:"diversification,omitempty"`
+}
+
+// ScoringConfig is the config of the text match and function score
+type ScoringConfig struct {
+	// FieldBoosts is the map of the field name to match the query and its boost
+	FieldBoosts map[string]float64 `json:"fieldBoosts"`
+	// RatingDecay is applied to the bayesian rating score
+	RatingDecay       *DecayConfig            `json:"ratingDecay,omitempty"`
+	ReviewCountFactor *FieldValueFactorConfig `json:"reviewCountFactor,omitempty"`
+	// PriceDecay is only for similar items to score items with the similar price higher
+	PriceDecay *PriceDecayConfig `json:"priceDecay,omitempty"`
+	MaxBoost   float64           `json:"maxBoost"`
+}
+
+type DecayConfig struct {
+	Origin float64 `json:"origin"`
+	Offset float64 `json:"offset"`
+	Scale  float64 `json:"scale"`
+	Decay  float64 `json:"decay"`
+}
+
+// GaussScore returns the score of the gauss decay function for the value
+// It's used to score documents without the field the same as the value since the decay function scores them 1.
+func (c *DecayConfig) GaussScore(value float64) float64 {
+	distance := math.Max(0, math.Abs(value-c.Origin)-c.Offset)
+	return math.Pow(c.Decay, math.Pow(distance/c.Scale, 2))
+}
+
+type FieldValueFactorConfig struct {
+	Factor float64 `json:"factor"`
+	// Modifier is one of the modifiers of field_value_factor e.g. "log1p"
+	Modifier string `json:"modifier"`
+}
+
+// DiversificationConfig is the config to cap items from the same shop, brand or group within the window
+// e.g. with WindowSize 5 and MaxSameShop 2, any 5 consecutive items contain at most 2 items from the same shop.
+// Max of 0 means no cap.
+type DiversificationConfig struct {
+	WindowSize   int `json:"windowSize"`
+	MaxSameShop  int `json:"maxSameShop"`
+	MaxSameBrand int `json:"maxSameBrand"`
+	MaxSameGroup int `json:"maxSameGroup"`
+}
+
+// PriceDecayConfig is the decay relative to the price of the base item
+type PriceDecayConfig struct {
+	OffsetRatio float64 `json:"offsetRatio"`
+	ScaleRatio  float64 `json:"scaleRatio"`
+	Decay       float64 `json:"decay"`
+}
+
+var searchableFields = map[string]bool{
+	es.ItemFieldName:            true,
+	es.ItemFieldNameModelNumber: true,
+	es.ItemFieldBrandName:       true,
+	es.ItemFieldCategoryNames:   true,
+	es.ItemFieldColors:          true,
+	es.ItemFieldDescription:     true,
+}
+
+var fieldValueFactorModifiers = map[string]bool{
+	"none": true, "log": true, "log1p": true, "log2p": true, "ln": true, "ln1p": true, "ln2p": true,
+	"square": true, "sqrt": true, "reciprocal": true,
+}
+
+// DefaultProfile returns the built-in profile used when the default profile is not configured
+func DefaultProfile() *Profile {
+	defaultDiversification := &DiversificationConfig{WindowSize: 5, MaxSameShop: 2, MaxSameBrand: 3, MaxSameGroup: 1}
+	return &Profile{
+		Name:    DefaultProfileName,
+		Version: 0,
+		Search: &ScoringConfig{
+			FieldBoosts: map[string]float64{
+				es.ItemFieldName:            20,
+				es.ItemFieldNameModelNumber: 20,
+				es.ItemFieldBrandName:       5,
+				es.ItemFieldCategoryNames:   5,
+				es.ItemFieldColors:          5,
+				es.ItemFieldDescription:     1,
+			},
+			RatingDecay:       &DecayConfig{Origin: 5, Offset: 0, Scale: 1, Decay: 0.5},
+			ReviewCountFactor: &FieldValueFactorConfig{Factor: 1, Modifier: "log2p"},
+			MaxBoost:          3,
+		},
+		SimilarItems: &ScoringConfig{
+			FieldBoosts: map[string]float64{
+				es.ItemFieldName:          30,
+				es.ItemFieldBrandName:     10,
+				es.ItemFieldCategoryNames: 5,
+				es.ItemFieldColors:        5,
+				es.ItemFieldDescription:   1,
+			},
+			RatingDecay:       &DecayConfig{Origin: 5, Offset: 0, Scale: 1, Decay: 0.5},
+			ReviewCountFactor: &FieldValueFactorConfig{Factor: 1, Modifier: "log2p"},
+			PriceDecay:        &PriceDecayConfig{OffsetRatio: 0.2, ScaleRatio: 0.2, Decay: 0.5},
+			MaxBoost:          3,
+		},
+		// price sorts are not diversified since the order must be strictly kept
+		Diversification: map[gqlmodel.SearchSortType]*DiversificationConfig{
+			gqlmodel.SearchSortTypeBestMatch:   defaultDiversification,
+			gqlmodel.SearchSortTypeReviewCount: defaultDiversification,
+			gqlmodel.SearchSortTypeRating:      defaultDiversification,
+		},
+	}
+}
+
+// GetDiversification returns the diversification config for the sort type, or nil when not diversified
+func (p *Profile) GetDiversification(sortType *gqlmodel.SearchSortType) *DiversificationConfig {
+	if sortType == nil {
+		return p.Diversification[gqlmodel.SearchSortTypeBestMatch]
+	}
+	return p.Diversification[*sortType]
+}
+
+// BoostedFields returns the fields with boost in the format of "field^boost"
+// Fields are sorted to build the same query every time for the request cache.
+func (c *ScoringConfig) BoostedFields() []string {
+	fields := make([]string, 0, len(c.FieldBoosts))
+	for field, boost := range c.FieldBoosts {
+		fields = append(fields, xesquery.Boost(field, boost))
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// NewProfileFromConfig creates the unversioned profile from the config in JSON e.g. to evaluate the config before saving
+func NewProfileFromConfig(name string, config string) (*Profile, error) {
+	return parseProfileConfig(name, 0, config)
+}
+
+// parseProfileConfig parses and validates the config in JSON
+func parseProfileConfig(name string, version int64, config string) (*Profile, error) {
+	var profile Profile
+	if err := json.Unmarshal([]byte(config), &profile); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	profile.Name = name
+	profile.Version = version
+	if err := profile.validate(); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+func (p *Profile) validate() error {
+	if p.Search == nil || p.SimilarItems == nil {
+		return errors.New("search and similarItems are required")
+	}
+	if err := p.Search.validate(); err != nil {
+		return fmt.Errorf("search: %w", err)
+	}
+	if err := p.SimilarItems.validate(); err != nil {
+		return fmt.Errorf("similarItems: %w", err)
+	}
+	for sortType, diversification := range p.Diversification {
+		if !sortType.IsValid() {
+			return fmt.Errorf("diversification: sort type '%s' is invalid", sortType)
+		}
+		if diversification == nil || diversification.WindowSize < 2 {
+			return fmt.Errorf("diversification: windowSize of '%s' must be 2 or more", sortType)
+		}
+		if diversification.MaxSameShop < 0 || diversification.MaxSameBrand < 0 || diversification.MaxSameGroup < 0 {
+			return fmt.Errorf("diversification: max of '%s' must not be negative", sortType)
+		}
+	}
+	return nil
+}
+
+func (c *ScoringConfig) validate() error {
+	if len(c.FieldBoosts) == 0 {
+		return errors.New("fieldBoosts must not be empty")
+	}
+	for field, boost := range c.FieldBoosts {
+		if !searchableFields[field] {
+			return fmt.Errorf("field '%s' is not searchable", field)
+		}
+		if boost <= 0 {
+			return fmt.Errorf("boost of '%s' must be positive", field)
+		}
+	}
+	if c.RatingDecay != nil && (c.RatingDecay.Scale <= 0 || c.RatingDecay.Decay <= 0 || c.RatingDecay.Decay >= 1) {
+		return errors.New("ratingDecay must have positive scale and decay between 0 and 1")
+	}
+	if c.ReviewCountFactor != nil && !fieldValueFactorModifiers[c.ReviewCountFactor.Modifier] {
+		return fmt.Errorf("modifier '%s' is invalid", c.ReviewCountFactor.Modifier)
+	}
+	if c.PriceDecay != nil && (c.PriceDecay.ScaleRatio <= 0 || c.PriceDecay.Decay <= 0 || c.PriceDecay.Decay >= 1) {
+		return errors.New("priceDecay must have positive scaleRatio and decay between 0 and 1")
+	}
+	if c.MaxBoost <= 0 {
+		return errors.New("maxBoost must be positive")
+	}
+	return nil
+}
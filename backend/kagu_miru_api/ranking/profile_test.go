@@ -0,0 +1,99 @@
+package ranking
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_parseProfileConfig(t *testing.T) {
+	t.Parallel()
+
+	defaultConfig, err := json.Marshal(DefaultProfile())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDefault := DefaultProfile()
+	wantDefault.Name = "test"
+	wantDefault.Version = 2
+
+	tests := []struct {
+		name    string
+		config  string
+		want    *Profile
+		wantErr bool
+	}{
+		{
+			name:   "parses the config of the default profile",
+			config: string(defaultConfig),
+			want:   wantDefault,
+		},
+		{
+			name:    "returns error when field is not searchable",
+			config:  `{"search":{"fieldBoosts":{"price":1},"maxBoost":3},"similarItems":{"fieldBoosts":{"name":1},"maxBoost":3}}`,
+			wantErr: true,
+		},
+		{
+			name:    "returns error when modifier is invalid",
+			config:  `{"search":{"fieldBoosts":{"name":1},"reviewCountFactor":{"factor":1,"modifier":"exp"},"maxBoost":3},"similarItems":{"fieldBoosts":{"name":1},"maxBoost":3}}`,
+			wantErr: true,
+		},
+		{
+			name:    "returns error when similarItems is missing",
+			config:  `{"search":{"fieldBoosts":{"name":1},"maxBoost":3}}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseProfileConfig("test", 2, tt.config)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseProfileConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseProfileConfig(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestScoringConfig_BoostedFields(t *testing.T) {
+	t.Parallel()
+
+	scoring := &ScoringConfig{FieldBoosts: map[string]float64{"name": 20, "brand_name": 5, "description": 1}}
+	want := []string{"brand_name^5.000000", "description^1.000000", "name^20.000000"}
+	if diff := cmp.Diff(want, scoring.BoostedFields()); diff != "" {
+		t.Errorf("BoostedFields(), (-want +got): %s", diff)
+	}
+}
+
+func TestDecayConfig_GaussScore(t *testing.T) {
+	t.Parallel()
+
+	decay := &DecayConfig{Origin: 5, Offset: 0.5, Scale: 1, Decay: 0.5}
+	tests := []struct {
+		name  string
+		value float64
+		want  float64
+	}{
+		{name: "origin", value: 5, want: 1},
+		{name: "within the offset", value: 4.5, want: 1},
+		{name: "scale away from the offset", value: 3.5, want: 0.5},
+		{name: "twice the scale away from the offset", value: 2.5, want: 0.0625},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, decay.GaussScore(tt.value)); diff != "" {
+				t.Errorf("GaussScore(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/olivere/elastic/v7"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	brandNamesAggregationSize = 10000
+	brandNamesCacheTTL        = 1 * time.Hour
+	// brandNamesRetryInterval is the interval to fetch brand names again after the fetch failed
+	brandNamesRetryInterval = 1 * time.Minute
+	// brand names shorter than this are not used for query parsing since they are likely to be common words
+	minBrandNameLength = 2
+)
+
+// brandNameCache caches brand names indexed in Elasticsearch to interpret brand names in query
+type brandNameCache struct {
+	mu sync.RWMutex
+	// key is lower cased brand name
+	brandNameMap map[string]string
+	expiresAt    time.Time
+}
+
+// getBrandNameMap returns the cached brand names, which are fetched again when the cache is expired
+// When the fetch fails, the last fetched brand names are served until the retry interval has passed
+// not to send the failing request for every search. The map is nil when brand names have never been fetched.
+func (s *searchClient) getBrandNameMap(ctx context.Context) (map[string]string, error) {
+	s.brandNameCache.mu.RLock()
+	brandNameMap, expiresAt := s.brandNameCache.brandNameMap, s.brandNameCache.expiresAt
+	s.brandNameCache.mu.RUnlock()
+	if time.Now().Before(expiresAt) {
+		return brandNameMap, nil
+	}
+
+	fetchedBrandNameMap, err := s.fetchBrandNameMap(ctx)
+	s.brandNameCache.mu.Lock()
+	defer s.brandNameCache.mu.Unlock()
+	if err != nil {
+		s.brandNameCache.expiresAt = time.Now().Add(brandNamesRetryInterval)
+		return s.brandNameCache.brandNameMap, err
+	}
+	s.brandNameCache.brandNameMap = fetchedBrandNameMap
+	s.brandNameCache.expiresAt = time.Now().Add(brandNamesCacheTTL)
+	return fetchedBrandNameMap, nil
+}
+
+func (s *searchClient) fetchBrandNameMap(ctx context.Context) (map[string]string, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "search.searchClient_fetchBrandNameMap")
+	defer span.End()
+
+	resp, err := s.esClient.Search().
+		Index(s.itemsIndexName).
+		Size(0).
+		Aggregation(es.ItemFieldBrandName, elastic.NewTermsAggregation().Field(es.ItemFieldBrandName).Size(brandNamesAggregationSize)).
+		Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("esClient.Search: %w", err)
+	}
+	result, ok := resp.Aggregations.Terms(es.ItemFieldBrandName)
+	if !ok {
+		return nil, fmt.Errorf("aggregation result for %s is not found", es.ItemFieldBrandName)
+	}
+
+	brandNameMap := make(map[string]string, len(result.Buckets))
+	for _, bucket := range result.Buckets {
+		brandName, ok := bucket.Key.(string)
+		if !ok || len([]rune(brandName)) < minBrandNameLength {
+			continue
+		}
+		brandNameMap[strings.ToLower(brandName)] = brandName
+	}
+	return brandNameMap, nil
+}
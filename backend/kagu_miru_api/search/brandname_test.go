@@ -0,0 +1,113 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/olivere/elastic/v7"
+)
+
+func TestSearchClient_getBrandNameMap(t *testing.T) {
+	t.Parallel()
+
+	var failing int32
+	var requestCount int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requestCount, 1)
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.LoadInt32(&failing) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"error":{"type":"unavailable"},"status":503}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"hits":{"hits":[]},"aggregations":{"brand_name":{"buckets":[{"key":"IKEA","doc_count":3},{"key":"A","doc_count":1}]}}}`))
+	}))
+	t.Cleanup(srv.Close)
+	esClient, err := elastic.NewSimpleClient(elastic.SetURL(srv.URL))
+	if err != nil {
+		t.Fatalf("elastic.NewSimpleClient() error = %v", err)
+	}
+	s := &searchClient{itemsIndexName: "items", esClient: esClient}
+	ctx := context.Background()
+	expireCache := func() {
+		s.brandNameCache.mu.Lock()
+		s.brandNameCache.expiresAt = time.Now().Add(-time.Second)
+		s.brandNameCache.mu.Unlock()
+	}
+	wantBrandNameMap := map[string]string{"ikea": "IKEA"}
+
+	steps := []struct {
+		name             string
+		failing          bool
+		expireCache      bool
+		want             map[string]string
+		wantErr          bool
+		wantRequestCount int32
+	}{
+		{
+			name:             "returns error when the first fetch fails",
+			failing:          true,
+			want:             nil,
+			wantErr:          true,
+			wantRequestCount: 1,
+		},
+		{
+			name:             "doesn't fetch again until the retry interval has passed",
+			failing:          true,
+			want:             nil,
+			wantRequestCount: 1,
+		},
+		{
+			name:             "fetches again after the retry interval",
+			expireCache:      true,
+			want:             wantBrandNameMap,
+			wantRequestCount: 2,
+		},
+		{
+			name:             "returns the cached brand names",
+			want:             wantBrandNameMap,
+			wantRequestCount: 2,
+		},
+		{
+			name:             "returns the last fetched brand names with error when the fetch fails",
+			failing:          true,
+			expireCache:      true,
+			want:             wantBrandNameMap,
+			wantErr:          true,
+			wantRequestCount: 3,
+		},
+		{
+			name:             "serves the last fetched brand names until the retry interval has passed",
+			failing:          true,
+			want:             wantBrandNameMap,
+			wantRequestCount: 3,
+		},
+	}
+	// the steps are run in order since the cache is shared
+	for _, step := range steps {
+		if step.failing {
+			atomic.StoreInt32(&failing, 1)
+		} else {
+			atomic.StoreInt32(&failing, 0)
+		}
+		if step.expireCache {
+			expireCache()
+		}
+
+		got, err := s.getBrandNameMap(ctx)
+		if (err != nil) != step.wantErr {
+			t.Errorf("%s: getBrandNameMap() error = %v, wantErr %v", step.name, err, step.wantErr)
+		}
+		if diff := cmp.Diff(step.want, got); diff != "" {
+			t.Errorf("%s: getBrandNameMap(), (-want +got): %s", step.name, diff)
+		}
+		if got := atomic.LoadInt32(&requestCount); got != step.wantRequestCount {
+			t.Errorf("%s: request count = %d, want %d", step.name, got, step.wantRequestCount)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"sort"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/olivere/elastic/v7"
+)
+
+// categoryFacetSize is the max number of categories aggregated across all levels
+const categoryFacetSize = 1000
+
+// newCategoryFacet builds the hierarchical category facet from the counts aggregated for category_ids
+// Since category_ids contains all ancestor ids, the count of the parent category includes the counts of its children.
+func newCategoryFacet(bucketKeyItems *elastic.AggregationBucketKeyItems, itemCategories []*xspanner.ItemCategoryWithParent) *Facet {
+	countMap := make(map[string]int, len(bucketKeyItems.Buckets))
+	for _, bucket := range bucketKeyItems.Buckets {
+		keyStr, ok := bucket.Key.(string)
+		if !ok {
+			continue
+		}
+		countMap[keyStr] = int(bucket.DocCount)
+	}
+
+	// sort by level to make sure parents are created before their children
+	sortedItemCategories := make([]*xspanner.ItemCategoryWithParent, len(itemCategories))
+	copy(sortedItemCategories, itemCategories)
+	sort.Slice(sortedItemCategories, func(i, j int) bool {
+		return sortedItemCategories[i].Level < sortedItemCategories[j].Level
+	})
+
+	facetValueMap := make(map[string]*FacetValue)
+	var rootValues []*FacetValue
+	for _, itemCategory := range sortedItemCategories {
+		count, ok := countMap[itemCategory.ID]
+		if !ok {
+			continue
+		}
+		facetValue := &FacetValue{
+			ID:    itemCategory.ID,
+			Name:  itemCategory.Name,
+			Count: count,
+		}
+		facetValueMap[itemCategory.ID] = facetValue
+
+		if itemCategory.Parent == nil {
+			rootValues = append(rootValues, facetValue)
+			continue
+		}
+		// the parent could be missing when it's not aggregated
+		if parentValue, ok := facetValueMap[itemCategory.Parent.ID]; ok {
+			facetValue.ParentID = parentValue.ID
+			parentValue.Children = append(parentValue.Children, facetValue)
+		}
+	}
+
+	sortFacetValuesByCount(rootValues)
+	totalCount := 0
+	for _, rootValue := range rootValues {
+		totalCount += rootValue.Count
+	}
+	return &Facet{
+		Title:      facetTypeTitleMap[FacetTypeCategoryIDs],
+		FacetType:  FacetTypeCategoryIDs,
+		Values:     rootValues,
+		TotalCount: totalCount,
+	}
+}
+
+func sortFacetValuesByCount(facetValues []*FacetValue) {
+	sort.SliceStable(facetValues, func(i, j int) bool {
+		return facetValues[i].Count > facetValues[j].Count
+	})
+	for _, facetValue := range facetValues {
+		sortFacetValuesByCount(facetValue.Children)
+	}
+}
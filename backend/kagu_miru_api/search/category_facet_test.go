@@ -0,0 +1,104 @@
+package search
+
+import (
+	"testing"
+
+	"cloud.google.com/go/spanner"
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/olivere/elastic/v7"
+)
+
+func Test_newCategoryFacet(t *testing.T) {
+	t.Parallel()
+
+	furniture := &xspanner.ItemCategoryWithParent{ItemCategory: &xspanner.ItemCategory{ID: "100804", Name: "インテリア・寝具・収納", Level: 0}}
+	sofa := &xspanner.ItemCategoryWithParent{
+		ItemCategory: &xspanner.ItemCategory{ID: "111223", Name: "ソファ", Level: 1, ParentID: spanner.NullString{StringVal: "100804", Valid: true}},
+		Parent:       furniture,
+	}
+	chair := &xspanner.ItemCategoryWithParent{
+		ItemCategory: &xspanner.ItemCategory{ID: "566157", Name: "チェア", Level: 1, ParentID: spanner.NullString{StringVal: "100804", Valid: true}},
+		Parent:       furniture,
+	}
+	sofaBed := &xspanner.ItemCategoryWithParent{
+		ItemCategory: &xspanner.ItemCategory{ID: "566168", Name: "ソファベッド", Level: 2, ParentID: spanner.NullString{StringVal: "111223", Valid: true}},
+		Parent:       sofa,
+	}
+	kitchen := &xspanner.ItemCategoryWithParent{ItemCategory: &xspanner.ItemCategory{ID: "558944", Name: "キッチン用品", Level: 0}}
+
+	tests := []struct {
+		name           string
+		bucketKeyItems *elastic.AggregationBucketKeyItems
+		itemCategories []*xspanner.ItemCategoryWithParent
+		want           *Facet
+	}{
+		{
+			name: "builds tree of aggregated categories sorted by count",
+			bucketKeyItems: &elastic.AggregationBucketKeyItems{
+				Buckets: []*elastic.AggregationBucketKeyItem{
+					{Key: "100804", DocCount: 30},
+					{Key: "111223", DocCount: 10},
+					{Key: "566157", DocCount: 20},
+					{Key: "566168", DocCount: 5},
+					{Key: "558944", DocCount: 3},
+				},
+			},
+			// children come before parents to make sure the order doesn't matter
+			itemCategories: []*xspanner.ItemCategoryWithParent{sofaBed, sofa, chair, kitchen, furniture},
+			want: &Facet{
+				Title:     "カテゴリー",
+				FacetType: FacetTypeCategoryIDs,
+				Values: []*FacetValue{
+					{
+						ID:    "100804",
+						Name:  "インテリア・寝具・収納",
+						Count: 30,
+						Children: []*FacetValue{
+							{ID: "566157", Name: "チェア", Count: 20, ParentID: "100804"},
+							{
+								ID:       "111223",
+								Name:     "ソファ",
+								Count:    10,
+								ParentID: "100804",
+								Children: []*FacetValue{
+									{ID: "566168", Name: "ソファベッド", Count: 5, ParentID: "111223"},
+								},
+							},
+						},
+					},
+					{ID: "558944", Name: "キッチン用品", Count: 3},
+				},
+				TotalCount: 33,
+			},
+		},
+		{
+			name: "excludes categories not aggregated",
+			bucketKeyItems: &elastic.AggregationBucketKeyItems{
+				Buckets: []*elastic.AggregationBucketKeyItem{
+					{Key: "558944", DocCount: 3},
+				},
+			},
+			itemCategories: []*xspanner.ItemCategoryWithParent{sofaBed, sofa, chair, kitchen, furniture},
+			want: &Facet{
+				Title:     "カテゴリー",
+				FacetType: FacetTypeCategoryIDs,
+				Values: []*FacetValue{
+					{ID: "558944", Name: "キッチン用品", Count: 3},
+				},
+				TotalCount: 3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := newCategoryFacet(tt.bucketKeyItems, tt.itemCategories)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("newCategoryFacet(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/pkg/interfaceconv"
 	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
 	"go.uber.org/zap"
 
@@ -15,7 +18,7 @@ import (
 
 	"github.com/k-yomo/kagu-miru/backend/internal/es"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
-	"github.com/k-yomo/kagu-miru/backend/pkg/xesquery"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
 	"github.com/olivere/elastic/v7"
 	"go.opentelemetry.io/otel"
 )
@@ -24,14 +27,23 @@ const (
 	defaultPage     int = 0
 	defaultPageSize int = 100
 	maxPageSize     int = 1000
+	// maxResultWindow is the default index.max_result_window, from + size can't exceed it
+	maxResultWindow int = 10000
 
 	minRequiredHitsForQuerySuggestion = 100
+
+	// predictedCategoryBoost is the boost added to the score of items in the categories predicted from the query
+	predictedCategoryBoost = 10
+	// buriedItemNegativeBoost is multiplied to the score of buried items
+	buriedItemNegativeBoost = 0.01
 )
 
 type Client interface {
-	SearchItems(ctx context.Context, input *gqlmodel.SearchInput) (*Response, error)
-	GetSimilarItems(ctx context.Context, input *gqlmodel.GetSimilarItemsInput, item *xspanner.Item) (*Response, error)
+	SearchItems(ctx context.Context, input *gqlmodel.SearchInput, opts *SearchItemsOptions) (*Response, error)
+	// GetSimilarItems searches items similar to the given item, the default ranking profile is used when profile is nil
+	GetSimilarItems(ctx context.Context, input *gqlmodel.GetSimilarItemsInput, item *xspanner.Item, profile *ranking.Profile) (*Response, error)
 	GetQuerySuggestions(ctx context.Context, query string) ([]string, error)
+	GetSpellingSuggestions(ctx context.Context, query string) ([]string, error)
 }
 
 type searchClient struct {
@@ -39,6 +51,7 @@ type searchClient struct {
 	itemsQuerySuggestionsIndexName string
 	esClient                       *elastic.Client
 	dbClient                       db.Client
+	brandNameCache                 brandNameCache
 }
 
 func NewSearchClient(
@@ -56,124 +69,390 @@ func NewSearchClient(
 }
 
 type Response struct {
-	Items      []*es.Item
+	Items      []*Item
 	Facets     []*Facet
 	Page       int
 	TotalPage  int
 	TotalCount int
+	// EndCursor is an opaque cursor to fetch the next page in the same point in time, which is set only when paginating with cursor
+	EndCursor   string
+	HasNextPage bool
+	// InterpretedQueryParts are the parts of the query interpreted as filters
+	InterpretedQueryParts []*InterpretedQueryPart
+	// Relaxation is how the search is broadened since the original search returned no hits
+	Relaxation Relaxation
+	// SpellingSuggestion is the corrected query suggested when the original query scores poorly
+	SpellingSuggestion string
+	// AutoCorrected is true when the items are searched with SpellingSuggestion instead of the original query
+	AutoCorrected bool
+	// PinnedItemIDs are the ids of the items in Items pinned by merchandising rules
+	PinnedItemIDs []string
+	// RankingProfile is the ranking profile used to score items
+	RankingProfile *ranking.Profile
+	// Debug is set only when debug mode is enabled
+	Debug *Debug
+}
+
+// Item is an item in the search result
+type Item struct {
+	*es.Item
+	// SameGroupItems are the other items in the same group collapsed into the item
+	SameGroupItems []*es.Item
+	// Highlight is nil when the item is not matched by the query text
+	Highlight *Highlight
 }
 
-func (s *searchClient) SearchItems(ctx context.Context, input *gqlmodel.SearchInput) (*Response, error) {
+// SearchItemsOptions are the options of SearchItems which are not given by users directly
+type SearchItemsOptions struct {
+	// BoostCategoryIDs are the categories predicted from the query
+	// Items in the categories are ranked higher, but items in the other categories are still returned.
+	BoostCategoryIDs []string
+	// PinnedItemIDs are placed at the top in the given order when sorted by best match
+	PinnedItemIDs []string
+	// BuriedItemIDs and BuriedGroupIDs are ranked lower, but still returned
+	BuriedItemIDs  []string
+	BuriedGroupIDs []string
+	// RankingProfile is the ranking profile to score items, the default profile is used when nil
+	RankingProfile *ranking.Profile
+	// Debug enables debug mode to return the Elasticsearch request and score explanations
+	Debug bool
+	// SkipQuerySuggestion doesn't record the query as a query suggestion e.g. for offline evaluation
+	SkipQuerySuggestion bool
+}
+
+func (s *searchClient) SearchItems(ctx context.Context, input *gqlmodel.SearchInput, opts *SearchItemsOptions) (*Response, error) {
 	ctx, span := otel.Tracer("").Start(ctx, "search.searchClient_SearchItems")
 	defer span.End()
 
-	searchQuery, err := buildSearchQuery(input)
+	if opts == nil {
+		opts = &SearchItemsOptions{}
+	}
+	if opts.RankingProfile == nil {
+		opts.RankingProfile = ranking.DefaultProfile()
+	}
+
+	originalQuery := input.Query
+	cur, err := parseCursor(input.Cursor)
 	if err != nil {
-		return nil, logging.Error(ctx, fmt.Errorf("buildSearchQuery: %w", err))
+		return nil, err
 	}
-	pageSize := defaultPageSize
-	if input.PageSize != nil {
-		pageSize = int(math.Min(float64(*input.PageSize), float64(maxPageSize)))
+	if err := s.openPointInTime(ctx, cur); err != nil {
+		return nil, err
 	}
+	pageSize := calcPageSize(input.PageSize)
+
+	var spellingSuggestion string
+	var autoCorrected bool
+	// the query auto-corrected in the first page is kept while paginating with the cursor
+	effectiveInput := input
+	if cur != nil && cur.CorrectedQuery != "" {
+		spellingSuggestion, autoCorrected = cur.CorrectedQuery, true
+		correctedInput := *input
+		correctedInput.Query = cur.CorrectedQuery
+		effectiveInput = &correctedInput
+	}
+	interpretedInput, interpretedQueryParts := s.interpretQuery(ctx, effectiveInput)
 
-	search := s.esClient.Search().
-		Index(s.itemsIndexName).
-		Query(searchQuery)
-	search, postFilterMap, postMetadataFilterMap := applyAggregationsAndPostFiltersForFacets(search, input.Filter)
-	resp, err := search.
-		SortBy(getSorters(input.SortType)...).
-		From(calcElasticSearchPage(input.Page) * pageSize).
-		Size(pageSize).
-		RequestCache(true).
-		Do(ctx)
+	result, err := s.searchItems(ctx, interpretedInput, RelaxationNone, opts, cur, pageSize)
 	if err != nil {
-		return nil, logging.Error(ctx, fmt.Errorf("esClient.Search: %w", err))
+		return nil, err
 	}
 
-	if resp.Hits.TotalHits.Value >= minRequiredHitsForQuerySuggestion {
+	// spelling suggestion is only for the first page since the query must not be changed while paginating
+	if originalQuery != "" && (cur == nil || cur.Page == 0) && isPoorQuery(result.resp.Hits.TotalHits.Value) {
+		suggestions, err := s.GetSpellingSuggestions(ctx, originalQuery)
+		if err != nil {
+			// search result can be still returned without spelling suggestion
+			logging.Logger(ctx).Warn("GetSpellingSuggestions failed", zap.Error(err))
+		}
+		if len(suggestions) > 0 {
+			spellingSuggestion = suggestions[0]
+		}
+		if spellingSuggestion != "" && (input.DisableAutoCorrect == nil || !*input.DisableAutoCorrect) {
+			correctedInput := *input
+			correctedInput.Query = spellingSuggestion
+			correctedInterpretedInput, correctedInterpretedQueryParts := s.interpretQuery(ctx, &correctedInput)
+			correctedResult, err := s.searchItems(ctx, correctedInterpretedInput, RelaxationNone, opts, cur, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			if shouldAutoCorrect(result.resp.Hits.TotalHits.Value, correctedResult.resp.Hits.TotalHits.Value) {
+				interpretedInput, interpretedQueryParts, result = correctedInterpretedInput, correctedInterpretedQueryParts, correctedResult
+				autoCorrected = true
+			}
+		}
+	}
+
+	// the search is broadened step by step until any items are hit
+	// the original result is kept when no relaxation hits any items
+	relaxation := RelaxationNone
+	if result.resp.Hits.TotalHits.Value == 0 {
+		for _, r := range getRelaxationChain(interpretedInput)[1:] {
+			relaxedResult, err := s.searchItems(ctx, relaxSearchInput(interpretedInput, r), r, opts, cur, pageSize)
+			if err != nil {
+				return nil, err
+			}
+			if relaxedResult.resp.Hits.TotalHits.Value > 0 {
+				relaxation, result = r, relaxedResult
+				break
+			}
+		}
+	}
+
+	if !opts.SkipQuerySuggestion && relaxation == RelaxationNone && !autoCorrected && result.resp.Hits.TotalHits.Value >= minRequiredHitsForQuerySuggestion {
 		go func() {
-			if err := s.insertQuerySuggestion(context.Background(), input.Query); err != nil {
+			if err := s.insertQuerySuggestion(context.Background(), originalQuery); err != nil {
 				logging.Logger(ctx).Error("insertQuerySuggestion failed", zap.Error(err))
 			}
 		}()
 	}
 
-	return &Response{
-		Items:      dedupItems(mapElasticsearchHitsToItems(ctx, resp.Hits.Hits)),
-		Facets:     s.mapAggregationToFacets(ctx, resp.Aggregations, postFilterMap, postMetadataFilterMap),
-		Page:       calcElasticSearchPage(input.Page) + 1,
-		TotalPage:  calcTotalPage(int(resp.Hits.TotalHits.Value), 100),
-		TotalCount: int(resp.Hits.TotalHits.Value),
-	}, nil
+	var correctedQuery string
+	if autoCorrected {
+		correctedQuery = spellingSuggestion
+	}
+	response := newResponse(ctx, result.resp, calcPage(input.Page, cur), pageSize, result.postFilters)
+	if cur != nil {
+		if err := s.applyEndCursor(ctx, response, result.resp, cur, result.searchAfter, correctedQuery); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("applyEndCursor: %w", err))
+		}
+	}
+	response.Facets = s.mapAggregationToFacets(ctx, result.resp.Aggregations, result.postMetadataFilterMap)
+	response.InterpretedQueryParts = interpretedQueryParts
+	response.Relaxation = relaxation
+	response.SpellingSuggestion = spellingSuggestion
+	response.AutoCorrected = autoCorrected
+	response.RankingProfile = opts.RankingProfile
+	if opts.Debug {
+		response.Debug, err = newDebug(result)
+		if err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("newDebug: %w", err))
+		}
+	}
+	if isPinningApplicable(input.SortType) {
+		response.PinnedItemIDs = findPinnedItemIDs(response.Items, opts.PinnedItemIDs)
+	}
+	// pinned items are at the top, and they are kept as placed by editors
+	response.Items = diversifyItems(response.Items, opts.RankingProfile.GetDiversification(input.SortType), len(response.PinnedItemIDs))
+	return response, nil
+}
+
+// interpretQuery returns the input with the filters interpreted from the query and the interpreted parts
+func (s *searchClient) interpretQuery(ctx context.Context, input *gqlmodel.SearchInput) (*gqlmodel.SearchInput, []*InterpretedQueryPart) {
+	if input.Query == "" {
+		return input, nil
+	}
+	brandNameMap, err := s.getBrandNameMap(ctx)
+	if err != nil {
+		// query can be still interpreted without brand names
+		logging.Logger(ctx).Warn("getBrandNameMap failed", zap.Error(err))
+	}
+	parsed := parseQuery(input.Query, brandNameMap)
+	if len(parsed.Parts) == 0 {
+		return input, nil
+	}
+	interpretedInput := *input
+	interpretedInput.Query = parsed.Query
+	interpretedInput.Filter = mergeSearchFilter(input.Filter, parsed.Filter)
+	return &interpretedInput, parsed.Parts
 }
 
-func buildSearchQuery(input *gqlmodel.SearchInput) (query elastic.Query, err error) {
-	var mustQueries []elastic.Query
-	if input.Query != "" {
-		mustQueries = append(mustQueries, elastic.NewMultiMatchQuery(
-			input.Query,
-			xesquery.Boost(es.ItemFieldName, 20),
-			xesquery.Boost(es.ItemFieldBrandName, 5),
-			xesquery.Boost(es.ItemFieldCategoryNames, 5),
-			xesquery.Boost(es.ItemFieldColors, 5),
-			es.ItemFieldDescription,
-		).Type("cross_fields").Operator("AND"))
+type itemsSearchResult struct {
+	// source is the request body of the search
+	source                *elastic.SearchSource
+	resp                  *elastic.SearchResult
+	postFilterMap         map[string]elastic.Query
+	postMetadataFilterMap map[string]elastic.Query
+	postFilters           []elastic.Query
+	// searchAfter is the sort values to fetch the next page with the cursor, nil when there is no next page
+	searchAfter []interface{}
+}
+
+func (s *searchClient) searchItems(ctx context.Context, input *gqlmodel.SearchInput, relaxation Relaxation, opts *SearchItemsOptions, cur *cursor, pageSize int) (*itemsSearchResult, error) {
+	searchQuery, err := buildSearchQuery(input, relaxation, opts)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("buildSearchQuery: %w", err))
+	}
+
+	sorters := getSorters(input.SortType)
+	source := elastic.NewSearchSource()
+	search := applyHighlight(s.newItemsSearch(source, cur).Query(searchQuery).Explain(opts.Debug))
+	search, postFilterMap, postMetadataFilterMap := applyAggregationsAndPostFiltersForFacets(search, input.Filter)
+	postFilters := extractAllFilters(postFilterMap, postMetadataFilterMap)
+	search = applyGroupCount(search.SortBy(sorters...), postFilters)
+
+	result := &itemsSearchResult{
+		source:                source,
+		postFilterMap:         postFilterMap,
+		postMetadataFilterMap: postMetadataFilterMap,
+		postFilters:           postFilters,
+	}
+	if cur == nil {
+		result.resp, err = applyGroupCollapse(search).
+			From(calcElasticSearchPage(input.Page) * pageSize).
+			Size(pageSize).
+			RequestCache(true).
+			Do(ctx)
 	} else {
-		mustQueries = append(mustQueries, elastic.NewMatchAllQuery())
+		result.resp, result.searchAfter, err = searchGroupsAfter(ctx, search, func(filters ...elastic.Query) *elastic.SearchService {
+			return applyHighlight(s.newItemsSearch(elastic.NewSearchSource(), cur)).
+				Query(elastic.NewBoolQuery().Must(searchQuery).Filter(filters...)).
+				PostFilter(elastic.NewBoolQuery().Filter(postFilters...)).
+				Explain(opts.Debug).
+				SortBy(sorters...)
+		}, cur.SearchAfter, pageSize)
 	}
+	if err != nil {
+		return nil, handleSearchError(ctx, err, cur)
+	}
+	return result, nil
+}
 
-	boolQuery := elastic.NewBoolQuery().Must(mustQueries...)
+func buildSearchQuery(input *gqlmodel.SearchInput, relaxation Relaxation, opts *SearchItemsOptions) (query elastic.Query, err error) {
+	boolQuery := elastic.NewBoolQuery().Must(buildTextQuery(input.Query, relaxation, opts.RankingProfile.Search))
+	if len(opts.BoostCategoryIDs) > 0 {
+		boolQuery.Should(
+			elastic.NewTermsQuery(es.ItemFieldCategoryIDs, interfaceconv.StringArrayToInterfaceArray(opts.BoostCategoryIDs)...).
+				Boost(predictedCategoryBoost),
+		)
+	}
 
-	if len(input.Filter.Platforms) > 0 {
-		var platforms []interface{}
-		for _, filterPlatform := range input.Filter.Platforms {
-			platform, err := mapGraphqlPlatformToPlatform(filterPlatform)
-			if err != nil {
-				return nil, fmt.Errorf("platform comversion faild: %w", err)
+	var scoringQuery elastic.Query = newScoringFunctionScoreQuery(boolQuery, opts.RankingProfile.Search)
+
+	if len(opts.BuriedItemIDs) > 0 || len(opts.BuriedGroupIDs) > 0 {
+		buriedQuery := elastic.NewBoolQuery()
+		if len(opts.BuriedItemIDs) > 0 {
+			buriedQuery.Should(elastic.NewTermsQuery(es.ItemFieldID, interfaceconv.StringArrayToInterfaceArray(opts.BuriedItemIDs)...))
+		}
+		if len(opts.BuriedGroupIDs) > 0 {
+			buriedQuery.Should(elastic.NewTermsQuery(es.ItemFieldGroupID, interfaceconv.StringArrayToInterfaceArray(opts.BuriedGroupIDs)...))
+		}
+		scoringQuery = elastic.NewBoostingQuery().Positive(scoringQuery).Negative(buriedQuery).NegativeBoost(buriedItemNegativeBoost)
+	}
+	// pinned items are returned even if they don't match the query, but the filters are still applied
+	if len(opts.PinnedItemIDs) > 0 && isPinningApplicable(input.SortType) {
+		scoringQuery = elastic.NewPinnedQuery().Ids(opts.PinnedItemIDs...).Organic(scoringQuery)
+	}
+
+	// platform, shop, status, price and rating filters are applied as post filters for facets
+
+	return elastic.NewBoolQuery().Must(scoringQuery).Filter(buildDimensionFilters(input.Filter)...), nil
+}
+
+// isPinningApplicable returns if pinned items can be placed at the top
+// Since pinned query works by scoring, pinning is applicable only when sorted by score.
+func isPinningApplicable(sortType *gqlmodel.SearchSortType) bool {
+	return sortType == nil || *sortType == gqlmodel.SearchSortTypeBestMatch
+}
+
+// findPinnedItemIDs returns the ids of the items which are pinned or have pinned items collapsed into them
+func findPinnedItemIDs(items []*Item, pinnedItemIDs []string) []string {
+	if len(pinnedItemIDs) == 0 {
+		return nil
+	}
+	pinnedItemIDMap := make(map[string]bool, len(pinnedItemIDs))
+	for _, itemID := range pinnedItemIDs {
+		pinnedItemIDMap[itemID] = true
+	}
+	var ids []string
+	for _, item := range items {
+		if pinnedItemIDMap[item.ID] {
+			ids = append(ids, item.ID)
+			continue
+		}
+		for _, sameGroupItem := range item.SameGroupItems {
+			if pinnedItemIDMap[sameGroupItem.ID] {
+				ids = append(ids, item.ID)
+				break
 			}
-			platforms = append(platforms, platform)
 		}
-		boolQuery.Filter(elastic.NewTermsQuery(es.ItemFieldPlatform, platforms...))
 	}
+	return ids
+}
 
-	if input.Filter.MinPrice != nil && input.Filter.MaxPrice != nil {
-		boolQuery.Filter(
-			elastic.NewRangeQuery(es.ItemFieldPrice).
-				Gte(*input.Filter.MinPrice).
-				Lte(*input.Filter.MaxPrice),
-		)
-	} else if input.Filter.MinPrice != nil {
-		boolQuery.Filter(elastic.NewRangeQuery(es.ItemFieldPrice).Gte(*input.Filter.MinPrice))
-	} else if input.Filter.MaxPrice != nil {
-		boolQuery.Filter(elastic.NewRangeQuery(es.ItemFieldPrice).Lte(*input.Filter.MaxPrice))
+// buildDimensionFilters builds filters for width, depth and height
+// Since dimensions are indexed as ranges, min/max filters match items whose range is within the given range,
+// while fitsWithin matches items whose range overlaps with 0 to the given size, which means the item may fit.
+func buildDimensionFilters(filter *gqlmodel.SearchFilter) []elastic.Query {
+	var filters []elastic.Query
+	dimensionRanges := []struct {
+		field string
+		min   *int
+		max   *int
+	}{
+		{field: es.ItemFieldWidthRange, min: filter.MinWidth, max: filter.MaxWidth},
+		{field: es.ItemFieldDepthRange, min: filter.MinDepth, max: filter.MaxDepth},
+		{field: es.ItemFieldHeightRange, min: filter.MinHeight, max: filter.MaxHeight},
+	}
+	for _, r := range dimensionRanges {
+		if r.min == nil && r.max == nil {
+			continue
+		}
+		rangeQuery := elastic.NewRangeQuery(r.field).Relation("within")
+		if r.min != nil {
+			rangeQuery.Gte(*r.min)
+		}
+		if r.max != nil {
+			rangeQuery.Lte(*r.max)
+		}
+		filters = append(filters, rangeQuery)
 	}
 
-	if input.Filter.MinRating != nil {
-		boolQuery.Filter(elastic.NewRangeQuery(es.ItemFieldAverageRating).Gte(*input.Filter.MinRating))
+	if filter.FitsWithin != nil {
+		dimensions := []struct {
+			field string
+			size  *int
+		}{
+			{field: es.ItemFieldWidthRange, size: filter.FitsWithin.Width},
+			{field: es.ItemFieldDepthRange, size: filter.FitsWithin.Depth},
+			{field: es.ItemFieldHeightRange, size: filter.FitsWithin.Height},
+		}
+		for _, d := range dimensions {
+			if d.size == nil {
+				continue
+			}
+			filters = append(filters, elastic.NewRangeQuery(d.field).Gte(0).Lte(*d.size).Relation("intersects"))
+		}
 	}
 
-	searchQuery := elastic.NewFunctionScoreQuery().Query(boolQuery).
-		AddScoreFunc(elastic.NewGaussDecayFunction().FieldName(es.ItemFieldAverageRating).Origin(5).Offset(1).Scale(1).Decay(0.4)).
-		AddScoreFunc(elastic.NewFieldValueFactorFunction().Field(es.ItemFieldReviewCount)).
-		MaxBoost(3)
+	return filters
+}
 
-	return searchQuery, nil
+func extractAllFilters(filterMaps ...map[string]elastic.Query) []elastic.Query {
+	var filters []elastic.Query
+	for _, filterMap := range filterMaps {
+		for _, filter := range filterMap {
+			filters = append(filters, filter)
+		}
+	}
+	return filters
 }
 
+// extractFiltersExceptForField returns the filters except for the field's one ordered by the field name
+// The order is fixed so that the same request builds the same query.
 func extractFiltersExceptForField(field string, filterMap map[string]elastic.Query) []elastic.Query {
-	var filters []elastic.Query
-	for filteredField, filter := range filterMap {
+	filteredFields := make([]string, 0, len(filterMap))
+	for filteredField := range filterMap {
 		if filteredField != field {
-			filters = append(filters, filter)
+			filteredFields = append(filteredFields, filteredField)
 		}
 	}
+	sort.Strings(filteredFields)
+
+	var filters []elastic.Query
+	for _, filteredField := range filteredFields {
+		filters = append(filters, filterMap[filteredField])
+	}
 	return filters
 }
 
+// getSorters returns sorters for the given sort type
+// id is always added as a tiebreaker to paginate in a consistent order
 func getSorters(sortType *gqlmodel.SearchSortType) []elastic.Sorter {
+	tiebreaker := elastic.NewFieldSort(es.ItemFieldID).Asc()
 	// defaulting to best match
 	if sortType == nil {
-		return []elastic.Sorter{elastic.NewScoreSort().Desc()}
+		return []elastic.Sorter{elastic.NewScoreSort().Desc(), tiebreaker}
 	}
 	var sorters []elastic.Sorter
 	switch *sortType {
@@ -187,30 +466,30 @@ func getSorters(sortType *gqlmodel.SearchSortType) []elastic.Sorter {
 			elastic.NewFieldSort(es.ItemFieldAverageRating).Desc(),
 		}
 	case gqlmodel.SearchSortTypeRating:
+		// items indexed before rating score was introduced don't have the score
 		sorters = []elastic.Sorter{
-			elastic.NewFieldSort(es.ItemFieldAverageRating).Desc(),
-			elastic.NewFieldSort(es.ItemFieldReviewCount).Desc(),
+			elastic.NewFieldSort(es.ItemFieldRatingScore).Desc().Missing("_last"),
+			elastic.NewFieldSort(es.ItemFieldGroupReviewCount).Desc().Missing("_last"),
 		}
 	default:
 		sorters = []elastic.Sorter{elastic.NewScoreSort().Desc()}
 	}
 
-	return sorters
+	return append(sorters, tiebreaker)
 }
 
-func (s *searchClient) GetSimilarItems(ctx context.Context, input *gqlmodel.GetSimilarItemsInput, item *xspanner.Item) (*Response, error) {
+func (s *searchClient) GetSimilarItems(ctx context.Context, input *gqlmodel.GetSimilarItemsInput, item *xspanner.Item, profile *ranking.Profile) (*Response, error) {
 	ctx, span := otel.Tracer("").Start(ctx, "search.searchClient_GetSimilarIts")
 	defer span.End()
 
+	if profile == nil {
+		profile = ranking.DefaultProfile()
+	}
+	scoring := profile.SimilarItems
+
 	boolQuery := elastic.NewBoolQuery().
 		Must(
-			elastic.NewMoreLikeThisQuery().Field(
-				xesquery.Boost(es.ItemFieldName, 30),
-				xesquery.Boost(es.ItemFieldBrandName, 10),
-				xesquery.Boost(es.ItemFieldCategoryNames, 5),
-				xesquery.Boost(es.ItemFieldColors, 5),
-				es.ItemFieldDescription,
-			).LikeItems(
+			elastic.NewMoreLikeThisQuery().Field(scoring.BoostedFields()...).LikeItems(
 				elastic.NewMoreLikeThisQueryItem().
 					Index(s.itemsIndexName).
 					Id(input.ItemID),
@@ -223,48 +502,56 @@ func (s *searchClient) GetSimilarItems(ctx context.Context, input *gqlmodel.GetS
 		boolQuery.MustNot(elastic.NewTermQuery(es.ItemFieldGroupID, item.GroupID))
 	}
 
-	functionScoreQuery := elastic.NewFunctionScoreQuery().
-		Query(boolQuery).
-		AddScoreFunc(
+	functionScoreQuery := newScoringFunctionScoreQuery(boolQuery, scoring)
+	if scoring.PriceDecay != nil {
+		functionScoreQuery.AddScoreFunc(
 			elastic.NewGaussDecayFunction().
 				FieldName(es.ItemFieldPrice).Origin(item.Price).
-				Offset(int(math.Round(float64(item.Price) * 0.2))).
-				Scale(int(math.Round(float64(item.Price) * 0.2))).
-				Decay(0.5),
-		).
-		AddScoreFunc(
-			elastic.NewGaussDecayFunction().
-				FieldName(es.ItemFieldAverageRating).
-				Origin(5).
-				Offset(1).
-				Scale(1).
-				Decay(0.5),
-		).
-		AddScoreFunc(elastic.NewFieldValueFactorFunction().Field(es.ItemFieldReviewCount)).
-		MaxBoost(3)
+				Offset(int(math.Round(float64(item.Price) * scoring.PriceDecay.OffsetRatio))).
+				Scale(int(math.Round(float64(item.Price) * scoring.PriceDecay.ScaleRatio))).
+				Decay(scoring.PriceDecay.Decay),
+		)
+	}
 
-	pageSize := defaultPageSize
-	if input.PageSize != nil {
-		pageSize = int(math.Min(float64(*input.PageSize), float64(maxPageSize)))
+	cur, err := parseCursor(input.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.openPointInTime(ctx, cur); err != nil {
+		return nil, err
+	}
+	pageSize := calcPageSize(input.PageSize)
+
+	sorters := []elastic.Sorter{elastic.NewScoreSort(), elastic.NewFieldSort(es.ItemFieldID).Asc()}
+	newSearch := func(filters ...elastic.Query) *elastic.SearchService {
+		return s.newItemsSearch(elastic.NewSearchSource(), cur).
+			Query(elastic.NewBoolQuery().Must(functionScoreQuery).Filter(filters...)).
+			SortBy(sorters...)
+	}
+	search := applyGroupCount(newSearch(), nil)
+	var resp *elastic.SearchResult
+	var searchAfter []interface{}
+	if cur == nil {
+		resp, err = applyGroupCollapse(search).
+			From(calcElasticSearchPage(input.Page) * pageSize).
+			Size(pageSize).
+			RequestCache(true).
+			Do(ctx)
+	} else {
+		resp, searchAfter, err = searchGroupsAfter(ctx, search, newSearch, cur.SearchAfter, pageSize)
 	}
-	resp, err := s.esClient.Search().
-		Index(s.itemsIndexName).
-		Query(functionScoreQuery).
-		SortBy(elastic.NewScoreSort()).
-		From(calcElasticSearchPage(input.Page) * pageSize).
-		Size(pageSize).
-		RequestCache(true).
-		Do(ctx)
 	if err != nil {
-		return nil, logging.Error(ctx, fmt.Errorf("esClient.Search: %w", err))
+		return nil, handleSearchError(ctx, err, cur)
 	}
 
-	return &Response{
-		Items:      dedupItems(mapElasticsearchHitsToItems(ctx, resp.Hits.Hits)),
-		Page:       calcElasticSearchPage(input.Page) + 1,
-		TotalPage:  calcTotalPage(int(resp.Hits.TotalHits.Value), 100),
-		TotalCount: int(resp.Hits.TotalHits.Value),
-	}, nil
+	response := newResponse(ctx, resp, calcPage(input.Page, cur), pageSize, nil)
+	if cur != nil {
+		if err := s.applyEndCursor(ctx, response, resp, cur, searchAfter, ""); err != nil {
+			return nil, logging.Error(ctx, fmt.Errorf("applyEndCursor: %w", err))
+		}
+	}
+	response.RankingProfile = profile
+	return response, nil
 }
 
 func (s *searchClient) GetQuerySuggestions(ctx context.Context, query string) ([]string, error) {
@@ -324,6 +611,126 @@ func (s *searchClient) insertQuerySuggestion(ctx context.Context, query string)
 	return nil
 }
 
+// newItemsSearch initializes search service for items index
+// When paginating with cursor, point in time is used instead of the index to get consistent results across pages
+// The given source can be used to get the built request body since the search service doesn't expose it.
+func (s *searchClient) newItemsSearch(source *elastic.SearchSource, cur *cursor) *elastic.SearchService {
+	if cur == nil {
+		return s.esClient.Search().SearchSource(source).Index(s.itemsIndexName)
+	}
+	return s.esClient.Search().
+		SearchSource(source).
+		PointInTime(elastic.NewPointInTimeWithKeepAlive(cur.PointInTimeID, pointInTimeKeepAlive))
+}
+
+// openPointInTime opens the point in time for the cursor of the first page
+func (s *searchClient) openPointInTime(ctx context.Context, cur *cursor) error {
+	if cur == nil || cur.PointInTimeID != "" {
+		return nil
+	}
+	resp, err := s.esClient.OpenPointInTime(s.itemsIndexName).KeepAlive(pointInTimeKeepAlive).Do(ctx)
+	if err != nil {
+		return logging.Error(ctx, fmt.Errorf("esClient.OpenPointInTime: %w", err))
+	}
+	cur.PointInTimeID = resp.Id
+	return nil
+}
+
+func handleSearchError(ctx context.Context, err error, cur *cursor) error {
+	// point in time is expired or closed
+	if cur != nil && elastic.IsNotFound(err) {
+		return xerror.NewInvalidArgument(fmt.Errorf("cursor is expired: %w", err))
+	}
+	return logging.Error(ctx, fmt.Errorf("esClient.Search: %w", err))
+}
+
+// parseCursor parses the cursor of the input
+// An empty cursor starts paginating with cursor from the first page, and nil is returned when cursor is not given.
+func parseCursor(cursorStr *string) (*cursor, error) {
+	if cursorStr == nil {
+		return nil, nil
+	}
+	if *cursorStr == "" {
+		return &cursor{}, nil
+	}
+	cur, err := decodeCursor(*cursorStr)
+	if err != nil {
+		return nil, xerror.NewInvalidArgument(fmt.Errorf("invalid cursor '%s': %w", *cursorStr, err))
+	}
+	return cur, nil
+}
+
+// newResponse maps the search result to Response
+// Items are collapsed by group, so the group count is used as the total count
+func newResponse(ctx context.Context, resp *elastic.SearchResult, page int, pageSize int, postFilters []elastic.Query) *Response {
+	items := mapElasticsearchHitsToItemsWithSameGroupItems(ctx, resp.Hits.Hits)
+	totalCount := int(resp.Hits.TotalHits.Value)
+	// total hits is a lower bound when it exceeds track_total_hits limit
+	isTotalCountLowerBound := resp.Hits.TotalHits.Relation == "gte"
+	if groupCount, ok := getGroupCount(resp.Aggregations, postFilters); ok {
+		totalCount = groupCount
+		isTotalCountLowerBound = false
+	}
+
+	return &Response{
+		Items:       items,
+		Page:        page,
+		TotalPage:   calcTotalPage(totalCount, pageSize),
+		TotalCount:  totalCount,
+		HasNextPage: len(resp.Hits.Hits) == pageSize && (isTotalCountLowerBound || page*pageSize < totalCount) && (page+1)*pageSize <= maxResultWindow,
+	}
+}
+
+// applyEndCursor sets the cursor pointing to the page next to the given search result when paginating with cursor
+// The point in time is closed when there is no next page, correctedQuery is kept to search with the same query in the next page.
+func (s *searchClient) applyEndCursor(ctx context.Context, response *Response, resp *elastic.SearchResult, cur *cursor, searchAfter []interface{}, correctedQuery string) error {
+	pointInTimeID := cur.PointInTimeID
+	// the id can be changed by each search
+	if resp.PitId != "" {
+		pointInTimeID = resp.PitId
+	}
+	if searchAfter == nil {
+		response.EndCursor, response.HasNextPage = "", false
+		s.closePointInTime(ctx, pointInTimeID)
+		return nil
+	}
+
+	endCursor, err := encodeCursor(&cursor{
+		PointInTimeID:  pointInTimeID,
+		SearchAfter:    searchAfter,
+		Page:           response.Page,
+		CorrectedQuery: correctedQuery,
+	})
+	if err != nil {
+		return fmt.Errorf("encodeCursor: %w", err)
+	}
+	response.EndCursor, response.HasNextPage = endCursor, true
+	return nil
+}
+
+// closePointInTime closes the point in time after the last page
+// It's also closed when it's expired, so the search result is still returned on failure.
+func (s *searchClient) closePointInTime(ctx context.Context, pointInTimeID string) {
+	if _, err := s.esClient.ClosePointInTime(pointInTimeID).Do(ctx); err != nil {
+		logging.Logger(ctx).Warn("esClient.ClosePointInTime failed", zap.Error(err))
+	}
+}
+
+func calcPageSize(inputPageSize *int) int {
+	if inputPageSize == nil {
+		return defaultPageSize
+	}
+	return int(math.Min(float64(*inputPageSize), float64(maxPageSize)))
+}
+
+// calcPage returns the current page number starting from 1
+func calcPage(inputPage *int, cur *cursor) int {
+	if cur != nil {
+		return cur.Page + 1
+	}
+	return calcElasticSearchPage(inputPage) + 1
+}
+
 func calcTotalPage(totalItems, pageSize int) int {
 	if totalItems == 0 {
 		return 1
@@ -339,16 +746,3 @@ func calcElasticSearchPage(inputPage *int) int {
 	}
 	return page
 }
-
-func dedupItems(items []*es.Item) []*es.Item {
-	groupIDMap := make(map[string]bool)
-	dedupedItems := make([]*es.Item, 0, len(items))
-	for _, item := range items {
-		if item.GroupID != "" && groupIDMap[item.GroupID] {
-			continue
-		}
-		dedupedItems = append(dedupedItems, item)
-		groupIDMap[item.GroupID] = true
-	}
-	return dedupedItems
-}
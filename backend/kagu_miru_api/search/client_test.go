@@ -0,0 +1,89 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+)
+
+func Test_buildDimensionFilters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		filter *gqlmodel.SearchFilter
+		want   []string
+	}{
+		{
+			name:   "no filters",
+			filter: &gqlmodel.SearchFilter{},
+			want:   []string{},
+		},
+		{
+			name:   "matches items within the min and max",
+			filter: &gqlmodel.SearchFilter{MinWidth: intPtr(100), MaxWidth: intPtr(200)},
+			want: []string{
+				`{"range":{"width_range":{"from":100,"include_lower":true,"include_upper":true,"relation":"within","to":200}}}`,
+			},
+		},
+		{
+			name:   "matches items within the min only",
+			filter: &gqlmodel.SearchFilter{MinDepth: intPtr(50)},
+			want: []string{
+				`{"range":{"depth_range":{"from":50,"include_lower":true,"include_upper":true,"relation":"within","to":null}}}`,
+			},
+		},
+		{
+			name:   "matches items within the max only",
+			filter: &gqlmodel.SearchFilter{MaxHeight: intPtr(80)},
+			want: []string{
+				`{"range":{"height_range":{"from":null,"include_lower":true,"include_upper":true,"relation":"within","to":80}}}`,
+			},
+		},
+		{
+			name: "matches items intersecting with 0 to the size to fit within",
+			filter: &gqlmodel.SearchFilter{
+				FitsWithin: &gqlmodel.DimensionsInput{Width: intPtr(120), Height: intPtr(90)},
+			},
+			want: []string{
+				`{"range":{"width_range":{"from":0,"include_lower":true,"include_upper":true,"relation":"intersects","to":120}}}`,
+				`{"range":{"height_range":{"from":0,"include_lower":true,"include_upper":true,"relation":"intersects","to":90}}}`,
+			},
+		},
+		{
+			name: "combines min, max and fits within",
+			filter: &gqlmodel.SearchFilter{
+				MinWidth:   intPtr(100),
+				FitsWithin: &gqlmodel.DimensionsInput{Width: intPtr(150)},
+			},
+			want: []string{
+				`{"range":{"width_range":{"from":100,"include_lower":true,"include_upper":true,"relation":"within","to":null}}}`,
+				`{"range":{"width_range":{"from":0,"include_lower":true,"include_upper":true,"relation":"intersects","to":150}}}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := []string{}
+			for _, filter := range buildDimensionFilters(tt.filter) {
+				source, err := filter.Source()
+				if err != nil {
+					t.Fatalf("Source() error = %v", err)
+				}
+				b, err := json.Marshal(source)
+				if err != nil {
+					t.Fatalf("json.Marshal() error = %v", err)
+				}
+				got = append(got, string(b))
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("buildDimensionFilters(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
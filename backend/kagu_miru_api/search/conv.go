@@ -21,6 +21,8 @@ func mapGraphqlPlatformToPlatform(platform gqlmodel.ItemSellingPlatform) (xitem.
 		return xitem.PlatformYahooShopping, nil
 	case gqlmodel.ItemSellingPlatformPaypayMall:
 		return xitem.PlatformPayPayMall, nil
+	case gqlmodel.ItemSellingPlatformAmazon:
+		return xitem.PlatformAmazon, nil
 	default:
 		return "", fmt.Errorf("unknown platform %s", platform.String())
 	}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+// pointInTimeKeepAlive is how long the point in time opened for cursor based pagination is kept alive
+// It's extended every time the cursor is used, so it only needs to cover the interval between page requests
+const pointInTimeKeepAlive = "5m"
+
+// cursor holds the state to fetch the next page in the same point in time
+// It's exposed to the client as an opaque base64 encoded string
+type cursor struct {
+	// PointInTimeID is the id of the point in time used to get consistent results across pages
+	PointInTimeID string `json:"pit,omitempty"`
+	// SearchAfter is the sort values of the last hit in the previous page, which ends with id as a tiebreaker
+	SearchAfter []interface{} `json:"sa,omitempty"`
+	// Page is the page number (starts from 1) of the previous page, 0 when no page is fetched yet
+	Page int `json:"p"`
+	// CorrectedQuery is the query auto-corrected in the first page, which is used instead of the given query
+	CorrectedQuery string `json:"cq,omitempty"`
+}
+
+func encodeCursor(c *cursor) (string, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor decodes the end cursor of a page
+func decodeCursor(s string) (*cursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("base64.DecodeString: %w", err)
+	}
+	var c cursor
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	if c.PointInTimeID == "" {
+		return nil, fmt.Errorf("point in time id must be set")
+	}
+	if len(c.SearchAfter) == 0 {
+		return nil, fmt.Errorf("search after must be set")
+	}
+	if c.Page < 1 {
+		return nil, fmt.Errorf("page must be 1 or greater")
+	}
+	return &c, nil
+}
@@ -0,0 +1,74 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_decodeCursor(t *testing.T) {
+	t.Parallel()
+
+	validCursor, _ := encodeCursor(&cursor{
+		PointInTimeID:  "pit",
+		SearchAfter:    []interface{}{12.5, "item-1"},
+		Page:           2,
+		CorrectedQuery: "ソファ",
+	})
+	noPointInTimeCursor, _ := encodeCursor(&cursor{SearchAfter: []interface{}{12.5, "item-1"}, Page: 2})
+	noSearchAfterCursor, _ := encodeCursor(&cursor{PointInTimeID: "pit", Page: 2})
+	noPageCursor, _ := encodeCursor(&cursor{PointInTimeID: "pit", SearchAfter: []interface{}{12.5, "item-1"}})
+
+	tests := []struct {
+		name    string
+		str     string
+		want    *cursor
+		wantErr bool
+	}{
+		{
+			name: "decodes encoded cursor",
+			str:  validCursor,
+			want: &cursor{
+				PointInTimeID:  "pit",
+				SearchAfter:    []interface{}{12.5, "item-1"},
+				Page:           2,
+				CorrectedQuery: "ソファ",
+			},
+		},
+		{
+			name:    "returns error when point in time id is not set",
+			str:     noPointInTimeCursor,
+			wantErr: true,
+		},
+		{
+			name:    "returns error when search after is not set",
+			str:     noSearchAfterCursor,
+			wantErr: true,
+		},
+		{
+			name:    "returns error when page is not set",
+			str:     noPageCursor,
+			wantErr: true,
+		},
+		{
+			name:    "returns error when invalid string is given",
+			str:     "invalid cursor",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := decodeCursor(tt.str)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("decodeCursor() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("decodeCursor(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
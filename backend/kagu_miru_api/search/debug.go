@@ -0,0 +1,102 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// Debug is the debug info of the search to investigate how items are matched and scored
+type Debug struct {
+	// Request is the request body sent to Elasticsearch
+	Request map[string]interface{}
+	// PostFilters is the map of the filtered field to the post filter
+	PostFilters map[string]interface{}
+	// MetadataPostFilters is the map of the metadata name to the post filter
+	MetadataPostFilters map[string]interface{}
+	// Aggregations is the map of the aggregation name to the aggregation for facets
+	Aggregations    map[string]interface{}
+	HitExplanations []*HitExplanation
+}
+
+// HitExplanation is the breakdown of the score of the hit
+type HitExplanation struct {
+	ItemID      string
+	Score       float64
+	Explanation map[string]interface{}
+}
+
+func newDebug(result *itemsSearchResult) (*Debug, error) {
+	request, err := toJSONMap(result.source)
+	if err != nil {
+		return nil, fmt.Errorf("toJSONMap: %w", err)
+	}
+	postFilters, err := queryMapToJSONMap(result.postFilterMap)
+	if err != nil {
+		return nil, fmt.Errorf("queryMapToJSONMap: %w", err)
+	}
+	metadataPostFilters, err := queryMapToJSONMap(result.postMetadataFilterMap)
+	if err != nil {
+		return nil, fmt.Errorf("queryMapToJSONMap: %w", err)
+	}
+	aggregations, _ := request["aggregations"].(map[string]interface{})
+	if aggregations == nil {
+		aggregations = make(map[string]interface{})
+	}
+
+	hitExplanations := make([]*HitExplanation, 0, len(result.resp.Hits.Hits))
+	for _, hit := range result.resp.Hits.Hits {
+		hitExplanation := &HitExplanation{ItemID: hit.Id}
+		if hit.Score != nil {
+			hitExplanation.Score = *hit.Score
+		}
+		if hit.Explanation != nil {
+			hitExplanation.Explanation, err = toJSONMap(hit.Explanation)
+			if err != nil {
+				return nil, fmt.Errorf("toJSONMap: %w", err)
+			}
+		}
+		hitExplanations = append(hitExplanations, hitExplanation)
+	}
+
+	return &Debug{
+		Request:             request,
+		PostFilters:         postFilters,
+		MetadataPostFilters: metadataPostFilters,
+		Aggregations:        aggregations,
+		HitExplanations:     hitExplanations,
+	}, nil
+}
+
+func queryMapToJSONMap(queryMap map[string]elastic.Query) (map[string]interface{}, error) {
+	jsonMap := make(map[string]interface{}, len(queryMap))
+	for key, query := range queryMap {
+		src, err := query.Source()
+		if err != nil {
+			return nil, fmt.Errorf("query.Source: %w", err)
+		}
+		jsonMap[key] = src
+	}
+	return jsonMap, nil
+}
+
+// toJSONMap converts the Elasticsearch source into the map in the same structure as JSON
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	if source, ok := v.(*elastic.SearchSource); ok {
+		src, err := source.Source()
+		if err != nil {
+			return nil, fmt.Errorf("source.Source: %w", err)
+		}
+		v = src
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+	m := make(map[string]interface{})
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+	return m, nil
+}
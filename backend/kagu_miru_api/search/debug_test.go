@@ -0,0 +1,86 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/olivere/elastic/v7"
+)
+
+func Test_newDebug(t *testing.T) {
+	t.Parallel()
+
+	score := 1.5
+	result := &itemsSearchResult{
+		source: elastic.NewSearchSource().
+			Query(elastic.NewMatchQuery(es.ItemFieldName, "ソファ")).
+			Aggregation(es.ItemFieldBrandName, elastic.NewTermsAggregation().Field(es.ItemFieldBrandName)),
+		resp: &elastic.SearchResult{Hits: &elastic.SearchHits{Hits: []*elastic.SearchHit{
+			{
+				Id:    "1",
+				Score: &score,
+				Explanation: &elastic.SearchExplanation{
+					Value:       1.5,
+					Description: "sum of:",
+				},
+			},
+			{Id: "2"},
+		}}},
+		postFilterMap: map[string]elastic.Query{
+			es.ItemFieldBrandName: elastic.NewTermsQuery(es.ItemFieldBrandName, "brand"),
+		},
+		postMetadataFilterMap: map[string]elastic.Query{},
+	}
+
+	want := &Debug{
+		Request: map[string]interface{}{
+			"query": map[string]interface{}{"match": map[string]interface{}{"name": map[string]interface{}{"query": "ソファ"}}},
+			"aggregations": map[string]interface{}{
+				"brand_name": map[string]interface{}{"terms": map[string]interface{}{"field": "brand_name"}},
+			},
+		},
+		PostFilters: map[string]interface{}{
+			"brand_name": map[string]interface{}{"terms": map[string]interface{}{"brand_name": []interface{}{"brand"}}},
+		},
+		MetadataPostFilters: map[string]interface{}{},
+		Aggregations: map[string]interface{}{
+			"brand_name": map[string]interface{}{"terms": map[string]interface{}{"field": "brand_name"}},
+		},
+		HitExplanations: []*HitExplanation{
+			{ItemID: "1", Score: 1.5, Explanation: map[string]interface{}{"value": 1.5, "description": "sum of:"}},
+			{ItemID: "2"},
+		},
+	}
+	got, err := newDebug(result)
+	if err != nil {
+		t.Fatalf("newDebug() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("newDebug(), (-want +got): %s", diff)
+	}
+}
+
+func Test_newDebug_withoutAggregations(t *testing.T) {
+	t.Parallel()
+
+	result := &itemsSearchResult{
+		source: elastic.NewSearchSource().Query(elastic.NewMatchAllQuery()),
+		resp:   &elastic.SearchResult{Hits: &elastic.SearchHits{}},
+	}
+
+	want := &Debug{
+		Request:             map[string]interface{}{"query": map[string]interface{}{"match_all": map[string]interface{}{}}},
+		PostFilters:         map[string]interface{}{},
+		MetadataPostFilters: map[string]interface{}{},
+		Aggregations:        map[string]interface{}{},
+		HitExplanations:     []*HitExplanation{},
+	}
+	got, err := newDebug(result)
+	if err != nil {
+		t.Fatalf("newDebug() error = %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("newDebug(), (-want +got): %s", diff)
+	}
+}
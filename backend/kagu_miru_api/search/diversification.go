@@ -0,0 +1,56 @@
+package search
+
+import (
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+)
+
+// diversifyItems re-ranks items not to place too many items from the same shop, brand or group in a row
+// Items are picked greedily in the original order, and the item violating the caps is deferred
+// until the window moves on. When every remaining item violates the caps, the first one is placed as is,
+// so that no items are dropped. The first fixedCount items (e.g. pinned items) are kept at their positions.
+func diversifyItems(items []*Item, config *ranking.DiversificationConfig, fixedCount int) []*Item {
+	if config == nil || len(items) <= fixedCount+1 {
+		return items
+	}
+
+	diversified := make([]*Item, 0, len(items))
+	diversified = append(diversified, items[:fixedCount]...)
+	remaining := append([]*Item{}, items[fixedCount:]...)
+	for len(remaining) > 0 {
+		windowStart := len(diversified) - (config.WindowSize - 1)
+		if windowStart < fixedCount {
+			windowStart = fixedCount
+		}
+		window := diversified[windowStart:]
+
+		picked := 0
+		for i, item := range remaining {
+			if !exceedsDiversificationCaps(window, item, config) {
+				picked = i
+				break
+			}
+		}
+		diversified = append(diversified, remaining[picked])
+		remaining = append(remaining[:picked], remaining[picked+1:]...)
+	}
+	return diversified
+}
+
+// exceedsDiversificationCaps returns true if adding the item to the window exceeds any caps
+func exceedsDiversificationCaps(window []*Item, item *Item, config *ranking.DiversificationConfig) bool {
+	var sameShop, sameBrand, sameGroup int
+	for _, windowItem := range window {
+		if item.ShopID != "" && windowItem.ShopID == item.ShopID && windowItem.Platform == item.Platform {
+			sameShop++
+		}
+		if item.BrandName != "" && windowItem.BrandName == item.BrandName {
+			sameBrand++
+		}
+		if item.GroupID != "" && windowItem.GroupID == item.GroupID {
+			sameGroup++
+		}
+	}
+	return (config.MaxSameShop > 0 && sameShop+1 > config.MaxSameShop) ||
+		(config.MaxSameBrand > 0 && sameBrand+1 > config.MaxSameBrand) ||
+		(config.MaxSameGroup > 0 && sameGroup+1 > config.MaxSameGroup)
+}
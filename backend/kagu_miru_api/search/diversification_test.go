@@ -0,0 +1,96 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+)
+
+func Test_diversifyItems(t *testing.T) {
+	t.Parallel()
+
+	newItem := func(id string, shopID string, brandName string, groupID string) *Item {
+		return &Item{Item: &es.Item{ID: id, ShopID: shopID, BrandName: brandName, GroupID: groupID}}
+	}
+	config := &ranking.DiversificationConfig{WindowSize: 3, MaxSameShop: 1, MaxSameGroup: 1}
+
+	tests := []struct {
+		name       string
+		items      []*Item
+		config     *ranking.DiversificationConfig
+		fixedCount int
+		want       []string
+	}{
+		{
+			name: "defers items from the same shop within the window",
+			items: []*Item{
+				newItem("a1", "a", "", "1"),
+				newItem("a2", "a", "", "2"),
+				newItem("a3", "a", "", "3"),
+				newItem("b4", "b", "", "4"),
+				newItem("c5", "c", "", "5"),
+				newItem("a6", "a", "", "6"),
+			},
+			config: config,
+			want:   []string{"a1", "b4", "c5", "a2", "a3", "a6"},
+		},
+		{
+			name: "caps items in the same group",
+			items: []*Item{
+				newItem("a1", "a", "", "1"),
+				newItem("b1", "b", "", "1"),
+				newItem("c2", "c", "", "2"),
+			},
+			config: config,
+			want:   []string{"a1", "c2", "b1"},
+		},
+		{
+			name: "keeps fixed items at the top",
+			items: []*Item{
+				newItem("a1", "a", "", "1"),
+				newItem("a2", "a", "", "2"),
+				newItem("a3", "a", "", "3"),
+				newItem("b4", "b", "", "4"),
+			},
+			config:     config,
+			fixedCount: 2,
+			want:       []string{"a1", "a2", "a3", "b4"},
+		},
+		{
+			name: "caps brands",
+			items: []*Item{
+				newItem("1", "a", "nitori", "1"),
+				newItem("2", "b", "nitori", "2"),
+				newItem("3", "c", "nitori", "3"),
+				newItem("4", "d", "lowya", "4"),
+			},
+			config: &ranking.DiversificationConfig{WindowSize: 3, MaxSameBrand: 2},
+			want:   []string{"1", "2", "4", "3"},
+		},
+		{
+			name: "doesn't diversify without config",
+			items: []*Item{
+				newItem("a1", "a", "", "1"),
+				newItem("a2", "a", "", "2"),
+				newItem("b3", "b", "", "3"),
+			},
+			want: []string{"a1", "a2", "b3"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, item := range diversifyItems(tt.items, tt.config, tt.fixedCount) {
+				got = append(got, item.ID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("diversifyItems(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -3,9 +3,10 @@ package search
 import (
 	"context"
 	"sort"
-	"strings"
+	"strconv"
 
 	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/internal/xitem"
 	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
 	"github.com/k-yomo/kagu-miru/backend/pkg/interfaceconv"
 	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
@@ -13,6 +14,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// defaultFacetSize is the max number of values aggregated for a facet
+const defaultFacetSize = 30
+
 type FacetType int
 
 const (
@@ -20,12 +24,29 @@ const (
 	FacetTypeBrandNames
 	FacetTypeColors
 	FacetTypeMetadata
+	FacetTypePrice
+	FacetTypeAverageRating
+	FacetTypeReviewCount
+	FacetTypePlatforms
+	FacetTypeShopIDs
 )
 
 var facetTypeTitleMap = map[FacetType]string{
-	FacetTypeCategoryIDs: "カテゴリー",
-	FacetTypeBrandNames:  "ブランド",
-	FacetTypeColors:      "カラー",
+	FacetTypeCategoryIDs:   "カテゴリー",
+	FacetTypeBrandNames:    "ブランド",
+	FacetTypeColors:        "カラー",
+	FacetTypePrice:         "価格",
+	FacetTypeAverageRating: "評価",
+	FacetTypeReviewCount:   "レビュー数",
+	FacetTypePlatforms:     "ショッピングサイト",
+	FacetTypeShopIDs:       "ショップ",
+}
+
+var platformNameMap = map[string]string{
+	string(xitem.PlatformAmazon):        "Amazon",
+	string(xitem.PlatformRakuten):       "楽天市場",
+	string(xitem.PlatformYahooShopping): "Yahoo!ショッピング",
+	string(xitem.PlatformPayPayMall):    "PayPayモール",
 }
 
 type Facet struct {
@@ -33,6 +54,9 @@ type Facet struct {
 	FacetType  FacetType
 	Values     []*FacetValue
 	TotalCount int
+	// Stats and Histogram are set only for numeric facets
+	Stats     *FacetStats
+	Histogram []*HistogramBucket
 }
 
 func (f *Facet) IsValid() bool {
@@ -44,6 +68,9 @@ type FacetValue struct {
 	ID    string
 	Name  string
 	Count int
+	// ParentID and Children are set only for hierarchical facets
+	ParentID string
+	Children []*FacetValue
 }
 
 func newFacetFromBucketKeyItems(
@@ -54,7 +81,7 @@ func newFacetFromBucketKeyItems(
 	facetValues := make([]*FacetValue, 0, len(bucketKeyItems.Buckets))
 	totalCount := bucketKeyItems.SumOfOtherDocCount
 	for _, bucket := range bucketKeyItems.Buckets {
-		keyStr, ok := bucket.Key.(string)
+		keyStr, ok := bucketKeyToString(bucket.Key)
 		if !ok {
 			continue
 		}
@@ -73,6 +100,19 @@ func newFacetFromBucketKeyItems(
 	}
 }
 
+// bucketKeyToString converts the key of terms aggregation bucket to string
+// Key of numeric field is decoded as float64
+func bucketKeyToString(key interface{}) (string, bool) {
+	switch k := key.(type) {
+	case string:
+		return k, true
+	case float64:
+		return strconv.FormatFloat(k, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
+
 func applyAggregationsAndPostFiltersForFacets(search *elastic.SearchService, searchFilter *gqlmodel.SearchFilter) (*elastic.SearchService, map[string]elastic.Query, map[string]elastic.Query) {
 	// filters for facetable fields
 	postFilterMap := make(map[string]elastic.Query)
@@ -82,9 +122,8 @@ func applyAggregationsAndPostFiltersForFacets(search *elastic.SearchService, sea
 		for _, id := range searchFilter.CategoryIds {
 			categoryIDs = append(categoryIDs, id)
 		}
-		// Since we aggregate for category_id
 		filter := elastic.NewTermsQuery(es.ItemFieldCategoryIDs, categoryIDs...)
-		postFilterMap[es.ItemFieldCategoryID] = filter
+		postFilterMap[es.ItemFieldCategoryIDs] = filter
 		postFilters = append(postFilters, filter)
 	}
 	if len(searchFilter.BrandNames) > 0 {
@@ -107,6 +146,38 @@ func applyAggregationsAndPostFiltersForFacets(search *elastic.SearchService, sea
 		postFilterMap[es.ItemFieldColors] = filter
 		postFilters = append(postFilters, filter)
 	}
+	if len(searchFilter.Platforms) > 0 {
+		var platforms []interface{}
+		for _, gqlPlatform := range searchFilter.Platforms {
+			if platform, err := mapGraphqlPlatformToPlatform(gqlPlatform); err == nil {
+				platforms = append(platforms, platform)
+			}
+		}
+		filter := elastic.NewTermsQuery(es.ItemFieldPlatform, platforms...)
+		postFilterMap[es.ItemFieldPlatform] = filter
+		postFilters = append(postFilters, filter)
+	}
+	if len(searchFilter.ShopIds) > 0 {
+		filter := elastic.NewTermsQuery(es.ItemFieldShopID, interfaceconv.StringArrayToInterfaceArray(searchFilter.ShopIds)...)
+		postFilterMap[es.ItemFieldShopID] = filter
+		postFilters = append(postFilters, filter)
+	}
+	if searchFilter.MinPrice != nil || searchFilter.MaxPrice != nil {
+		filter := elastic.NewRangeQuery(es.ItemFieldPrice)
+		if searchFilter.MinPrice != nil {
+			filter.Gte(*searchFilter.MinPrice)
+		}
+		if searchFilter.MaxPrice != nil {
+			filter.Lte(*searchFilter.MaxPrice)
+		}
+		postFilterMap[es.ItemFieldPrice] = filter
+		postFilters = append(postFilters, filter)
+	}
+	if searchFilter.MinRating != nil {
+		filter := elastic.NewRangeQuery(es.ItemFieldAverageRating).Gte(*searchFilter.MinRating)
+		postFilterMap[es.ItemFieldAverageRating] = filter
+		postFilters = append(postFilters, filter)
+	}
 
 	var metadataFilters []elastic.Query
 	postMetadataFilterMap := make(map[string]elastic.Query)
@@ -133,16 +204,18 @@ func applyAggregationsAndPostFiltersForFacets(search *elastic.SearchService, sea
 
 	search.
 		Aggregation(
-			es.ItemFieldCategoryID,
+			es.ItemFieldCategoryIDs,
 			newFilterAggregationForFacet(
-				es.ItemFieldCategoryID,
-				append(extractFiltersExceptForField(es.ItemFieldCategoryID, postFilterMap), metadataFilters...),
+				es.ItemFieldCategoryIDs,
+				categoryFacetSize,
+				append(extractFiltersExceptForField(es.ItemFieldCategoryIDs, postFilterMap), metadataFilters...),
 			),
 		).
 		Aggregation(
 			es.ItemFieldBrandName,
 			newFilterAggregationForFacet(
 				es.ItemFieldBrandName,
+				defaultFacetSize,
 				append(extractFiltersExceptForField(es.ItemFieldBrandName, postFilterMap), metadataFilters...),
 			),
 		).
@@ -150,42 +223,47 @@ func applyAggregationsAndPostFiltersForFacets(search *elastic.SearchService, sea
 			es.ItemFieldColors,
 			newFilterAggregationForFacet(
 				es.ItemFieldColors,
+				defaultFacetSize,
 				append(extractFiltersExceptForField(es.ItemFieldColors, postFilterMap), metadataFilters...),
 			),
-		)
-
-	allFilters := append(postFilters, metadataFilters...)
-	if len(allFilters) > 0 {
-		search.Aggregation(es.ItemFieldMetadata, elastic.NewFiltersAggregation().
-			Filters(allFilters...).
-			SubAggregation(
-				es.ItemFieldMetadata,
-				elastic.NewNestedAggregation().
-					Path(es.ItemFieldMetadata).
-					SubAggregation(
-						es.MetadataNameFullPath,
-						elastic.NewTermsAggregation().
-							Field(es.MetadataNameFullPath).
-							SubAggregation(
-								es.MetadataValueFullPath,
-								elastic.NewTermsAggregation().Field(es.MetadataValueFullPath),
-							),
-					),
-			))
-	} else {
-		search.Aggregation(es.ItemFieldMetadata, elastic.NewNestedAggregation().
-			Path(es.ItemFieldMetadata).
-			SubAggregation(
-				es.MetadataNameFullPath,
-				elastic.NewTermsAggregation().
-					Field(es.MetadataNameFullPath).
-					SubAggregation(
-						es.MetadataValueFullPath,
-						elastic.NewTermsAggregation().Field(es.MetadataValueFullPath),
-					),
+		).
+		Aggregation(
+			es.ItemFieldPlatform,
+			newFilterAggregationForFacet(
+				es.ItemFieldPlatform,
+				defaultFacetSize,
+				append(extractFiltersExceptForField(es.ItemFieldPlatform, postFilterMap), metadataFilters...),
+			),
+		).
+		Aggregation(
+			es.ItemFieldShopID,
+			newFilterTermsAggregationForFacet(
+				es.ItemFieldShopID,
+				// shop name is aggregated to display along with the id
+				elastic.NewTermsAggregation().Field(es.ItemFieldShopID).Size(defaultFacetSize).
+					SubAggregation(es.ItemFieldShopName, elastic.NewTermsAggregation().Field(es.ItemFieldShopName).Size(1)),
+				append(extractFiltersExceptForField(es.ItemFieldShopID, postFilterMap), metadataFilters...),
 			),
 		)
-	}
+
+	search = applyAggregationsForRangeFacets(search, postFilterMap, metadataFilters)
+
+	search.Aggregation(es.ItemFieldMetadata, elastic.NewFilterAggregation().
+		Filter(elastic.NewBoolQuery().Filter(append(postFilters, metadataFilters...)...)).
+		SubAggregation(
+			es.ItemFieldMetadata,
+			elastic.NewNestedAggregation().
+				Path(es.ItemFieldMetadata).
+				SubAggregation(
+					es.MetadataNameFullPath,
+					elastic.NewTermsAggregation().
+						Field(es.MetadataNameFullPath).
+						SubAggregation(
+							es.MetadataValueFullPath,
+							elastic.NewTermsAggregation().Field(es.MetadataValueFullPath),
+						),
+				),
+		))
 
 	for _, metadata := range searchFilter.Metadata {
 		if len(metadata.Values) == 0 {
@@ -204,52 +282,45 @@ func applyAggregationsAndPostFiltersForFacets(search *elastic.SearchService, sea
 
 // newFilterAggregationForFacet initializes the aggregation with filters applied to the other fields
 //  to get the correct facet count for the given field
-func newFilterAggregationForFacet(field string, filters []elastic.Query) elastic.Aggregation {
-	// We can't use filters aggregation when 0 filters
-	if len(filters) == 0 {
-		return elastic.NewTermsAggregation().Field(field).Size(30)
-	}
-	return elastic.NewFiltersAggregation().
-		Filters(filters...).
-		SubAggregation(field, elastic.NewTermsAggregation().Field(field).Size(30))
+func newFilterAggregationForFacet(field string, size int, filters []elastic.Query) elastic.Aggregation {
+	return newFilterTermsAggregationForFacet(field, elastic.NewTermsAggregation().Field(field).Size(size), filters)
+}
+
+// newFilterTermsAggregationForFacet is the same as newFilterAggregationForFacet but accepts customized terms aggregation
+// Filter aggregation with a bool query is used instead of filters aggregation
+// since filters aggregation creates a bucket per filter rather than applying all of them.
+func newFilterTermsAggregationForFacet(field string, termsAgg *elastic.TermsAggregation, filters []elastic.Query) elastic.Aggregation {
+	return elastic.NewFilterAggregation().
+		Filter(elastic.NewBoolQuery().Filter(filters...)).
+		SubAggregation(field, termsAgg)
 }
 
 func newFilterMetadataAggregationForFacet(metadataName string, filters []elastic.Query) elastic.Aggregation {
 	filters = append(filters, elastic.NewNestedQuery(es.ItemFieldMetadata, elastic.NewTermQuery(es.MetadataNameFullPath, metadataName)))
-	return elastic.NewFiltersAggregation().
-		Filters(filters...).
+	return elastic.NewFilterAggregation().
+		Filter(elastic.NewBoolQuery().Filter(filters...)).
 		SubAggregation(metadataName, elastic.NewNestedAggregation().
 			Path(es.ItemFieldMetadata).
 			SubAggregation(metadataName, elastic.NewTermsAggregation().Field(es.MetadataValueFullPath).Size(30)))
 }
 
-func (s *searchClient) mapAggregationToFacets(ctx context.Context, agg elastic.Aggregations, postFilterMap map[string]elastic.Query, postMetadataFilterMap map[string]elastic.Query) []*Facet {
+func (s *searchClient) mapAggregationToFacets(ctx context.Context, agg elastic.Aggregations, postMetadataFilterMap map[string]elastic.Query) []*Facet {
 	var facets []*Facet
 
-	if result, ok := agg.Terms(es.ItemFieldCategoryID); ok {
-		isFilterAggregation := len(extractFiltersExceptForField(es.ItemFieldCategoryID, postFilterMap)) > 0 || len(postMetadataFilterMap) > 0
-		if isFilterAggregation {
-			result, _ = result.Buckets[0].Terms(es.ItemFieldCategoryID)
-		}
+	if filterResult, ok := agg.Filter(es.ItemFieldCategoryIDs); ok {
+		result, _ := filterResult.Terms(es.ItemFieldCategoryIDs)
 		itemCategories, err := s.dbClient.GetAllItemCategoriesWithParent(ctx)
 		if err != nil {
 			logging.Logger(ctx).Error("failed to get item categories", zap.Error(err))
 		}
-		idNameMap := make(map[string]string)
-		for _, itemCategory := range itemCategories {
-			idNameMap[itemCategory.ID] = strings.Join(itemCategory.CategoryNames(), " > ")
-		}
-		facet := newFacetFromBucketKeyItems(result, FacetTypeCategoryIDs, idNameMap)
+		facet := newCategoryFacet(result, itemCategories)
 		if facet.IsValid() {
 			facets = append(facets, facet)
 		}
 	}
 
-	if result, ok := agg.Terms(es.ItemFieldBrandName); ok {
-		isFilterAggregation := len(extractFiltersExceptForField(es.ItemFieldBrandName, postFilterMap)) > 0 || len(postMetadataFilterMap) > 0
-		if isFilterAggregation {
-			result, _ = result.Buckets[0].Terms(es.ItemFieldBrandName)
-		}
+	if filterResult, ok := agg.Filter(es.ItemFieldBrandName); ok {
+		result, _ := filterResult.Terms(es.ItemFieldBrandName)
 		idNameMap := make(map[string]string)
 		for _, bucket := range result.Buckets {
 			keyStr, ok := bucket.Key.(string)
@@ -264,11 +335,8 @@ func (s *searchClient) mapAggregationToFacets(ctx context.Context, agg elastic.A
 		}
 	}
 
-	if result, ok := agg.Terms(es.ItemFieldColors); ok {
-		isFilterAggregation := len(extractFiltersExceptForField(es.ItemFieldColors, postFilterMap)) > 0 || len(postMetadataFilterMap) > 0
-		if isFilterAggregation {
-			result, _ = result.Buckets[0].Terms(es.ItemFieldColors)
-		}
+	if filterResult, ok := agg.Filter(es.ItemFieldColors); ok {
+		result, _ := filterResult.Terms(es.ItemFieldColors)
 		idNameMap := make(map[string]string)
 		for _, bucket := range result.Buckets {
 			keyStr, ok := bucket.Key.(string)
@@ -284,11 +352,8 @@ func (s *searchClient) mapAggregationToFacets(ctx context.Context, agg elastic.A
 	}
 
 	var metadataFacets []*Facet
-	if result, ok := agg.Terms(es.ItemFieldMetadata); ok {
-		isFilterAggregation := len(postFilterMap) > 0 || len(postMetadataFilterMap) > 0
-		if isFilterAggregation {
-			result, _ = result.Buckets[0].Terms(es.ItemFieldMetadata)
-		}
+	if filterResult, ok := agg.Filter(es.ItemFieldMetadata); ok {
+		result, _ := filterResult.Terms(es.ItemFieldMetadata)
 		result, _ = result.Terms(es.MetadataNameFullPath)
 
 		for _, bucket := range result.Buckets {
@@ -331,8 +396,8 @@ func (s *searchClient) mapAggregationToFacets(ctx context.Context, agg elastic.A
 	}
 
 	for metadataName := range postMetadataFilterMap {
-		if result, ok := agg.Terms(metadataName); ok {
-			result, _ := result.Buckets[0].Terms(metadataName)
+		if filterResult, ok := agg.Filter(metadataName); ok {
+			result, _ := filterResult.Terms(metadataName)
 			result, _ = result.Terms(metadataName)
 
 			facetValues := make([]*FacetValue, 0, len(result.Buckets))
@@ -362,6 +427,38 @@ func (s *searchClient) mapAggregationToFacets(ctx context.Context, agg elastic.A
 		}
 	}
 
+	if filterResult, ok := agg.Filter(es.ItemFieldPlatform); ok {
+		result, _ := filterResult.Terms(es.ItemFieldPlatform)
+		facet := newFacetFromBucketKeyItems(result, FacetTypePlatforms, platformNameMap)
+		if facet.IsValid() {
+			facets = append(facets, facet)
+		}
+	}
+
+	if filterResult, ok := agg.Filter(es.ItemFieldShopID); ok {
+		result, _ := filterResult.Terms(es.ItemFieldShopID)
+		idNameMap := make(map[string]string)
+		for _, bucket := range result.Buckets {
+			keyStr, ok := bucket.Key.(string)
+			if !ok {
+				continue
+			}
+			if shopNameResult, ok := bucket.Terms(es.ItemFieldShopName); ok && len(shopNameResult.Buckets) > 0 {
+				idNameMap[keyStr], _ = shopNameResult.Buckets[0].Key.(string)
+			}
+		}
+		facet := newFacetFromBucketKeyItems(result, FacetTypeShopIDs, idNameMap)
+		if facet.IsValid() {
+			facets = append(facets, facet)
+		}
+	}
+
+	rangeFacets, err := mapAggregationToRangeFacets(agg)
+	if err != nil {
+		logging.Logger(ctx).Error("failed to map aggregation to range facets", zap.Error(err))
+	}
+	facets = append(facets, rangeFacets...)
+
 	sort.Slice(metadataFacets, func(i, j int) bool {
 		iOrder := es.MetadataNameSortOrderMap[metadataFacets[i].Title]
 		jOrder := es.MetadataNameSortOrderMap[metadataFacets[j].Title]
@@ -0,0 +1,214 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"github.com/olivere/elastic/v7"
+	"go.uber.org/zap"
+)
+
+const (
+	groupCountAggregationName  = "group_count"
+	sameGroupItemsInnerHitName = "same_group_items"
+	maxSameGroupItems          = 10
+	// counts below the threshold are expected to be close to accurate
+	// https://www.elastic.co/guide/en/elasticsearch/reference/7.14/search-aggregations-metrics-cardinality-aggregation.html#_precision_control
+	groupCountPrecisionThreshold = 40000
+
+	// groupPagingBatchSizeRatio is the ratio of the number of hits fetched in a batch to the page size
+	// A batch has more hits than the page size since the hits other than the top hit of each group are skipped.
+	groupPagingBatchSizeRatio = 3
+	// maxGroupPagingBatches is the max number of batches fetched for a page
+	// The page can have fewer groups than the page size when the limit is reached, but the next page is still fetched from the last batch.
+	maxGroupPagingBatches = 5
+)
+
+// applyGroupCollapse collapses items in the same group into the top item
+func applyGroupCollapse(search *elastic.SearchService) *elastic.SearchService {
+	return search.Collapse(
+		elastic.NewCollapseBuilder(es.ItemFieldGroupID).
+			InnerHit(
+				elastic.NewInnerHit().
+					Name(sameGroupItemsInnerHitName).
+					Size(maxSameGroupItems).
+					SortBy(elastic.NewFieldSort(es.ItemFieldPrice).Asc()),
+			),
+	)
+}
+
+// applyGroupCount aggregates the number of groups matched to the query and given post filters
+// It's applied to both page number and cursor based pagination to return the same total count.
+func applyGroupCount(search *elastic.SearchService, postFilters []elastic.Query) *elastic.SearchService {
+	cardinalityAgg := elastic.NewCardinalityAggregation().
+		Field(es.ItemFieldGroupID).
+		PrecisionThreshold(groupCountPrecisionThreshold)
+	// aggregations are not affected by post filter, so the same filter needs to be applied
+	if len(postFilters) == 0 {
+		return search.Aggregation(groupCountAggregationName, cardinalityAgg)
+	}
+	return search.Aggregation(
+		groupCountAggregationName,
+		elastic.NewFilterAggregation().
+			Filter(elastic.NewBoolQuery().Filter(postFilters...)).
+			SubAggregation(groupCountAggregationName, cardinalityAgg),
+	)
+}
+
+// searchGroupsAfter fetches the page of groups after the given sort values in the point in time
+// ES 7 doesn't support collapse with search_after, so hits are fetched without collapse in batches with search_after,
+// then the hits of the groups in the batch are collapsed with the same query and sort to get the top hit of each group.
+// A group is added to the page only when its top hit is in the batch, otherwise the group is already in the previous pages.
+// firstSearch is used for the first batch to get the aggregations, and newSearch builds the search for the following batches
+// and the top hits with the same query, post filter and sort, the given filters must not affect the score.
+// The response of the first batch is returned with the top hits in the page,
+// and the sort values to fetch the next page are returned together, which are nil when there is no next page.
+func searchGroupsAfter(
+	ctx context.Context,
+	firstSearch *elastic.SearchService,
+	newSearch func(filters ...elastic.Query) *elastic.SearchService,
+	searchAfter []interface{},
+	pageSize int,
+) (*elastic.SearchResult, []interface{}, error) {
+	batchSize := pageSize * groupPagingBatchSizeRatio
+	var firstResp *elastic.SearchResult
+	var pageHits []*elastic.SearchHit
+	for i := 0; i < maxGroupPagingBatches; i++ {
+		search := firstSearch
+		if i > 0 {
+			search = newSearch()
+		}
+		// only group id is needed to get the top hits, so highlight is skipped
+		search = search.
+			Highlight(nil).
+			FetchSourceContext(elastic.NewFetchSourceContext(true).Include(es.ItemFieldGroupID)).
+			Size(batchSize)
+		if len(searchAfter) > 0 {
+			search = search.SearchAfter(searchAfter...)
+		}
+		// the error is returned as is to be handled by the status code
+		resp, err := search.Do(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if firstResp == nil {
+			firstResp = resp
+		}
+		batchHits := resp.Hits.Hits
+		if len(batchHits) == 0 {
+			searchAfter = nil
+			break
+		}
+
+		topHitsResp, err := applyGroupCollapse(newSearch(newGroupIDsFilter(ctx, batchHits))).
+			Size(len(batchHits)).
+			Do(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		var hasRestInBatch bool
+		pageHits, searchAfter, hasRestInBatch = appendTopHitsInBatch(pageHits, batchHits, topHitsResp.Hits.Hits, pageSize)
+		if hasRestInBatch {
+			break
+		}
+		if len(batchHits) < batchSize {
+			// all hits after the cursor are fetched
+			searchAfter = nil
+			break
+		}
+		if len(pageHits) == pageSize {
+			break
+		}
+	}
+
+	firstResp.Hits.Hits = pageHits
+	return firstResp, searchAfter, nil
+}
+
+// newGroupIDsFilter builds the filter for the groups of the given hits
+func newGroupIDsFilter(ctx context.Context, hits []*elastic.SearchHit) elastic.Query {
+	var groupIDs []interface{}
+	groupIDMap := make(map[string]bool)
+	for _, hit := range hits {
+		var esItem es.Item
+		if err := json.Unmarshal(hit.Source, &esItem); err != nil {
+			logging.Logger(ctx).Error("Failed to unmarshal hit.Source into es.Item", zap.String("source", string(hit.Source)))
+			continue
+		}
+		if groupIDMap[esItem.GroupID] {
+			continue
+		}
+		groupIDMap[esItem.GroupID] = true
+		groupIDs = append(groupIDs, esItem.GroupID)
+	}
+	return elastic.NewTermsQuery(es.ItemFieldGroupID, groupIDs...)
+}
+
+// appendTopHitsInBatch appends the top hits in the batch to the page hits until the page is filled
+// It returns the sort values of the last hit added to the page when the page is filled before the rest of the top hits in the batch,
+// otherwise the sort values of the last hit in the batch since the rest of hits in the batch are not the top hits.
+func appendTopHitsInBatch(pageHits, batchHits, topHits []*elastic.SearchHit, pageSize int) ([]*elastic.SearchHit, []interface{}, bool) {
+	batchHitMap := make(map[string]*elastic.SearchHit, len(batchHits))
+	for _, hit := range batchHits {
+		batchHitMap[hit.Id] = hit
+	}
+
+	var lastSort []interface{}
+	for _, topHit := range topHits {
+		batchHit, ok := batchHitMap[topHit.Id]
+		// the top hit is before the batch, so the group is already in the previous pages
+		if !ok {
+			continue
+		}
+		if len(pageHits) == pageSize {
+			return pageHits, lastSort, true
+		}
+		pageHits = append(pageHits, topHit)
+		lastSort = batchHit.Sort
+	}
+	return pageHits, batchHits[len(batchHits)-1].Sort, false
+}
+
+// getGroupCount gets the number of groups aggregated by applyGroupCount
+func getGroupCount(agg elastic.Aggregations, postFilters []elastic.Query) (int, bool) {
+	if len(postFilters) > 0 {
+		filterResult, ok := agg.Filter(groupCountAggregationName)
+		if !ok {
+			return 0, false
+		}
+		agg = filterResult.Aggregations
+	}
+	result, ok := agg.Cardinality(groupCountAggregationName)
+	if !ok || result.Value == nil {
+		return 0, false
+	}
+	return int(*result.Value), true
+}
+
+// mapElasticsearchHitsToItemsWithSameGroupItems maps collapsed hits to items with the other items in the same group
+func mapElasticsearchHitsToItemsWithSameGroupItems(ctx context.Context, hits []*elastic.SearchHit) []*Item {
+	items := make([]*Item, 0, len(hits))
+	for _, hit := range hits {
+		var esItem es.Item
+		if err := json.Unmarshal(hit.Source, &esItem); err != nil {
+			logging.Logger(ctx).Error("Failed to unmarshal hit.Source into es.Item", zap.String("source", string(hit.Source)))
+			continue
+		}
+
+		item := &Item{Item: &esItem, Highlight: mapElasticsearchHighlightToHighlight(hit.Highlight)}
+		if innerHits, ok := hit.InnerHits[sameGroupItemsInnerHitName]; ok && innerHits.Hits != nil {
+			for _, sameGroupItem := range mapElasticsearchHitsToItems(ctx, innerHits.Hits.Hits) {
+				// inner hits include the collapsed item itself
+				if sameGroupItem.ID == esItem.ID {
+					continue
+				}
+				item.SameGroupItems = append(item.SameGroupItems, sameGroupItem)
+			}
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
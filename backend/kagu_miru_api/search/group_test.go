@@ -0,0 +1,258 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/olivere/elastic/v7"
+)
+
+func newTestSortedHit(id string, score float64) *elastic.SearchHit {
+	return &elastic.SearchHit{Id: id, Sort: []interface{}{score, id}}
+}
+
+func hitIDs(hits []*elastic.SearchHit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.Id)
+	}
+	return ids
+}
+
+// sendTestSearch sends the search built by newSearch to the test server and returns the request body
+func sendTestSearch(t *testing.T, newSearch func(search *elastic.SearchService) *elastic.SearchService) string {
+	t.Helper()
+
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"hits":{"hits":[]}}`))
+	}))
+	t.Cleanup(srv.Close)
+	esClient, err := elastic.NewSimpleClient(elastic.SetURL(srv.URL))
+	if err != nil {
+		t.Fatalf("elastic.NewSimpleClient() error = %v", err)
+	}
+	if _, err := newSearch(esClient.Search("items")).Do(context.Background()); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	return string(bytes.TrimSpace(body))
+}
+
+func newTestHit(t *testing.T, item *es.Item) *elastic.SearchHit {
+	t.Helper()
+
+	source, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	return &elastic.SearchHit{Id: item.ID, Source: source}
+}
+
+func Test_applyGroupCollapse(t *testing.T) {
+	t.Parallel()
+
+	want := `{"collapse":{"field":"group_id","inner_hits":[{"name":"same_group_items","size":10,"sort":[{"price":{"order":"asc"}}]}]}}`
+	got := sendTestSearch(t, applyGroupCollapse)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("applyGroupCollapse(), (-want +got): %s", diff)
+	}
+}
+
+func Test_applyGroupCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		postFilters []elastic.Query
+		want        string
+	}{
+		{
+			name: "counts groups",
+			want: `{"aggregations":{"group_count":{"cardinality":{"field":"group_id","precision_threshold":40000}}}}`,
+		},
+		{
+			name:        "counts groups filtered by the post filters",
+			postFilters: []elastic.Query{elastic.NewTermsQuery(es.ItemFieldBrandName, "brand")},
+			want: `{"aggregations":{"group_count":{"aggregations":{"group_count":{"cardinality":{"field":"group_id","precision_threshold":40000}}},` +
+				`"filter":{"bool":{"filter":{"terms":{"brand_name":["brand"]}}}}}}}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := sendTestSearch(t, func(search *elastic.SearchService) *elastic.SearchService {
+				return applyGroupCount(search, tt.postFilters)
+			})
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("applyGroupCount(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_getGroupCount(t *testing.T) {
+	t.Parallel()
+
+	postFilters := []elastic.Query{elastic.NewTermsQuery(es.ItemFieldBrandName, "brand")}
+	tests := []struct {
+		name         string
+		aggregations string
+		postFilters  []elastic.Query
+		want         int
+		wantOK       bool
+	}{
+		{
+			name:         "gets the group count",
+			aggregations: `{"group_count": {"value": 12}}`,
+			want:         12,
+			wantOK:       true,
+		},
+		{
+			name:         "gets the group count wrapped with filter aggregation",
+			aggregations: `{"group_count": {"doc_count": 20, "group_count": {"value": 5}}}`,
+			postFilters:  postFilters,
+			want:         5,
+			wantOK:       true,
+		},
+		{
+			name:         "not found without the aggregation",
+			aggregations: `{}`,
+			postFilters:  postFilters,
+		},
+		{
+			name:         "not found without the value",
+			aggregations: `{"group_count": {"value": null}}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var agg elastic.Aggregations
+			if err := json.Unmarshal([]byte(tt.aggregations), &agg); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			got, gotOK := getGroupCount(agg, tt.postFilters)
+			if got != tt.want || gotOK != tt.wantOK {
+				t.Errorf("getGroupCount() = (%d, %v), want (%d, %v)", got, gotOK, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func Test_mapElasticsearchHitsToItemsWithSameGroupItems(t *testing.T) {
+	t.Parallel()
+
+	a1 := &es.Item{ID: "a1", GroupID: "a", Name: "ソファ", Price: 20000}
+	a2 := &es.Item{ID: "a2", GroupID: "a", Name: "ソファ", Price: 10000}
+	a3 := &es.Item{ID: "a3", GroupID: "a", Name: "ソファ", Price: 30000}
+	b1 := &es.Item{ID: "b1", GroupID: "b", Name: "ベッド", Price: 50000}
+
+	collapsedHit := newTestHit(t, a1)
+	collapsedHit.Highlight = elastic.SearchHitHighlight{es.ItemFieldName: {"<em>ソファ</em>"}}
+	collapsedHit.InnerHits = map[string]*elastic.SearchHitInnerHits{
+		sameGroupItemsInnerHitName: {Hits: &elastic.SearchHits{Hits: []*elastic.SearchHit{
+			newTestHit(t, a2),
+			newTestHit(t, a1),
+			newTestHit(t, a3),
+		}}},
+	}
+	hitWithoutInnerHits := newTestHit(t, b1)
+	invalidHit := &elastic.SearchHit{Id: "invalid", Source: json.RawMessage(`invalid`)}
+
+	want := []*Item{
+		{
+			Item:           a1,
+			SameGroupItems: []*es.Item{a2, a3},
+			Highlight:      &Highlight{Name: "<em>ソファ</em>"},
+		},
+		{Item: b1},
+	}
+	got := mapElasticsearchHitsToItemsWithSameGroupItems(context.Background(), []*elastic.SearchHit{collapsedHit, invalidHit, hitWithoutInnerHits})
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("mapElasticsearchHitsToItemsWithSameGroupItems(), (-want +got): %s", diff)
+	}
+}
+
+func Test_appendTopHitsInBatch(t *testing.T) {
+	t.Parallel()
+
+	// a and b are the groups first appearing in the batch, and d is the group already in the previous pages
+	batchHits := []*elastic.SearchHit{
+		newTestSortedHit("a1", 5),
+		newTestSortedHit("d1", 4),
+		newTestSortedHit("b1", 3),
+		newTestSortedHit("a2", 2),
+	}
+	topHits := []*elastic.SearchHit{
+		newTestSortedHit("d0", 6),
+		newTestSortedHit("a1", 5),
+		newTestSortedHit("b1", 3),
+	}
+
+	tests := []struct {
+		name               string
+		pageHits           []*elastic.SearchHit
+		pageSize           int
+		wantPageHitIDs     []string
+		wantSearchAfter    []interface{}
+		wantHasRestInBatch bool
+	}{
+		{
+			name:            "appends top hits in the batch and skips groups in the previous pages",
+			pageSize:        10,
+			wantPageHitIDs:  []string{"a1", "b1"},
+			wantSearchAfter: []interface{}{2.0, "a2"},
+		},
+		{
+			name:            "continues from the last hit in the batch when the page is filled with the last top hit",
+			pageSize:        2,
+			wantPageHitIDs:  []string{"a1", "b1"},
+			wantSearchAfter: []interface{}{2.0, "a2"},
+		},
+		{
+			name:               "continues from the last hit in the page when the page is filled before the rest of top hits",
+			pageSize:           1,
+			wantPageHitIDs:     []string{"a1"},
+			wantSearchAfter:    []interface{}{5.0, "a1"},
+			wantHasRestInBatch: true,
+		},
+		{
+			name:               "appends to the hits of the previous batch",
+			pageHits:           []*elastic.SearchHit{newTestSortedHit("c1", 7)},
+			pageSize:           2,
+			wantPageHitIDs:     []string{"c1", "a1"},
+			wantSearchAfter:    []interface{}{5.0, "a1"},
+			wantHasRestInBatch: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotPageHits, gotSearchAfter, gotHasRestInBatch := appendTopHitsInBatch(tt.pageHits, batchHits, topHits, tt.pageSize)
+			if diff := cmp.Diff(tt.wantPageHitIDs, hitIDs(gotPageHits)); diff != "" {
+				t.Errorf("appendTopHitsInBatch() page hits, (-want +got): %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantSearchAfter, gotSearchAfter); diff != "" {
+				t.Errorf("appendTopHitsInBatch() search after, (-want +got): %s", diff)
+			}
+			if gotHasRestInBatch != tt.wantHasRestInBatch {
+				t.Errorf("appendTopHitsInBatch() has rest in batch = %v, want %v", gotHasRestInBatch, tt.wantHasRestInBatch)
+			}
+		})
+	}
+}
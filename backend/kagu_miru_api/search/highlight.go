@@ -0,0 +1,89 @@
+package search
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/olivere/elastic/v7"
+)
+
+const (
+	highlightPreTag  = "<em>"
+	highlightPostTag = "</em>"
+	// descriptionFragmentSize is the approximate max number of characters of description snippet
+	descriptionFragmentSize = 200
+)
+
+// sentenceDelimiterRegex matches delimiters of sentences in descriptions
+// Descriptions from shopping sites often use line breaks or symbols instead of punctuation.
+// Since highlighted texts are html escaped, <br> tag is matched in the escaped form.
+var sentenceDelimiterRegex = regexp.MustCompile(`[。！？!?\n]+|&lt;br\s*/?&gt;|[■□◆◇●○★☆※]`)
+
+// Highlight has the highlighted texts matched to the query
+// Matched terms are surrounded by <em> and </em>, and the other texts are html escaped.
+type Highlight struct {
+	Name string
+	// Description is the sentence best matched to the query in the description
+	Description string
+}
+
+// applyHighlight highlights name and description
+// Since name and description are analyzed with kuromoji analyzer, the terms are highlighted in the same way as the query is matched.
+func applyHighlight(search *elastic.SearchService) *elastic.SearchService {
+	return search.Highlight(
+		elastic.NewHighlight().
+			HighlighterType("unified").
+			Encoder("html").
+			PreTags(highlightPreTag).
+			PostTags(highlightPostTag).
+			// boundary scanner is applied only to description since whole name is returned
+			BoundaryScannerType("sentence").
+			BoundaryScannerLocale("ja-JP").
+			Order("score").
+			Fields(
+				// whole name is returned
+				elastic.NewHighlighterField(es.ItemFieldName).NumOfFragments(0),
+				elastic.NewHighlighterField(es.ItemFieldDescription).
+					NumOfFragments(1).
+					FragmentSize(descriptionFragmentSize),
+			),
+	)
+}
+
+// mapElasticsearchHighlightToHighlight maps highlight of the hit
+// nil is returned when neither name nor description is highlighted.
+func mapElasticsearchHighlightToHighlight(highlight elastic.SearchHitHighlight) *Highlight {
+	if len(highlight) == 0 {
+		return nil
+	}
+	h := &Highlight{}
+	if fragments := highlight[es.ItemFieldName]; len(fragments) > 0 {
+		h.Name = fragments[0]
+	}
+	if fragments := highlight[es.ItemFieldDescription]; len(fragments) > 0 {
+		h.Description = extractBestSentence(fragments[0])
+	}
+	if h.Name == "" && h.Description == "" {
+		return nil
+	}
+	return h
+}
+
+// extractBestSentence extracts the sentence containing the most highlighted terms from the fragment
+// The fragment can contain multiple sentences since the sentence boundary scanner doesn't recognize delimiters like line breaks.
+func extractBestSentence(fragment string) string {
+	var bestSentence string
+	maxHighlightCount := -1
+	for _, sentence := range sentenceDelimiterRegex.Split(fragment, -1) {
+		sentence = strings.TrimSpace(sentence)
+		if sentence == "" {
+			continue
+		}
+		if count := strings.Count(sentence, highlightPreTag); count > maxHighlightCount {
+			bestSentence = sentence
+			maxHighlightCount = count
+		}
+	}
+	return bestSentence
+}
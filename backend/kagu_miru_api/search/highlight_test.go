@@ -0,0 +1,39 @@
+package search
+
+import "testing"
+
+func Test_extractBestSentence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fragment string
+		want     string
+	}{
+		{
+			name:     "extracts sentence with the most highlighted terms",
+			fragment: "北欧風のデザイン。<em>ソファ</em>は<em>3人掛け</em>です。<em>ソファ</em>カバー付き",
+			want:     "<em>ソファ</em>は<em>3人掛け</em>です",
+		},
+		{
+			name:     "splits by line breaks and symbols",
+			fragment: "送料無料&lt;br&gt;■サイズ: 幅180cm\n■素材: <em>天然木</em>",
+			want:     "素材: <em>天然木</em>",
+		},
+		{
+			name:     "returns the first sentence when nothing is highlighted",
+			fragment: "  北欧風のデザイン。送料無料",
+			want:     "北欧風のデザイン",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := extractBestSentence(tt.fragment); got != tt.want {
+				t.Errorf("extractBestSentence() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
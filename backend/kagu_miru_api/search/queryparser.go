@@ -0,0 +1,324 @@
+package search
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+	"golang.org/x/text/unicode/norm"
+)
+
+// dimensionTolerance is the tolerance in cm applied to a dimension without 以下/以上
+// e.g. "幅180" is interpreted as width between 170cm and 190cm
+const dimensionTolerance = 10
+
+type InterpretedQueryPartType int
+
+const (
+	InterpretedQueryPartTypeColor InterpretedQueryPartType = iota + 1
+	InterpretedQueryPartTypeBrand
+	InterpretedQueryPartTypePrice
+	InterpretedQueryPartTypeDimension
+)
+
+// InterpretedQueryPart is a part of the query interpreted as a filter
+type InterpretedQueryPart struct {
+	// Text is the substring of the original query before normalization, so that it can be removed from the query
+	Text string
+	Type InterpretedQueryPartType
+}
+
+type parsedQuery struct {
+	// Query is the remaining text which is not interpreted as filters
+	Query string
+	// Filter has only the filters interpreted from the query
+	Filter *gqlmodel.SearchFilter
+	Parts  []*InterpretedQueryPart
+}
+
+var (
+	// e.g. 1万〜3万円, 10000~30000円
+	priceRangeRegex = regexp.MustCompile(`(\d+(?:\.\d+)?)(万|千)?円?\s*[〜~]\s*(\d+(?:\.\d+)?)(万円|千円|円|万)`)
+	// e.g. 3万円以下, 5000円以上
+	priceRegex = regexp.MustCompile(`(\d+(?:\.\d+)?)(万円|千円|円|万)\s*(以下|以内|未満|まで|以上|から)`)
+	// e.g. 幅180, 奥行45cm以下, H70cm以上
+	// w, d and h must be separated not to match model numbers e.g. ABC-H120, see isSeparateTerm
+	dimensionRegex = regexp.MustCompile(`(?i)(横幅|幅|奥行き|奥行|高さ|[wdh])\s*(\d+(?:\.\d+)?)(mm|cm|センチ|m\b)?(?:\s*(以下|以内|未満|まで|以上))?`)
+)
+
+var colorAliasMap = map[string]gqlmodel.ItemColor{
+	"白":   gqlmodel.ItemColorWhite,
+	"白色":  gqlmodel.ItemColorWhite,
+	"黄色":  gqlmodel.ItemColorYellow,
+	"赤":   gqlmodel.ItemColorRed,
+	"赤色":  gqlmodel.ItemColorRed,
+	"灰色":  gqlmodel.ItemColorGray,
+	"紫":   gqlmodel.ItemColorPurple,
+	"紫色":  gqlmodel.ItemColorPurple,
+	"茶":   gqlmodel.ItemColorBrown,
+	"茶色":  gqlmodel.ItemColorBrown,
+	"緑":   gqlmodel.ItemColorGreen,
+	"緑色":  gqlmodel.ItemColorGreen,
+	"青":   gqlmodel.ItemColorBlue,
+	"青色":  gqlmodel.ItemColorBlue,
+	"黒":   gqlmodel.ItemColorBlack,
+	"黒色":  gqlmodel.ItemColorBlack,
+	"紺":   gqlmodel.ItemColorNavy,
+	"紺色":  gqlmodel.ItemColorNavy,
+	"金":   gqlmodel.ItemColorGold,
+	"金色":  gqlmodel.ItemColorGold,
+	"銀":   gqlmodel.ItemColorSilver,
+	"銀色":  gqlmodel.ItemColorSilver,
+	"グレイ": gqlmodel.ItemColorGray,
+}
+
+// colorNameMap maps color name used in items and its aliases to the color
+var colorNameMap = func() map[string]gqlmodel.ItemColor {
+	m := make(map[string]gqlmodel.ItemColor)
+	for _, color := range gqlmodel.AllItemColor {
+		if name := mapGraphqlItemColorToSearchItemColor(color); name != "" {
+			m[name] = color
+		}
+	}
+	for alias, color := range colorAliasMap {
+		m[alias] = color
+	}
+	return m
+}()
+
+// parseQuery extracts colors, prices, dimensions and brand names from the query
+// brandNameMap must be keyed by lower cased brand name
+func parseQuery(query string, brandNameMap map[string]string) *parsedQuery {
+	q := newNormalizedQuery(query)
+	filter := &gqlmodel.SearchFilter{}
+	var parts []*InterpretedQueryPart
+
+	for _, loc := range priceRangeRegex.FindAllStringSubmatchIndex(q.text, -1) {
+		match := submatches(q.text, loc)
+		minUnit := match[2]
+		// "1〜3万円" means 1万円 to 3万円
+		if minUnit == "" {
+			minUnit = match[4]
+		}
+		minPrice, ok := parsePrice(match[1], minUnit)
+		if !ok {
+			continue
+		}
+		maxPrice, ok := parsePrice(match[3], match[4])
+		if !ok {
+			continue
+		}
+		filter.MinPrice = &minPrice
+		filter.MaxPrice = &maxPrice
+		parts = append(parts, &InterpretedQueryPart{Text: q.originalText(loc[0], loc[1]), Type: InterpretedQueryPartTypePrice})
+		q.mask(loc[0], loc[1])
+	}
+
+	for _, loc := range priceRegex.FindAllStringSubmatchIndex(q.text, -1) {
+		match := submatches(q.text, loc)
+		price, ok := parsePrice(match[1], match[2])
+		if !ok {
+			continue
+		}
+		switch match[3] {
+		case "以上", "から":
+			filter.MinPrice = &price
+		case "未満":
+			price--
+			filter.MaxPrice = &price
+		default:
+			filter.MaxPrice = &price
+		}
+		parts = append(parts, &InterpretedQueryPart{Text: q.originalText(loc[0], loc[1]), Type: InterpretedQueryPartTypePrice})
+		q.mask(loc[0], loc[1])
+	}
+
+	for _, loc := range dimensionRegex.FindAllStringSubmatchIndex(q.text, -1) {
+		match := submatches(q.text, loc)
+		if len(match[1]) == 1 && !isSeparateTerm(q.text, loc[0], loc[1]) {
+			continue
+		}
+		length, ok := parseLengthInCentimeters(match[2], match[3])
+		if !ok {
+			continue
+		}
+		var minLength, maxLength *int
+		switch match[4] {
+		case "以上":
+			minLength = &length
+		case "以下", "以内", "まで":
+			maxLength = &length
+		case "未満":
+			l := length - 1
+			maxLength = &l
+		default:
+			lower, upper := length-dimensionTolerance, length+dimensionTolerance
+			if lower < 0 {
+				lower = 0
+			}
+			minLength, maxLength = &lower, &upper
+		}
+		switch strings.ToLower(match[1]) {
+		case "横幅", "幅", "w":
+			filter.MinWidth, filter.MaxWidth = minLength, maxLength
+		case "奥行き", "奥行", "d":
+			filter.MinDepth, filter.MaxDepth = minLength, maxLength
+		case "高さ", "h":
+			filter.MinHeight, filter.MaxHeight = minLength, maxLength
+		}
+		parts = append(parts, &InterpretedQueryPart{Text: q.originalText(loc[0], loc[1]), Type: InterpretedQueryPartTypeDimension})
+		q.mask(loc[0], loc[1])
+	}
+
+	var remainingTerms []string
+	for _, loc := range termRegex.FindAllStringIndex(q.text, -1) {
+		term := q.text[loc[0]:loc[1]]
+		if color, ok := colorNameMap[term]; ok {
+			filter.Colors = append(filter.Colors, color)
+			parts = append(parts, &InterpretedQueryPart{Text: q.originalText(loc[0], loc[1]), Type: InterpretedQueryPartTypeColor})
+			continue
+		}
+		if brandName, ok := brandNameMap[strings.ToLower(term)]; ok {
+			filter.BrandNames = append(filter.BrandNames, brandName)
+			parts = append(parts, &InterpretedQueryPart{Text: q.originalText(loc[0], loc[1]), Type: InterpretedQueryPartTypeBrand})
+			continue
+		}
+		remainingTerms = append(remainingTerms, term)
+	}
+
+	return &parsedQuery{
+		Query:  strings.Join(remainingTerms, " "),
+		Filter: filter,
+		Parts:  parts,
+	}
+}
+
+// termRegex matches the terms separated by spaces, full-width spaces are already normalized to spaces
+var termRegex = regexp.MustCompile(`\S+`)
+
+// normalizedQuery is the NFKC normalized query which keeps the mapping to the original query
+// The interpreted parts are masked with spaces instead of being removed, so the offsets don't change.
+type normalizedQuery struct {
+	original string
+	text     string
+	// originalStarts and originalEnds are the range in the original query of each byte in text
+	originalStarts []int
+	originalEnds   []int
+}
+
+func newNormalizedQuery(query string) *normalizedQuery {
+	q := &normalizedQuery{original: query}
+	var b strings.Builder
+	var iter norm.Iter
+	iter.InitString(norm.NFKC, query)
+	for !iter.Done() {
+		start := iter.Pos()
+		segment := iter.Next()
+		end := iter.Pos()
+		for range segment {
+			q.originalStarts = append(q.originalStarts, start)
+			q.originalEnds = append(q.originalEnds, end)
+		}
+		b.Write(segment)
+	}
+	q.text = b.String()
+	return q
+}
+
+// originalText returns the substring of the original query corresponding to text[start:end]
+func (q *normalizedQuery) originalText(start, end int) string {
+	return q.original[q.originalStarts[start]:q.originalEnds[end-1]]
+}
+
+func (q *normalizedQuery) mask(start, end int) {
+	q.text = q.text[:start] + strings.Repeat(" ", end-start) + q.text[end:]
+}
+
+// submatches returns the submatches of the location found by FindStringSubmatchIndex
+// Unmatched optional groups are empty.
+func submatches(s string, loc []int) []string {
+	match := make([]string, len(loc)/2)
+	for i := range match {
+		if loc[2*i] >= 0 {
+			match[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return match
+}
+
+// isSeparateTerm returns true if s[start:end] is not adjacent to ASCII alphanumerics or symbols
+// e.g. "H120" is a separate term in "棚 H120" and "棚H120", but not in "ABC-H120"
+func isSeparateTerm(s string, start, end int) bool {
+	if start > 0 {
+		if r, _ := utf8.DecodeLastRuneInString(s[:start]); isASCIIAlphanumericOrSymbol(r) {
+			return false
+		}
+	}
+	if end < len(s) {
+		if r, _ := utf8.DecodeRuneInString(s[end:]); isASCIIAlphanumericOrSymbol(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCIIAlphanumericOrSymbol(r rune) bool {
+	return r < utf8.RuneSelf && r != ' ' && unicode.IsGraphic(r)
+}
+
+func parsePrice(numStr string, unit string) (int, bool) {
+	num, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch unit {
+	case "万", "万円":
+		num *= 10000
+	case "千", "千円":
+		num *= 1000
+	}
+	return int(math.Round(num)), true
+}
+
+func parseLengthInCentimeters(numStr string, unit string) (int, bool) {
+	num, err := strconv.ParseFloat(numStr, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch strings.ToLower(unit) {
+	case "mm":
+		num /= 10
+	case "m":
+		num *= 100
+	}
+	return int(math.Round(num)), true
+}
+
+// mergeSearchFilter sets the filters interpreted from the query to the given filter
+// Filters explicitly given are prioritized over the interpreted ones.
+func mergeSearchFilter(filter *gqlmodel.SearchFilter, interpreted *gqlmodel.SearchFilter) *gqlmodel.SearchFilter {
+	merged := *filter
+	if len(merged.Colors) == 0 {
+		merged.Colors = interpreted.Colors
+	}
+	if len(merged.BrandNames) == 0 {
+		merged.BrandNames = interpreted.BrandNames
+	}
+	if merged.MinPrice == nil && merged.MaxPrice == nil {
+		merged.MinPrice, merged.MaxPrice = interpreted.MinPrice, interpreted.MaxPrice
+	}
+	if merged.MinWidth == nil && merged.MaxWidth == nil {
+		merged.MinWidth, merged.MaxWidth = interpreted.MinWidth, interpreted.MaxWidth
+	}
+	if merged.MinDepth == nil && merged.MaxDepth == nil {
+		merged.MinDepth, merged.MaxDepth = interpreted.MinDepth, interpreted.MaxDepth
+	}
+	if merged.MinHeight == nil && merged.MaxHeight == nil {
+		merged.MinHeight, merged.MaxHeight = interpreted.MinHeight, interpreted.MaxHeight
+	}
+	return &merged
+}
@@ -0,0 +1,127 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func Test_parseQuery(t *testing.T) {
+	t.Parallel()
+
+	brandNameMap := map[string]string{
+		"ニトリ":  "ニトリ",
+		"ikea": "IKEA",
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		want  *parsedQuery
+	}{
+		{
+			name:  "interprets color, dimension and price",
+			query: "白 ソファ 幅180 3万円以下",
+			want: &parsedQuery{
+				Query: "ソファ",
+				Filter: &gqlmodel.SearchFilter{
+					Colors:   []gqlmodel.ItemColor{gqlmodel.ItemColorWhite},
+					MaxPrice: intPtr(30000),
+					MinWidth: intPtr(170),
+					MaxWidth: intPtr(190),
+				},
+				Parts: []*InterpretedQueryPart{
+					{Text: "3万円以下", Type: InterpretedQueryPartTypePrice},
+					{Text: "幅180", Type: InterpretedQueryPartTypeDimension},
+					{Text: "白", Type: InterpretedQueryPartTypeColor},
+				},
+			},
+		},
+		{
+			name:  "interprets price range and full-width characters keeping the original text",
+			query: "ＩＫＥＡ　チェア　１〜２万円　高さ80cm以上",
+			want: &parsedQuery{
+				Query: "チェア",
+				Filter: &gqlmodel.SearchFilter{
+					BrandNames: []string{"IKEA"},
+					MinPrice:   intPtr(10000),
+					MaxPrice:   intPtr(20000),
+					MinHeight:  intPtr(80),
+				},
+				Parts: []*InterpretedQueryPart{
+					{Text: "１〜２万円", Type: InterpretedQueryPartTypePrice},
+					{Text: "高さ80cm以上", Type: InterpretedQueryPartTypeDimension},
+					{Text: "ＩＫＥＡ", Type: InterpretedQueryPartTypeBrand},
+				},
+			},
+		},
+		{
+			name:  "interprets dimension in mm and price less than",
+			query: "ニトリ 奥行450mm以下 5000円未満",
+			want: &parsedQuery{
+				Query: "",
+				Filter: &gqlmodel.SearchFilter{
+					BrandNames: []string{"ニトリ"},
+					MaxPrice:   intPtr(4999),
+					MaxDepth:   intPtr(45),
+				},
+				Parts: []*InterpretedQueryPart{
+					{Text: "5000円未満", Type: InterpretedQueryPartTypePrice},
+					{Text: "奥行450mm以下", Type: InterpretedQueryPartTypeDimension},
+					{Text: "ニトリ", Type: InterpretedQueryPartTypeBrand},
+				},
+			},
+		},
+		{
+			name:  "interprets dimensions with w, d and h separated from the other terms",
+			query: "ラック Ｗ１２０ D45以下 棚H90",
+			want: &parsedQuery{
+				Query: "ラック 棚",
+				Filter: &gqlmodel.SearchFilter{
+					MinWidth:  intPtr(110),
+					MaxWidth:  intPtr(130),
+					MaxDepth:  intPtr(45),
+					MinHeight: intPtr(80),
+					MaxHeight: intPtr(100),
+				},
+				Parts: []*InterpretedQueryPart{
+					{Text: "Ｗ１２０", Type: InterpretedQueryPartTypeDimension},
+					{Text: "D45以下", Type: InterpretedQueryPartTypeDimension},
+					{Text: "H90", Type: InterpretedQueryPartTypeDimension},
+				},
+			},
+		},
+		{
+			name:  "doesn't interpret model numbers as dimensions",
+			query: "ABC-H120 W120X",
+			want: &parsedQuery{
+				Query:  "ABC-H120 W120X",
+				Filter: &gqlmodel.SearchFilter{},
+			},
+		},
+		{
+			name:  "keeps query as it is when nothing is interpreted",
+			query: "ダイニングテーブル 4人掛け",
+			want: &parsedQuery{
+				Query:  "ダイニングテーブル 4人掛け",
+				Filter: &gqlmodel.SearchFilter{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := parseQuery(tt.query, brandNameMap)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseQuery(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/olivere/elastic/v7"
+)
+
+const (
+	rangeFacetStatsAggregationName       = "stats"
+	rangeFacetPercentilesAggregationName = "percentiles"
+	rangeFacetHistogramAggregationName   = "histogram"
+	rangeFacetHistogramBucketNum         = 10
+)
+
+var rangeFacetPercents = []float64{5, 25, 50, 75, 95}
+
+// rangeFacetFields are the numeric fields aggregated into stats and histogram
+var rangeFacetFields = []struct {
+	field     string
+	facetType FacetType
+}{
+	{field: es.ItemFieldPrice, facetType: FacetTypePrice},
+	{field: es.ItemFieldAverageRating, facetType: FacetTypeAverageRating},
+	{field: es.ItemFieldReviewCount, facetType: FacetTypeReviewCount},
+}
+
+type FacetStats struct {
+	Min         float64
+	Max         float64
+	Avg         float64
+	Percentiles []*Percentile
+}
+
+type Percentile struct {
+	Percent float64
+	Value   float64
+}
+
+type HistogramBucket struct {
+	Min   float64
+	Max   float64
+	Count int
+}
+
+// variableWidthHistogramAggregation builds histogram whose bucket boundaries are adapted to the distribution of the values
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.14/search-aggregations-bucket-variablewidthhistogram-aggregation.html
+// It must not be nested under multi bucket aggregations.
+type variableWidthHistogramAggregation struct {
+	field   string
+	buckets int
+}
+
+func newVariableWidthHistogramAggregation(field string, buckets int) *variableWidthHistogramAggregation {
+	return &variableWidthHistogramAggregation{field: field, buckets: buckets}
+}
+
+func (a *variableWidthHistogramAggregation) Source() (interface{}, error) {
+	return map[string]interface{}{
+		"variable_width_histogram": map[string]interface{}{
+			"field":   a.field,
+			"buckets": a.buckets,
+		},
+	}, nil
+}
+
+type variableWidthHistogramResult struct {
+	Buckets []struct {
+		Min      float64 `json:"min"`
+		Max      float64 `json:"max"`
+		DocCount int64   `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+// applyAggregationsForRangeFacets aggregates stats and histogram of numeric fields
+func applyAggregationsForRangeFacets(search *elastic.SearchService, postFilterMap map[string]elastic.Query, metadataFilters []elastic.Query) *elastic.SearchService {
+	for _, f := range rangeFacetFields {
+		search.Aggregation(f.field, newRangeFacetAggregation(f.field, postFilterMap, metadataFilters))
+	}
+	return search
+}
+
+// newRangeFacetAggregation builds the aggregation of stats and histogram of the field
+// The aggregation is filtered by the filters except for the field itself to show the distribution before being narrowed down.
+func newRangeFacetAggregation(field string, postFilterMap map[string]elastic.Query, metadataFilters []elastic.Query) *elastic.FilterAggregation {
+	filters := append(extractFiltersExceptForField(field, postFilterMap), metadataFilters...)
+	// filter aggregation is used even when no filter since variable_width_histogram can't be nested under filters aggregation
+	return elastic.NewFilterAggregation().
+		Filter(elastic.NewBoolQuery().Filter(filters...)).
+		SubAggregation(rangeFacetStatsAggregationName, elastic.NewStatsAggregation().Field(field)).
+		SubAggregation(rangeFacetPercentilesAggregationName, elastic.NewPercentilesAggregation().Field(field).Percentiles(rangeFacetPercents...)).
+		SubAggregation(rangeFacetHistogramAggregationName, newVariableWidthHistogramAggregation(field, rangeFacetHistogramBucketNum))
+}
+
+func mapAggregationToRangeFacets(agg elastic.Aggregations) ([]*Facet, error) {
+	var facets []*Facet
+	for _, f := range rangeFacetFields {
+		filterResult, ok := agg.Filter(f.field)
+		if !ok {
+			continue
+		}
+		stats, ok := filterResult.Stats(rangeFacetStatsAggregationName)
+		if !ok || stats.Count == 0 || stats.Min == nil || stats.Max == nil || stats.Avg == nil {
+			continue
+		}
+
+		facetStats := &FacetStats{Min: *stats.Min, Max: *stats.Max, Avg: *stats.Avg}
+		if percentiles, ok := filterResult.Percentiles(rangeFacetPercentilesAggregationName); ok {
+			for key, value := range percentiles.Values {
+				percent, err := strconv.ParseFloat(key, 64)
+				if err != nil {
+					return nil, fmt.Errorf("strconv.ParseFloat: %w", err)
+				}
+				facetStats.Percentiles = append(facetStats.Percentiles, &Percentile{Percent: percent, Value: value})
+			}
+			sort.Slice(facetStats.Percentiles, func(i, j int) bool {
+				return facetStats.Percentiles[i].Percent < facetStats.Percentiles[j].Percent
+			})
+		}
+
+		var histogram []*HistogramBucket
+		if raw, ok := filterResult.Aggregations[rangeFacetHistogramAggregationName]; ok {
+			var result variableWidthHistogramResult
+			if err := json.Unmarshal(raw, &result); err != nil {
+				return nil, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			for _, bucket := range result.Buckets {
+				histogram = append(histogram, &HistogramBucket{
+					Min:   bucket.Min,
+					Max:   bucket.Max,
+					Count: int(bucket.DocCount),
+				})
+			}
+		}
+
+		facets = append(facets, &Facet{
+			Title:      facetTypeTitleMap[f.facetType],
+			FacetType:  f.facetType,
+			Values:     []*FacetValue{},
+			TotalCount: int(stats.Count),
+			Stats:      facetStats,
+			Histogram:  histogram,
+		})
+	}
+	return facets, nil
+}
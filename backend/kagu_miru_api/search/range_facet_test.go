@@ -0,0 +1,199 @@
+package search
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/olivere/elastic/v7"
+)
+
+func Test_newRangeFacetAggregation(t *testing.T) {
+	t.Parallel()
+
+	postFilterMap := map[string]elastic.Query{
+		es.ItemFieldPrice:       elastic.NewRangeQuery(es.ItemFieldPrice).Gte(1000),
+		es.ItemFieldReviewCount: elastic.NewRangeQuery(es.ItemFieldReviewCount).Gte(10),
+		es.ItemFieldBrandName:   elastic.NewTermsQuery(es.ItemFieldBrandName, "brand"),
+	}
+	metadataFilters := []elastic.Query{elastic.NewTermQuery(es.MetadataNameFullPath, "素材")}
+
+	tests := []struct {
+		name            string
+		field           string
+		postFilterMap   map[string]elastic.Query
+		metadataFilters []elastic.Query
+		want            string
+	}{
+		{
+			name:            "respects every filter except its own",
+			field:           es.ItemFieldPrice,
+			postFilterMap:   postFilterMap,
+			metadataFilters: metadataFilters,
+			want: `{"aggregations":{` +
+				`"histogram":{"variable_width_histogram":{"buckets":10,"field":"price"}},` +
+				`"percentiles":{"percentiles":{"field":"price","percents":[5,25,50,75,95]}},` +
+				`"stats":{"stats":{"field":"price"}}},` +
+				`"filter":{"bool":{"filter":[` +
+				`{"terms":{"brand_name":["brand"]}},` +
+				`{"range":{"review_count":{"from":10,"include_lower":true,"include_upper":true,"to":null}}},` +
+				`{"term":{"metadata.name":"素材"}}]}}}`,
+		},
+		{
+			name:            "respects every filter when its own field is not filtered",
+			field:           es.ItemFieldAverageRating,
+			postFilterMap:   postFilterMap,
+			metadataFilters: metadataFilters,
+			want: `{"aggregations":{` +
+				`"histogram":{"variable_width_histogram":{"buckets":10,"field":"average_rating"}},` +
+				`"percentiles":{"percentiles":{"field":"average_rating","percents":[5,25,50,75,95]}},` +
+				`"stats":{"stats":{"field":"average_rating"}}},` +
+				`"filter":{"bool":{"filter":[` +
+				`{"terms":{"brand_name":["brand"]}},` +
+				`{"range":{"price":{"from":1000,"include_lower":true,"include_upper":true,"to":null}}},` +
+				`{"range":{"review_count":{"from":10,"include_lower":true,"include_upper":true,"to":null}}},` +
+				`{"term":{"metadata.name":"素材"}}]}}}`,
+		},
+		{
+			name:  "wraps with filter aggregation even without filters",
+			field: es.ItemFieldReviewCount,
+			want: `{"aggregations":{` +
+				`"histogram":{"variable_width_histogram":{"buckets":10,"field":"review_count"}},` +
+				`"percentiles":{"percentiles":{"field":"review_count","percents":[5,25,50,75,95]}},` +
+				`"stats":{"stats":{"field":"review_count"}}},` +
+				`"filter":{"bool":{}}}`,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			source, err := newRangeFacetAggregation(tt.field, tt.postFilterMap, tt.metadataFilters).Source()
+			if err != nil {
+				t.Fatalf("Source() error = %v", err)
+			}
+			got, err := json.Marshal(source)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, string(got)); diff != "" {
+				t.Errorf("newRangeFacetAggregation(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_mapAggregationToRangeFacets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		aggregations string
+		want         []*Facet
+		wantErr      bool
+	}{
+		{
+			name: "maps stats, percentiles and histogram under filter aggregation",
+			aggregations: `{
+				"price": {
+					"doc_count": 3,
+					"stats": {"count": 3, "min": 1000, "max": 5000, "avg": 3000, "sum": 9000},
+					"percentiles": {"values": {"95.0": 4800, "5.0": 1200, "50.0": 3000}},
+					"histogram": {"buckets": [
+						{"min": 1000, "key": 1000, "max": 1000, "doc_count": 1},
+						{"min": 3000, "key": 4000, "max": 5000, "doc_count": 2}
+					]}
+				}
+			}`,
+			want: []*Facet{
+				{
+					Title:      "価格",
+					FacetType:  FacetTypePrice,
+					Values:     []*FacetValue{},
+					TotalCount: 3,
+					Stats: &FacetStats{
+						Min: 1000,
+						Max: 5000,
+						Avg: 3000,
+						Percentiles: []*Percentile{
+							{Percent: 5, Value: 1200},
+							{Percent: 50, Value: 3000},
+							{Percent: 95, Value: 4800},
+						},
+					},
+					Histogram: []*HistogramBucket{
+						{Min: 1000, Max: 1000, Count: 1},
+						{Min: 3000, Max: 5000, Count: 2},
+					},
+				},
+			},
+		},
+		{
+			name: "keeps the order of the range facet fields",
+			aggregations: `{
+				"review_count": {"doc_count": 1, "stats": {"count": 1, "min": 10, "max": 10, "avg": 10, "sum": 10}},
+				"average_rating": {"doc_count": 1, "stats": {"count": 1, "min": 4.5, "max": 4.5, "avg": 4.5, "sum": 4.5}}
+			}`,
+			want: []*Facet{
+				{
+					Title:      "評価",
+					FacetType:  FacetTypeAverageRating,
+					Values:     []*FacetValue{},
+					TotalCount: 1,
+					Stats:      &FacetStats{Min: 4.5, Max: 4.5, Avg: 4.5},
+				},
+				{
+					Title:      "レビュー数",
+					FacetType:  FacetTypeReviewCount,
+					Values:     []*FacetValue{},
+					TotalCount: 1,
+					Stats:      &FacetStats{Min: 10, Max: 10, Avg: 10},
+				},
+			},
+		},
+		{
+			name: "excludes fields without values",
+			aggregations: `{
+				"price": {
+					"doc_count": 0,
+					"stats": {"count": 0, "min": null, "max": null, "avg": null, "sum": 0},
+					"percentiles": {"values": {"5.0": null}},
+					"histogram": {"buckets": []}
+				}
+			}`,
+			want: nil,
+		},
+		{
+			name: "returns error for invalid percent",
+			aggregations: `{
+				"price": {
+					"doc_count": 1,
+					"stats": {"count": 1, "min": 1000, "max": 1000, "avg": 1000, "sum": 1000},
+					"percentiles": {"values": {"invalid": 1000}}
+				}
+			}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var agg elastic.Aggregations
+			if err := json.Unmarshal([]byte(tt.aggregations), &agg); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			got, err := mapAggregationToRangeFacets(agg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("mapAggregationToRangeFacets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mapAggregationToRangeFacets(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
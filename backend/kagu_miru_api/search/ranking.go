@@ -0,0 +1,43 @@
+package search
+
+import (
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+	"github.com/olivere/elastic/v7"
+)
+
+// neutralRatingScore is the rating score of items without the rating score
+// It's the same as the default prior of the bayesian rating computed by item_indexer.
+const neutralRatingScore = 3.5
+
+// newScoringFunctionScoreQuery builds the function score query scoring items with the ranking profile's config
+func newScoringFunctionScoreQuery(query elastic.Query, scoring *ranking.ScoringConfig) *elastic.FunctionScoreQuery {
+	functionScoreQuery := elastic.NewFunctionScoreQuery().Query(query).MaxBoost(scoring.MaxBoost)
+	if scoring.RatingDecay != nil {
+		// the decay function scores documents without the field 1, so items not reindexed with the rating score yet
+		// are scored as the neutral rating instead of outranking rated items
+		functionScoreQuery.Add(
+			elastic.NewExistsQuery(es.ItemFieldRatingScore),
+			elastic.NewGaussDecayFunction().
+				FieldName(es.ItemFieldRatingScore).
+				Origin(scoring.RatingDecay.Origin).
+				Offset(scoring.RatingDecay.Offset).
+				Scale(scoring.RatingDecay.Scale).
+				Decay(scoring.RatingDecay.Decay),
+		)
+		functionScoreQuery.Add(
+			elastic.NewBoolQuery().MustNot(elastic.NewExistsQuery(es.ItemFieldRatingScore)),
+			elastic.NewWeightFactorFunction(scoring.RatingDecay.GaussScore(neutralRatingScore)),
+		)
+	}
+	if scoring.ReviewCountFactor != nil {
+		functionScoreQuery.AddScoreFunc(
+			elastic.NewFieldValueFactorFunction().
+				Field(es.ItemFieldReviewCount).
+				Factor(scoring.ReviewCountFactor.Factor).
+				Modifier(scoring.ReviewCountFactor.Modifier).
+				Missing(0),
+		)
+	}
+	return functionScoreQuery
+}
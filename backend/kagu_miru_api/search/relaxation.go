@@ -0,0 +1,73 @@
+package search
+
+import (
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+	"github.com/olivere/elastic/v7"
+)
+
+// relaxedMinimumShouldMatch is the minimum should match used instead of AND operator when relaxed
+const relaxedMinimumShouldMatch = "2<75%"
+
+// Relaxation represents how the search is broadened when the original search returns no hits
+type Relaxation int
+
+const (
+	RelaxationNone Relaxation = iota
+	// RelaxationMinimumShouldMatch matches items containing most of the terms instead of all of them
+	RelaxationMinimumShouldMatch
+	// RelaxationDropFilters drops the filters except for categories, platforms and prices
+	RelaxationDropFilters
+	// RelaxationCategoryOnly matches the query to category names only with category and platform filters
+	RelaxationCategoryOnly
+)
+
+// getRelaxationChain returns relaxations to be tried in order until the search returns any hits
+// Searches without query are not relaxed, since dropping the filters the user browses by returns almost all items.
+func getRelaxationChain(input *gqlmodel.SearchInput) []Relaxation {
+	if input.Query == "" {
+		return []Relaxation{RelaxationNone}
+	}
+	return []Relaxation{RelaxationNone, RelaxationMinimumShouldMatch, RelaxationDropFilters, RelaxationCategoryOnly}
+}
+
+// relaxSearchInput returns the copy of the input relaxed with the given relaxation
+// Platforms are never dropped since they are chosen explicitly e.g. the platform row in home.
+func relaxSearchInput(input *gqlmodel.SearchInput, relaxation Relaxation) *gqlmodel.SearchInput {
+	relaxed := *input
+	switch relaxation {
+	case RelaxationDropFilters:
+		relaxed.Filter = &gqlmodel.SearchFilter{
+			CategoryIds: input.Filter.CategoryIds,
+			Platforms:   input.Filter.Platforms,
+			MinPrice:    input.Filter.MinPrice,
+			MaxPrice:    input.Filter.MaxPrice,
+		}
+	case RelaxationCategoryOnly:
+		relaxed.Filter = &gqlmodel.SearchFilter{
+			CategoryIds: input.Filter.CategoryIds,
+			Platforms:   input.Filter.Platforms,
+		}
+	}
+	return &relaxed
+}
+
+// buildTextQuery builds the query to match the query text with the given relaxation
+func buildTextQuery(query string, relaxation Relaxation, scoring *ranking.ScoringConfig) elastic.Query {
+	if query == "" {
+		return elastic.NewMatchAllQuery()
+	}
+	switch relaxation {
+	case RelaxationNone:
+		return newMultiMatchQuery(query, scoring).Operator("AND")
+	case RelaxationCategoryOnly:
+		return elastic.NewMatchQuery(es.ItemFieldCategoryNames, query)
+	default:
+		return newMultiMatchQuery(query, scoring).MinimumShouldMatch(relaxedMinimumShouldMatch)
+	}
+}
+
+func newMultiMatchQuery(query string, scoring *ranking.ScoringConfig) *elastic.MultiMatchQuery {
+	return elastic.NewMultiMatchQuery(query, scoring.BoostedFields()...).Type("cross_fields")
+}
@@ -0,0 +1,90 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+)
+
+func Test_getRelaxationChain(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input *gqlmodel.SearchInput
+		want  []Relaxation
+	}{
+		{
+			name:  "relaxes the search with query step by step",
+			input: &gqlmodel.SearchInput{Query: "ソファ", Filter: &gqlmodel.SearchFilter{}},
+			want:  []Relaxation{RelaxationNone, RelaxationMinimumShouldMatch, RelaxationDropFilters, RelaxationCategoryOnly},
+		},
+		{
+			name: "doesn't relax the search without query",
+			input: &gqlmodel.SearchInput{Filter: &gqlmodel.SearchFilter{
+				Platforms: []gqlmodel.ItemSellingPlatform{gqlmodel.ItemSellingPlatformAmazon},
+			}},
+			want: []Relaxation{RelaxationNone},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if diff := cmp.Diff(tt.want, getRelaxationChain(tt.input)); diff != "" {
+				t.Errorf("getRelaxationChain(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_relaxSearchInput(t *testing.T) {
+	t.Parallel()
+
+	minPrice := 1000
+	input := &gqlmodel.SearchInput{
+		Query: "ソファ",
+		Filter: &gqlmodel.SearchFilter{
+			CategoryIds: []string{"100"},
+			Platforms:   []gqlmodel.ItemSellingPlatform{gqlmodel.ItemSellingPlatformAmazon},
+			BrandNames:  []string{"brand"},
+			MinPrice:    &minPrice,
+		},
+	}
+	tests := []struct {
+		name       string
+		relaxation Relaxation
+		want       *gqlmodel.SearchFilter
+	}{
+		{
+			name:       "drops filters except for categories, platforms and prices",
+			relaxation: RelaxationDropFilters,
+			want: &gqlmodel.SearchFilter{
+				CategoryIds: []string{"100"},
+				Platforms:   []gqlmodel.ItemSellingPlatform{gqlmodel.ItemSellingPlatformAmazon},
+				MinPrice:    &minPrice,
+			},
+		},
+		{
+			name:       "keeps categories and platforms when matching category names only",
+			relaxation: RelaxationCategoryOnly,
+			want: &gqlmodel.SearchFilter{
+				CategoryIds: []string{"100"},
+				Platforms:   []gqlmodel.ItemSellingPlatform{gqlmodel.ItemSellingPlatformAmazon},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := relaxSearchInput(input, tt.relaxation)
+			if diff := cmp.Diff(tt.want, got.Filter); diff != "" {
+				t.Errorf("relaxSearchInput(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
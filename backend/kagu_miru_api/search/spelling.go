@@ -0,0 +1,141 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"github.com/olivere/elastic/v7"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	// poorQueryMaxHits is the max number of hits regarded as the query scores poorly
+	poorQueryMaxHits = 10
+	// autoCorrectMinHitsRatio is the min ratio of the hits of the suggestion to the hits of the original query
+	// to search with the suggestion instead of the original query
+	autoCorrectMinHitsRatio = 10
+
+	spellingSuggestionSize = 3
+)
+
+// spellingSuggestionFields are the fields used to build the vocabulary for spelling suggestions
+// The suggestions are scored with the word shingles of the field, and collated with the field itself.
+var spellingSuggestionFields = []struct {
+	field        string
+	shingleField string
+}{
+	{field: es.ItemFieldName, shingleField: es.ItemFieldNameShingle},
+	{field: es.ItemFieldCategoryNames, shingleField: es.ItemFieldCategoryNamesShingle},
+}
+
+// collateQueryTemplate is used to return only suggestions matching any items
+const collateQueryTemplate = `{"match": {"{{field_name}}": {"query": "{{suggestion}}", "operator": "and"}}}`
+
+// GetSpellingSuggestions returns corrected queries ordered by relevance
+// Suggestions are built from the words used in items, so it returns nothing if the query doesn't contain typos.
+func (s *searchClient) GetSpellingSuggestions(ctx context.Context, query string) ([]string, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "search.searchClient_GetSpellingSuggestions")
+	defer span.End()
+
+	search := s.esClient.Search().Index(s.itemsIndexName).Size(0)
+	for _, f := range spellingSuggestionFields {
+		search = search.Suggester(
+			elastic.NewPhraseSuggester(f.field).
+				Text(query).
+				Field(f.shingleField).
+				Size(spellingSuggestionSize).
+				MaxErrors(2).
+				CandidateGenerator(elastic.NewDirectCandidateGenerator(f.shingleField).SuggestMode("always").MinWordLength(2)).
+				CollateQuery(elastic.NewScript(collateQueryTemplate).Type("inline")).
+				CollateParams(map[string]interface{}{"field_name": f.field}),
+		)
+	}
+	resp, err := search.RequestCache(true).Do(ctx)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("esClient.Search: %w", err))
+	}
+	return buildSpellingSuggestions(query, resp.Suggest), nil
+}
+
+// isPoorQuery returns true when the query hits too few items to suggest the corrected query
+func isPoorQuery(totalHits int64) bool {
+	return totalHits < poorQueryMaxHits
+}
+
+// shouldAutoCorrect returns true when the corrected query hits enough more items than the original query
+// The original query without any hits is regarded as it hits 1 item.
+func shouldAutoCorrect(originalTotalHits, correctedTotalHits int64) bool {
+	return correctedTotalHits >= autoCorrectMinHitsRatio*int64(math.Max(1, float64(originalTotalHits)))
+}
+
+// buildSpellingSuggestions returns the suggestions of all fields ordered by the score without duplicates
+// Suggestions which are the same as the query except for spaces are excluded.
+func buildSpellingSuggestions(query string, searchSuggest elastic.SearchSuggest) []string {
+	type suggestion struct {
+		text  string
+		score float64
+	}
+	suggestionMap := make(map[string]*suggestion)
+	for _, f := range spellingSuggestionFields {
+		for _, searchSuggestion := range searchSuggest[f.field] {
+			for _, option := range searchSuggestion.Options {
+				text := joinSuggestionTokens(option.Text)
+				if text == "" || removeSpaces(text) == removeSpaces(query) {
+					continue
+				}
+				if sg, ok := suggestionMap[text]; ok && sg.score >= option.Score {
+					continue
+				}
+				suggestionMap[text] = &suggestion{text: text, score: option.Score}
+			}
+		}
+	}
+
+	suggestions := make([]*suggestion, 0, len(suggestionMap))
+	for _, sg := range suggestionMap {
+		suggestions = append(suggestions, sg)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].score == suggestions[j].score {
+			return suggestions[i].text < suggestions[j].text
+		}
+		return suggestions[i].score > suggestions[j].score
+	})
+	suggestedQueries := make([]string, 0, len(suggestions))
+	for _, sg := range suggestions {
+		suggestedQueries = append(suggestedQueries, sg.text)
+	}
+	return suggestedQueries
+}
+
+// joinSuggestionTokens joins the tokens of the suggestion
+// Phrase suggester joins tokens with space, but Japanese words are not separated with space e.g. "ソファ ベッド" => "ソファベッド".
+// The space is kept next to alphanumeric words e.g. "ikea ソファ".
+func joinSuggestionTokens(text string) string {
+	tokens := strings.Fields(text)
+	var sb strings.Builder
+	for i, token := range tokens {
+		if i > 0 {
+			prevRunes := []rune(tokens[i-1])
+			if isASCIIAlphanumeric(prevRunes[len(prevRunes)-1]) || isASCIIAlphanumeric([]rune(token)[0]) {
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString(token)
+	}
+	return sb.String()
+}
+
+func isASCIIAlphanumeric(r rune) bool {
+	return r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+func removeSpaces(s string) string {
+	return strings.Join(strings.Fields(s), "")
+}
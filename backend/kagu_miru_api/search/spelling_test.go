@@ -0,0 +1,138 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/olivere/elastic/v7"
+)
+
+func Test_buildSpellingSuggestions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		query         string
+		searchSuggest elastic.SearchSuggest
+		want          []string
+	}{
+		{
+			name:  "merges suggestions of all fields ordered by score",
+			query: "ソフアベッド",
+			searchSuggest: elastic.SearchSuggest{
+				es.ItemFieldName: {{Options: []elastic.SearchSuggestionOption{
+					{Text: "ソファ ベッド", Score: 0.5},
+					{Text: "ソファ ベット", Score: 0.1},
+				}}},
+				es.ItemFieldCategoryNames: {{Options: []elastic.SearchSuggestionOption{
+					{Text: "ソファ ベッド", Score: 0.8},
+					{Text: "ソファー ベッド", Score: 0.3},
+				}}},
+			},
+			want: []string{"ソファベッド", "ソファーベッド", "ソファベット"},
+		},
+		{
+			name:  "keeps space next to alphanumeric words",
+			query: "ikea sofa ソフア",
+			searchSuggest: elastic.SearchSuggest{
+				es.ItemFieldName: {{Options: []elastic.SearchSuggestionOption{
+					{Text: "ikea sofa ソファ", Score: 0.5},
+				}}},
+			},
+			want: []string{"ikea sofa ソファ"},
+		},
+		{
+			name:  "excludes suggestions same as the query except for spaces",
+			query: "ソファ ベッド",
+			searchSuggest: elastic.SearchSuggest{
+				es.ItemFieldName: {{Options: []elastic.SearchSuggestionOption{
+					{Text: "ソファ ベッド", Score: 0.5},
+					{Text: " ", Score: 0.3},
+				}}},
+			},
+			want: []string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := buildSpellingSuggestions(tt.query, tt.searchSuggest)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("buildSpellingSuggestions(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_isPoorQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		totalHits int64
+		want      bool
+	}{
+		{name: "no hits", totalHits: 0, want: true},
+		{name: "less than the max hits", totalHits: poorQueryMaxHits - 1, want: true},
+		{name: "max hits", totalHits: poorQueryMaxHits, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := isPoorQuery(tt.totalHits); got != tt.want {
+				t.Errorf("isPoorQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_shouldAutoCorrect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name               string
+		originalTotalHits  int64
+		correctedTotalHits int64
+		want               bool
+	}{
+		{
+			name:               "corrects the query without hits",
+			originalTotalHits:  0,
+			correctedTotalHits: autoCorrectMinHitsRatio,
+			want:               true,
+		},
+		{
+			name:               "doesn't correct the query without hits when the suggestion hits too few",
+			originalTotalHits:  0,
+			correctedTotalHits: autoCorrectMinHitsRatio - 1,
+			want:               false,
+		},
+		{
+			name:               "corrects when the suggestion hits enough more items",
+			originalTotalHits:  3,
+			correctedTotalHits: 3 * autoCorrectMinHitsRatio,
+			want:               true,
+		},
+		{
+			name:               "doesn't correct when the suggestion doesn't hit enough more items",
+			originalTotalHits:  3,
+			correctedTotalHits: 3*autoCorrectMinHitsRatio - 1,
+			want:               false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := shouldAutoCorrect(tt.originalTotalHits, tt.correctedTotalHits); got != tt.want {
+				t.Errorf("shouldAutoCorrect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
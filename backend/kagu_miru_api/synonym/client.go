@@ -0,0 +1,185 @@
+package synonym
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/es"
+	"github.com/k-yomo/kagu-miru/backend/internal/xerror"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"github.com/k-yomo/kagu-miru/backend/pkg/logging"
+	"github.com/olivere/elastic/v7"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+const (
+	maxSynonymsPerSet = 100
+	// lockOwner is the owner of the lock of the items index held while applying synonyms
+	lockOwner = "kagu_miru_api"
+)
+
+type Client interface {
+	GetAllSynonymSets(ctx context.Context) ([]*xspanner.SynonymSet, error)
+	GetSynonymSetHistories(ctx context.Context, synonymSetID string) ([]*xspanner.SynonymSetHistory, error)
+	// SaveSynonymSet saves the synonym set and applies all synonyms to the items index
+	SaveSynonymSet(ctx context.Context, synonymSet *xspanner.SynonymSet) (*xspanner.SynonymSet, error)
+	// RollbackSynonymSet restores the synonym set at the given version and applies all synonyms to the items index
+	RollbackSynonymSet(ctx context.Context, synonymSetID string, version int64) (*xspanner.SynonymSet, error)
+	// ApplySynonyms applies all active synonym sets to the items index
+	ApplySynonyms(ctx context.Context) error
+}
+
+type synonymClient struct {
+	itemsIndexName string
+	esClient       *elastic.Client
+	dbClient       db.Client
+
+	applyMu sync.Mutex
+}
+
+func NewSynonymClient(itemsIndexName string, esClient *elastic.Client, dbClient db.Client) Client {
+	return &synonymClient{
+		itemsIndexName: itemsIndexName,
+		esClient:       esClient,
+		dbClient:       dbClient,
+	}
+}
+
+func (s *synonymClient) GetAllSynonymSets(ctx context.Context) ([]*xspanner.SynonymSet, error) {
+	return s.dbClient.GetAllSynonymSets(ctx)
+}
+
+func (s *synonymClient) GetSynonymSetHistories(ctx context.Context, synonymSetID string) ([]*xspanner.SynonymSetHistory, error) {
+	return s.dbClient.GetSynonymSetHistories(ctx, synonymSetID)
+}
+
+func (s *synonymClient) SaveSynonymSet(ctx context.Context, synonymSet *xspanner.SynonymSet) (*xspanner.SynonymSet, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "synonym.synonymClient_SaveSynonymSet")
+	defer span.End()
+
+	synonyms, err := normalizeSynonyms(synonymSet.Synonyms)
+	if err != nil {
+		return nil, xerror.NewInvalidArgument(err)
+	}
+	normalized := *synonymSet
+	normalized.Synonyms = synonyms
+	saved, err := s.dbClient.SaveSynonymSet(ctx, &normalized)
+	if err != nil {
+		return nil, logging.Error(ctx, fmt.Errorf("dbClient.SaveSynonymSet: %w", err))
+	}
+	if err := s.ApplySynonyms(ctx); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+func (s *synonymClient) RollbackSynonymSet(ctx context.Context, synonymSetID string, version int64) (*xspanner.SynonymSet, error) {
+	ctx, span := otel.Tracer("").Start(ctx, "synonym.synonymClient_RollbackSynonymSet")
+	defer span.End()
+
+	saved, err := s.dbClient.RollbackSynonymSet(ctx, synonymSetID, version)
+	if err != nil {
+		if xerror.IsErrorType(err, xerror.TypeNotFound) {
+			return nil, err
+		}
+		return nil, logging.Error(ctx, fmt.Errorf("dbClient.RollbackSynonymSet: %w", err))
+	}
+	if err := s.ApplySynonyms(ctx); err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// ApplySynonyms updates the search time synonym filter of the items index with all active synonyms
+// The filter is updateable and used only by the search analyzer, so the items don't need to be reindexed.
+// The update is skipped when the synonyms are not changed since the index is closed for a moment while updating.
+func (s *synonymClient) ApplySynonyms(ctx context.Context) error {
+	ctx, span := otel.Tracer("").Start(ctx, "synonym.synonymClient_ApplySynonyms")
+	defer span.End()
+
+	// concurrent applies must not overwrite the synonyms with the older ones
+	s.applyMu.Lock()
+	defer s.applyMu.Unlock()
+	// the lock is shared with items_index_creator, so the alias is not switched to the version with the older synonyms
+	release, err := es.AcquireLock(ctx, s.esClient, s.itemsIndexName, lockOwner)
+	if errors.Is(err, es.ErrLocked) {
+		return xerror.NewConflict(fmt.Errorf("the items index is being changed, apply synonyms again later: %w", err))
+	}
+	if err != nil {
+		return logging.Error(ctx, fmt.Errorf("es.AcquireLock: %w", err))
+	}
+	defer func() {
+		if err := release(context.Background()); err != nil {
+			logging.Logger(ctx).Error("failed to release the lock", zap.Error(err))
+		}
+	}()
+
+	synonymSets, err := s.dbClient.GetAllSynonymSets(ctx)
+	if err != nil {
+		return logging.Error(ctx, fmt.Errorf("dbClient.GetAllSynonymSets: %w", err))
+	}
+	rules := buildSynonymRules(synonymSets)
+
+	// the items index can be still a concrete index created before versioning
+	index, err := es.GetAliasedIndex(ctx, s.esClient, s.itemsIndexName)
+	if errors.Is(err, es.ErrNotAlias) {
+		index = s.itemsIndexName
+	} else if err != nil {
+		return logging.Error(ctx, fmt.Errorf("es.GetAliasedIndex: %w", err))
+	}
+	currentRules, err := es.GetSynonyms(ctx, s.esClient, index)
+	if err != nil {
+		return logging.Error(ctx, fmt.Errorf("es.GetSynonyms: %w", err))
+	}
+	if es.IsSameSynonyms(currentRules, rules) {
+		return nil
+	}
+	if err := es.UpdateSynonyms(ctx, s.esClient, index, rules); err != nil {
+		return logging.Error(ctx, fmt.Errorf("es.UpdateSynonyms: %w", err))
+	}
+	logging.Logger(ctx).Info("synonyms are applied", zap.String("index", index), zap.Int("rules", len(rules)))
+	return nil
+}
+
+// normalizeSynonyms trims the terms and removes duplicates
+// Terms must not contain characters used in synonym rule syntax.
+func normalizeSynonyms(synonyms []string) ([]string, error) {
+	seen := make(map[string]bool)
+	normalized := make([]string, 0, len(synonyms))
+	for _, synonym := range synonyms {
+		synonym = strings.TrimSpace(synonym)
+		if synonym == "" || seen[synonym] {
+			continue
+		}
+		if strings.ContainsAny(synonym, ",#\n") || strings.Contains(synonym, "=>") {
+			return nil, fmt.Errorf("synonym '%s' contains invalid characters", synonym)
+		}
+		seen[synonym] = true
+		normalized = append(normalized, synonym)
+	}
+	if len(normalized) < 2 {
+		return nil, errors.New("synonym set must have at least 2 synonyms")
+	}
+	if len(normalized) > maxSynonymsPerSet {
+		return nil, fmt.Errorf("synonym set can have up to %d synonyms", maxSynonymsPerSet)
+	}
+	return normalized, nil
+}
+
+// buildSynonymRules builds equivalent synonym rules in solr format from active synonym sets
+// e.g. "ソファ, ソファー, sofa"
+func buildSynonymRules(synonymSets []*xspanner.SynonymSet) []string {
+	rules := make([]string, 0, len(synonymSets))
+	for _, synonymSet := range synonymSets {
+		if !synonymSet.IsActive || len(synonymSet.Synonyms) < 2 {
+			continue
+		}
+		rules = append(rules, strings.Join(synonymSet.Synonyms, ", "))
+	}
+	return rules
+}
@@ -0,0 +1,64 @@
+package synonym
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/k-yomo/kagu-miru/backend/internal/xspanner"
+)
+
+func Test_normalizeSynonyms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		synonyms []string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "trims and dedupes synonyms",
+			synonyms: []string{" ソファ", "ソファー ", "sofa", "ソファ", ""},
+			want:     []string{"ソファ", "ソファー", "sofa"},
+		},
+		{
+			name:     "returns error when synonym contains rule syntax",
+			synonyms: []string{"テレビ台", "TVボード => ローボード"},
+			wantErr:  true,
+		},
+		{
+			name:     "returns error when less than 2 synonyms",
+			synonyms: []string{"ソファ", " ソファ "},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := normalizeSynonyms(tt.synonyms)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("normalizeSynonyms() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("normalizeSynonyms(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_buildSynonymRules(t *testing.T) {
+	t.Parallel()
+
+	synonymSets := []*xspanner.SynonymSet{
+		{ID: "1", Synonyms: []string{"ソファ", "ソファー", "sofa"}, IsActive: true},
+		{ID: "2", Synonyms: []string{"テレビ台", "TVボード"}, IsActive: false},
+		{ID: "3", Synonyms: []string{"ローボード"}, IsActive: true},
+	}
+	want := []string{"ソファ, ソファー, sofa"}
+	if diff := cmp.Diff(want, buildSynonymRules(synonymSets)); diff != "" {
+		t.Errorf("buildSynonymRules(), (-want +got): %s", diff)
+	}
+}
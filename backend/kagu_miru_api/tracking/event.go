@@ -26,8 +26,27 @@ type Event struct {
 	IPAddress string `json:"ip"`
 }
 
+// experimentsParamKey is the key in params of the map of the experiment id to the assigned variant name
+// The experiments are nested in params since the events table doesn't have the column.
+const experimentsParamKey = "experiments"
+
+type ctxExperimentsKey struct{}
+
+// WithExperiments sets the experiment variants of the user to the context to stamp onto the events
+func WithExperiments(ctx context.Context, experiments map[string]string) context.Context {
+	return context.WithValue(ctx, ctxExperimentsKey{}, experiments)
+}
+
 func NewEvent(ctx context.Context, gqlEvent gqlmodel.Event) *Event {
-	params, _ := json.Marshal(gqlEvent.Params)
+	paramsMap := gqlEvent.Params
+	if experiments := getExperiments(ctx); len(experiments) > 0 {
+		paramsMap = make(map[string]interface{}, len(gqlEvent.Params)+1)
+		for k, v := range gqlEvent.Params {
+			paramsMap[k] = v
+		}
+		paramsMap[experimentsParamKey] = experiments
+	}
+	params, _ := json.Marshal(paramsMap)
 	event := newDefaultEvent(ctx)
 	event.ID = gqlEvent.ID.String()
 	event.Action = gqlEvent.Action.String()
@@ -36,6 +55,36 @@ func NewEvent(ctx context.Context, gqlEvent gqlmodel.Event) *Event {
 	return event
 }
 
+// SearchZeroResultActionParams is the params of the event recorded when the search returns no items
+type SearchZeroResultActionParams struct {
+	SearchID    string                     `json:"searchId"`
+	SearchInput *gqlmodel.SearchInput      `json:"searchInput"`
+	Relaxation  *gqlmodel.SearchRelaxation `json:"relaxation"`
+	Recovered   bool                       `json:"recovered"`
+	// RankingProfile is the ranking profile used in the search
+	RankingProfile *gqlmodel.RankingProfileInput `json:"rankingProfile"`
+	// Experiments is set from the context when the event is created
+	Experiments map[string]string `json:"experiments,omitempty"`
+}
+
+// NewSearchZeroResultEvent creates the event recorded on backend when the search returns no items
+func NewSearchZeroResultEvent(ctx context.Context, params *SearchZeroResultActionParams) *Event {
+	params.Experiments = getExperiments(ctx)
+	paramsJSON, _ := json.Marshal(params)
+	event := newDefaultEvent(ctx)
+	event.ID = gqlmodel.EventIDSearch.String()
+	event.Action = gqlmodel.ActionZeroResult.String()
+	event.CreatedAt = time.Now()
+	event.Params = string(paramsJSON)
+	return event
+}
+
+// getExperiments returns the experiment variants of the user set by WithExperiments
+func getExperiments(ctx context.Context) map[string]string {
+	experiments, _ := ctx.Value(ctxExperimentsKey{}).(map[string]string)
+	return experiments
+}
+
 func newDefaultEvent(ctx context.Context) *Event {
 	req, ok := request.GetRequestFromCtx(ctx)
 	if !ok {
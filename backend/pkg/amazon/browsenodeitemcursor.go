@@ -3,15 +3,42 @@ package amazon
 import (
 	"context"
 	"errors"
-	"time"
 
 	"github.com/utekaravinash/gopaapi5/api"
-
-	"github.com/cenkalti/backoff/v4"
 )
 
 var Done = errors.New("DONE")
 
+// itemResources is the resources of the item to get for indexing
+var itemResources = []api.Resource{
+	api.BrowseNodeInfoBrowseNodes,
+	api.ImagesPrimaryLarge,
+	api.ImagesVariantsLarge,
+	api.ItemInfoByLineInfo,
+	api.ItemInfoFeatures,
+	api.ItemInfoProductInfo,
+	api.ItemInfoTitle,
+	api.OffersListingsAvailabilityType,
+	api.OffersListingsCondition,
+	api.OffersListingsConditionSubCondition,
+	api.OffersListingsDeliveryInfoIsAmazonFulfilled,
+	api.OffersListingsDeliveryInfoIsFreeShippingEligible,
+	api.OffersListingsDeliveryInfoIsPrimeEligible,
+	api.OffersListingsDeliveryInfoShippingCharges,
+	api.OffersListingsIsBuyBoxWinner,
+	api.OffersListingsLoyaltyPointsPoints,
+	api.OffersListingsMerchantInfo,
+	api.OffersListingsPrice,
+	api.OffersListingsProgramEligibilityIsPrimeExclusive,
+	api.OffersListingsProgramEligibilityIsPrimePantry,
+	api.OffersListingsPromotions,
+	api.OffersListingsSavingBasis,
+	api.OffersSummariesHighestPrice,
+	api.OffersSummariesLowestPrice,
+	api.OffersSummariesOfferCount,
+	api.ParentASIN,
+}
+
 type BrowseNodeItemCursor struct {
 	amazonClient *Client
 
@@ -33,6 +60,39 @@ func (c *Client) NewBrowseNodeItemCursor(browseNodeID string, minPrice int, maxP
 	}
 }
 
+// BrowseNodeItemCursorState is the serializable state of BrowseNodeItemCursor to resume the cursor
+type BrowseNodeItemCursorState struct {
+	BrowseNodeID string `json:"browse_node_id"`
+	CurPage      int    `json:"cur_page"`
+	CurMinPrice  int    `json:"cur_min_price"`
+	MaxPrice     int    `json:"max_price"`
+	IsDone       bool   `json:"is_done"`
+}
+
+// NewBrowseNodeItemCursorFromState restores the cursor from the state
+func (c *Client) NewBrowseNodeItemCursorFromState(state *BrowseNodeItemCursorState) *BrowseNodeItemCursor {
+	return &BrowseNodeItemCursor{
+		amazonClient: c,
+		browseNodeID: state.BrowseNodeID,
+		curPage:      state.CurPage,
+		curMinPrice:  state.CurMinPrice,
+		maxPrice:     state.MaxPrice,
+		isDone:       state.IsDone,
+	}
+}
+
+// State returns the current state of the cursor
+// Restoring the cursor from the state fetches the next items from where the cursor was.
+func (g *BrowseNodeItemCursor) State() *BrowseNodeItemCursorState {
+	return &BrowseNodeItemCursorState{
+		BrowseNodeID: g.browseNodeID,
+		CurPage:      g.curPage,
+		CurMinPrice:  g.curMinPrice,
+		MaxPrice:     g.maxPrice,
+		IsDone:       g.isDone,
+	}
+}
+
 func (g *BrowseNodeItemCursor) CurPage() int {
 	return g.curPage
 }
@@ -55,46 +115,9 @@ func (g *BrowseNodeItemCursor) Next(ctx context.Context) ([]api.Item, error) {
 		LanguagesOfPreference: []api.Language{api.JapaneseJapan},
 		ItemPage:              g.curPage,
 		SortBy:                api.PriceLowToHigh,
-		Resources: []api.Resource{
-			api.BrowseNodeInfoBrowseNodes,
-			api.ImagesPrimaryLarge,
-			api.ImagesVariantsLarge,
-			api.ItemInfoByLineInfo,
-			api.ItemInfoProductInfo,
-			api.ItemInfoTitle,
-			api.OffersListingsAvailabilityType,
-			api.OffersListingsCondition,
-			api.OffersListingsConditionSubCondition,
-			api.OffersListingsDeliveryInfoIsAmazonFulfilled,
-			api.OffersListingsDeliveryInfoIsFreeShippingEligible,
-			api.OffersListingsDeliveryInfoIsPrimeEligible,
-			api.OffersListingsDeliveryInfoShippingCharges,
-			api.OffersListingsIsBuyBoxWinner,
-			api.OffersListingsLoyaltyPointsPoints,
-			api.OffersListingsMerchantInfo,
-			api.OffersListingsPrice,
-			api.OffersListingsProgramEligibilityIsPrimeExclusive,
-			api.OffersListingsProgramEligibilityIsPrimePantry,
-			api.OffersListingsPromotions,
-			api.OffersListingsSavingBasis,
-			api.OffersSummariesHighestPrice,
-			api.OffersSummariesLowestPrice,
-			api.OffersSummariesOfferCount,
-			api.ParentASIN,
-		},
+		Resources:             itemResources,
 	}
-	var searchItemRes *api.SearchResult
-	expBackoff := backoff.NewExponentialBackOff()
-	expBackoff.InitialInterval = 1 * time.Second
-	b := backoff.WithMaxRetries(expBackoff, 5)
-	err := backoff.Retry(func() error {
-		var err error
-		searchItemRes, err = g.amazonClient.SearchItems(ctx, searchItemParams)
-		if err == TransactionPerDayExhausted {
-			return backoff.Permanent(err)
-		}
-		return err
-	}, b)
+	searchItemRes, err := g.amazonClient.SearchItems(ctx, searchItemParams)
 	if err != nil {
 		return nil, err
 	}
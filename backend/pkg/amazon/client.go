@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
 	"golang.org/x/time/rate"
 
 	"go.uber.org/multierr"
@@ -15,43 +16,52 @@ import (
 
 const (
 	// maxResultCountPerPage = 10
-	maxPage          = 10
-	maxAPICallPerDay = 8640
+	maxPage = 10
+	// MaxAPICallPerDay is the transactions per day limit of the credential
+	MaxAPICallPerDay = 8640
+	// MaxGetItemsCount is the max number of item ids to get at once
+	MaxGetItemsCount = 10
 )
 
-var TransactionPerDayExhausted = errors.New("TPD is exhausted")
+// itemNotAccessibleErrorCode is the error code of the item which can't be got through the API e.g. not sold anymore
+const itemNotAccessibleErrorCode = "ItemNotAccessible"
+
+// TransactionPerDayExhausted wraps quota.ErrExhausted
+var TransactionPerDayExhausted = fmt.Errorf("TPD is exhausted: %w", quota.ErrExhausted)
+
+// ErrItemNotAccessible is returned by GetItems for each item which is no longer accessible
+var ErrItemNotAccessible = errors.New("item is not accessible")
 
 type Client struct {
-	apiClient  *gopaapi5.Client
-	apiCallNum int
+	apiClient *gopaapi5.Client
+
+	quotaLedger  quota.Ledger
+	credentialID string
 
 	rateLimiter *rate.Limiter
 }
 
-func NewClient(partnerTag, accessKey, secretKey string) (*Client, error) {
+func NewClient(partnerTag, accessKey, secretKey string, quotaLedger quota.Ledger) (*Client, error) {
 	apiClient, err := gopaapi5.NewClient(accessKey, secretKey, partnerTag, api.Japan)
 	if err != nil {
 		return nil, err
 	}
 	return &Client{
-		apiClient:   apiClient,
-		rateLimiter: rate.NewLimiter(1, 1),
+		apiClient:    apiClient,
+		quotaLedger:  quotaLedger,
+		credentialID: quota.CredentialID(accessKey),
+		rateLimiter:  rate.NewLimiter(1, 1),
 	}, nil
 }
 
 // GetBrowseNodes gets browse nodes (categories) by id list
 func (c *Client) GetBrowseNodes(ctx context.Context, browseNodeIDs []string) ([]api.BrowseNode, error) {
-	if c.tpdExhausted() {
-		return nil, TransactionPerDayExhausted
-	}
-	if c.tpdExhausted() {
-		return nil, TransactionPerDayExhausted
-	}
-
 	if err := c.rateLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("failed to wait :%w", err)
 	}
-	c.apiCallNum++
+	if err := c.reserveQuota(ctx); err != nil {
+		return nil, err
+	}
 	resp, err := c.apiClient.GetBrowseNodes(ctx, &api.GetBrowseNodesParams{
 		BrowseNodeIds: browseNodeIDs,
 		Resources: []api.Resource{
@@ -75,14 +85,12 @@ func (c *Client) GetBrowseNodes(ctx context.Context, browseNodeIDs []string) ([]
 }
 
 func (c *Client) SearchItems(ctx context.Context, params *api.SearchItemsParams) (*api.SearchResult, error) {
-	if c.tpdExhausted() {
-		return nil, TransactionPerDayExhausted
-	}
 	if err := c.rateLimiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("failed to wait: %w", err)
 	}
-
-	c.apiCallNum++
+	if err := c.reserveQuota(ctx); err != nil {
+		return nil, err
+	}
 	resp, err := c.apiClient.SearchItems(ctx, params)
 	if err != nil {
 		return nil, err
@@ -98,6 +106,60 @@ func (c *Client) SearchItems(ctx context.Context, params *api.SearchItemsParams)
 	return &resp.SearchResult, nil
 }
 
-func (c *Client) tpdExhausted() bool {
-	return c.apiCallNum >= maxAPICallPerDay
+// GetItems gets items by ASIN list
+// Items are returned along with the error when some of the items couldn't be got (e.g. the item is no longer accessible).
+// The errors of the items no longer accessible wrap ErrItemNotAccessible, see also IsOnlyItemNotAccessible.
+func (c *Client) GetItems(ctx context.Context, asins []string) ([]api.Item, error) {
+	if len(asins) > MaxGetItemsCount {
+		return nil, fmt.Errorf("asins must be less than or equal to %d, got %d", MaxGetItemsCount, len(asins))
+	}
+	if err := c.rateLimiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("failed to wait: %w", err)
+	}
+	if err := c.reserveQuota(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := c.apiClient.GetItems(ctx, &api.GetItemsParams{
+		ItemIds:               asins,
+		Condition:             api.New,
+		LanguagesOfPreference: []api.Language{api.JapaneseJapan},
+		Resources:             itemResources,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var errs []error
+	if len(resp.Errors) > 0 {
+		for _, e := range resp.Errors {
+			if e.Code == itemNotAccessibleErrorCode {
+				errs = append(errs, fmt.Errorf("%w: %v", ErrItemNotAccessible, e.Message))
+				continue
+			}
+			errs = append(errs, fmt.Errorf("type: %v, code: %v, message: %v", e.Type, e.Code, e.Message))
+		}
+	}
+
+	return resp.ItemsResult.Items, multierr.Combine(errs...)
+}
+
+// IsOnlyItemNotAccessible returns true if the error returned by GetItems is nil or consists only of ErrItemNotAccessible
+// The items missing in the result can be regarded as no longer accessible only in this case.
+func IsOnlyItemNotAccessible(err error) bool {
+	for _, e := range multierr.Errors(err) {
+		if !errors.Is(e, ErrItemNotAccessible) {
+			return false
+		}
+	}
+	return true
+}
+
+// reserveQuota reserves an api call from the transactions per day
+func (c *Client) reserveQuota(ctx context.Context) error {
+	if _, err := c.quotaLedger.Reserve(ctx, c.credentialID, 1); err != nil {
+		if errors.Is(err, quota.ErrExhausted) {
+			return TransactionPerDayExhausted
+		}
+		return fmt.Errorf("quotaLedger.Reserve: %w", err)
+	}
+	return nil
 }
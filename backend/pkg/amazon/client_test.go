@@ -0,0 +1,56 @@
+package amazon
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"go.uber.org/multierr"
+)
+
+func TestIsOnlyItemNotAccessible(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{
+			name: "no error",
+			err:  nil,
+			want: true,
+		},
+		{
+			name: "only item not accessible errors",
+			err: multierr.Combine(
+				fmt.Errorf("%w: B000000001", ErrItemNotAccessible),
+				fmt.Errorf("%w: B000000002", ErrItemNotAccessible),
+			),
+			want: true,
+		},
+		{
+			name: "item not accessible error with the other error",
+			err: multierr.Combine(
+				fmt.Errorf("%w: B000000001", ErrItemNotAccessible),
+				errors.New("type: ERROR, code: TooManyRequests, message: throttled"),
+			),
+			want: false,
+		},
+		{
+			name: "the other error",
+			err:  errors.New("request failed"),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := IsOnlyItemNotAccessible(tt.err); got != tt.want {
+				t.Errorf("IsOnlyItemNotAccessible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
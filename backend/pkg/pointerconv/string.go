@@ -6,3 +6,10 @@ func StringToPointer(str string) *string {
 	}
 	return &str
 }
+
+func PointerToString(str *string) string {
+	if str == nil {
+		return ""
+	}
+	return *str
+}
@@ -1,9 +1,14 @@
 package rakutenichiba
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"sync"
+
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
 )
 
 const (
@@ -17,6 +22,7 @@ type Client struct {
 
 	applicationIDs []string
 	appIDIndex     int
+	quotaLedger    quota.Ledger
 
 	affiliateID string
 
@@ -26,12 +32,13 @@ type Client struct {
 	httpClient        *http.Client
 }
 
-func NewClient(appIDs []string, affiliateID string) *Client {
+func NewClient(appIDs []string, affiliateID string, quotaLedger quota.Ledger) *Client {
 	itemAPIURL, _ := url.Parse(itemSearchAPIURL)
 	genreAPIURL, _ := url.Parse(genreSearchAPIURL)
 	tagAPIURL, _ := url.Parse(tagSearchAPIURL)
 	return &Client{
 		applicationIDs:    appIDs,
+		quotaLedger:       quotaLedger,
 		affiliateID:       affiliateID,
 		itemSearchAPIURL:  itemAPIURL,
 		genreSearchAPIURL: genreAPIURL,
@@ -46,19 +53,40 @@ func (c *Client) ApplicationIDNum() int {
 	return len(c.applicationIDs)
 }
 
-func (c *Client) buildParams(params map[string]string) map[string]string {
+func (c *Client) buildParams(ctx context.Context, params map[string]string) (map[string]string, error) {
+	appID, err := c.getApplicationID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	p := map[string]string{
 		"format":        "json",
-		"applicationId": c.getApplicationID(),
+		"applicationId": appID,
 	}
 	for k, v := range params {
 		p[k] = v
 	}
 
-	return p
+	return p, nil
+}
+
+// getApplicationID returns the next application id with the quota reserved
+// Application ids with the quota exhausted are skipped, and quota.ErrExhausted is returned when all of them are exhausted.
+func (c *Client) getApplicationID(ctx context.Context) (string, error) {
+	for range c.applicationIDs {
+		appID := c.nextApplicationID()
+		_, err := c.quotaLedger.Reserve(ctx, quota.CredentialID(appID), 1)
+		if errors.Is(err, quota.ErrExhausted) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("quotaLedger.Reserve: %w", err)
+		}
+		return appID, nil
+	}
+	return "", quota.ErrExhausted
 }
 
-func (c *Client) getApplicationID() string {
+func (c *Client) nextApplicationID() string {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
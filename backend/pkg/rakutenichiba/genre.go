@@ -61,7 +61,11 @@ type SearchGenreResponse struct {
 // SearchGenre searches parent, current and children genre of given ID
 // https://webservice.rakuten.co.jp/api/ichibagenresearch/
 func (c *Client) SearchGenre(ctx context.Context, genreID string) (*SearchGenreResponse, error) {
-	u := urlutil.CopyWithQueries(c.genreSearchAPIURL, c.buildParams(map[string]string{"genreId": genreID}))
+	params, err := c.buildParams(ctx, map[string]string{"genreId": genreID})
+	if err != nil {
+		return nil, fmt.Errorf("c.buildParams: %w", err)
+	}
+	u := urlutil.CopyWithQueries(c.genreSearchAPIURL, params)
 	var resp SearchGenreResponse
 	if err := httputil.GetAndUnmarshal(ctx, c.httpClient, u, &resp); err != nil {
 		return nil, fmt.Errorf("httputil.GetAndUnmarshal: %w", err)
@@ -3,8 +3,6 @@ package rakutenichiba
 import (
 	"context"
 	"errors"
-
-	"github.com/cenkalti/backoff/v4"
 )
 
 var Done = errors.New("DONE")
@@ -30,6 +28,39 @@ func (c *Client) NewGenreItemCursor(genreID int, minPrice int, maxPrice int) *Ge
 	}
 }
 
+// GenreItemCursorState is the serializable state of GenreItemCursor to resume the cursor
+type GenreItemCursorState struct {
+	GenreID     int  `json:"genre_id"`
+	CurPage     int  `json:"cur_page"`
+	CurMinPrice int  `json:"cur_min_price"`
+	MaxPrice    int  `json:"max_price"`
+	IsDone      bool `json:"is_done"`
+}
+
+// NewGenreItemCursorFromState restores the cursor from the state
+func (c *Client) NewGenreItemCursorFromState(state *GenreItemCursorState) *GenreItemCursor {
+	return &GenreItemCursor{
+		ichibaClient: c,
+		genreID:      state.GenreID,
+		curPage:      state.CurPage,
+		curMinPrice:  state.CurMinPrice,
+		maxPrice:     state.MaxPrice,
+		isDone:       state.IsDone,
+	}
+}
+
+// State returns the current state of the cursor
+// Restoring the cursor from the state fetches the next items from where the cursor was.
+func (g *GenreItemCursor) State() *GenreItemCursorState {
+	return &GenreItemCursorState{
+		GenreID:     g.genreID,
+		CurPage:     g.curPage,
+		CurMinPrice: g.curMinPrice,
+		MaxPrice:    g.maxPrice,
+		IsDone:      g.isDone,
+	}
+}
+
 func (g *GenreItemCursor) CurPage() int {
 	return g.curPage
 }
@@ -48,13 +79,7 @@ func (g *GenreItemCursor) Next(ctx context.Context) (*SearchItemResponse, error)
 		Page:     g.curPage,
 		SortType: SearchItemSortTypeItemPriceAsc,
 	}
-	var searchItemRes *SearchItemResponse
-	b := backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 5)
-	err := backoff.Retry(func() error {
-		var err error
-		searchItemRes, err = g.ichibaClient.SearchItem(ctx, searchItemParams)
-		return err
-	}, b)
+	searchItemRes, err := g.ichibaClient.SearchItem(ctx, searchItemParams)
 	if err != nil {
 		return nil, err
 	}
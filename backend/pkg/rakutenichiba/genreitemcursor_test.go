@@ -0,0 +1,69 @@
+package rakutenichiba
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestGenreItemCursor_State(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{}
+	tests := []struct {
+		name   string
+		cursor *GenreItemCursor
+		want   *GenreItemCursorState
+	}{
+		{
+			name:   "state of the new cursor",
+			cursor: client.NewGenreItemCursor(100804, 1000, 50000),
+			want:   &GenreItemCursorState{GenreID: 100804, CurPage: 1, CurMinPrice: 1000, MaxPrice: 50000},
+		},
+		{
+			name:   "state of the restored cursor",
+			cursor: client.NewGenreItemCursorFromState(&GenreItemCursorState{GenreID: 111223, CurPage: 5, CurMinPrice: 3000, MaxPrice: 50000}),
+			want:   &GenreItemCursorState{GenreID: 111223, CurPage: 5, CurMinPrice: 3000, MaxPrice: 50000},
+		},
+		{
+			name:   "state of the restored done cursor",
+			cursor: client.NewGenreItemCursorFromState(&GenreItemCursorState{GenreID: 111223, CurPage: 3, CurMinPrice: 3000, MaxPrice: 50000, IsDone: true}),
+			want:   &GenreItemCursorState{GenreID: 111223, CurPage: 3, CurMinPrice: 3000, MaxPrice: 50000, IsDone: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			// the state is saved as JSON in the checkpoint
+			b, err := json.Marshal(tt.cursor.State())
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var state GenreItemCursorState
+			if err := json.Unmarshal(b, &state); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if diff := cmp.Diff(tt.want, &state); diff != "" {
+				t.Errorf("State(), (-want +got): %s", diff)
+			}
+			restored := client.NewGenreItemCursorFromState(&state)
+			if diff := cmp.Diff(tt.want, restored.State()); diff != "" {
+				t.Errorf("NewGenreItemCursorFromState().State(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestGenreItemCursor_Next_restoredDone(t *testing.T) {
+	t.Parallel()
+
+	// the done cursor doesn't call the api, so the client without credentials is enough
+	cursor := (&Client{}).NewGenreItemCursorFromState(&GenreItemCursorState{GenreID: 100804, CurPage: 3, MaxPrice: 50000, IsDone: true})
+	if _, err := cursor.Next(context.Background()); err != Done {
+		t.Errorf("Next() error = %v, want %v", err, Done)
+	}
+}
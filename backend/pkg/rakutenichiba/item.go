@@ -136,7 +136,11 @@ func (c *Client) SearchItem(ctx context.Context, params *SearchItemParams) (*Sea
 		reqParams["page"] = strconv.Itoa(params.Page)
 	}
 
-	u := urlutil.CopyWithQueries(c.itemSearchAPIURL, c.buildParams(reqParams))
+	reqParams, err := c.buildParams(ctx, reqParams)
+	if err != nil {
+		return nil, fmt.Errorf("c.buildParams: %w", err)
+	}
+	u := urlutil.CopyWithQueries(c.itemSearchAPIURL, reqParams)
 	var resp SearchItemResponse
 	if err := httputil.GetAndUnmarshal(ctx, c.httpClient, u, &resp); err != nil {
 		return nil, fmt.Errorf("httputil.GetAndUnmarshal: %w", err)
@@ -0,0 +1,11 @@
+package strutil
+
+// Contains returns true when str is in strs
+func Contains(strs []string, str string) bool {
+	for _, s := range strs {
+		if s == str {
+			return true
+		}
+	}
+	return false
+}
@@ -3,8 +3,6 @@ package yahoo_shopping
 import (
 	"context"
 	"errors"
-
-	"github.com/cenkalti/backoff/v4"
 )
 
 var Done = errors.New("DONE")
@@ -30,6 +28,39 @@ func (c *Client) NewCategoryItemCursor(categoryID int, minPrice int, maxPrice in
 	}
 }
 
+// CategoryItemCursorState is the serializable state of CategoryItemCursor to resume the cursor
+type CategoryItemCursorState struct {
+	CategoryID  int  `json:"category_id"`
+	CurPage     int  `json:"cur_page"`
+	CurMinPrice int  `json:"cur_min_price"`
+	MaxPrice    int  `json:"max_price"`
+	IsDone      bool `json:"is_done"`
+}
+
+// NewCategoryItemCursorFromState restores the cursor from the state
+func (c *Client) NewCategoryItemCursorFromState(state *CategoryItemCursorState) *CategoryItemCursor {
+	return &CategoryItemCursor{
+		shoppingClient: c,
+		categoryID:     state.CategoryID,
+		curPage:        state.CurPage,
+		curMinPrice:    state.CurMinPrice,
+		maxPrice:       state.MaxPrice,
+		isDone:         state.IsDone,
+	}
+}
+
+// State returns the current state of the cursor
+// Restoring the cursor from the state fetches the next items from where the cursor was.
+func (g *CategoryItemCursor) State() *CategoryItemCursorState {
+	return &CategoryItemCursorState{
+		CategoryID:  g.categoryID,
+		CurPage:     g.curPage,
+		CurMinPrice: g.curMinPrice,
+		MaxPrice:    g.maxPrice,
+		IsDone:      g.isDone,
+	}
+}
+
 func (g *CategoryItemCursor) CurPage() int {
 	return g.curPage
 }
@@ -48,13 +79,7 @@ func (g *CategoryItemCursor) Next(ctx context.Context) (*SearchItemResponse, err
 		Page:       g.curPage,
 		SortType:   SearchItemSortTypePriceAsc,
 	}
-	var searchItemRes *SearchItemResponse
-	b := backoff.WithMaxRetries(backoff.NewExponentialBackOff(), 5)
-	err := backoff.Retry(func() error {
-		var err error
-		searchItemRes, err = g.shoppingClient.SearchItem(ctx, searchItemParams)
-		return err
-	}, b)
+	searchItemRes, err := g.shoppingClient.SearchItem(ctx, searchItemParams)
 	if err != nil {
 		return nil, err
 	}
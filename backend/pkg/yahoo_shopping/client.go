@@ -2,6 +2,7 @@ package yahoo_shopping
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -9,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/k-yomo/kagu-miru/backend/internal/quota"
 	"github.com/k-yomo/kagu-miru/backend/pkg/httputil"
 	"github.com/k-yomo/kagu-miru/backend/pkg/urlutil"
 )
@@ -25,22 +27,27 @@ const (
 
 const CategoryFurnitureID = 2506
 
+// MaxAPICallPerDay is the daily limit of the api calls per application id
+const MaxAPICallPerDay = 50_000
+
 type Client struct {
 	mu sync.Mutex
 
 	applicationIDs []string
 	appIDIndex     int
+	quotaLedger    quota.Ledger
 
 	itemSearchAPIURL     *url.URL
 	categorySearchAPIURL *url.URL
 	httpClient           *http.Client
 }
 
-func NewClient(appIDs []string) *Client {
+func NewClient(appIDs []string, quotaLedger quota.Ledger) *Client {
 	itemAPIURL, _ := url.Parse(itemSearchAPIURL)
 	categoryAPIURL, _ := url.Parse(categorySearchAPIURL)
 	return &Client{
 		applicationIDs:       appIDs,
+		quotaLedger:          quotaLedger,
 		itemSearchAPIURL:     itemAPIURL,
 		categorySearchAPIURL: categoryAPIURL,
 		httpClient:           http.DefaultClient,
@@ -71,7 +78,11 @@ type GetCategoryResponse struct {
 
 // https://developer.yahoo.co.jp/webapi/shopping/shopping/v1/categorysearch.html
 func (c *Client) SearchCategory(ctx context.Context, categoryID int) (*GetCategoryResponse, error) {
-	u := urlutil.CopyWithQueries(c.categorySearchAPIURL, c.buildParams(map[string]string{"category_id": strconv.Itoa(categoryID)}))
+	params, err := c.buildParams(ctx, map[string]string{"category_id": strconv.Itoa(categoryID)})
+	if err != nil {
+		return nil, fmt.Errorf("c.buildParams: %w", err)
+	}
+	u := urlutil.CopyWithQueries(c.categorySearchAPIURL, params)
 	var resp GetCategoryResponse
 	if err := httputil.GetAndXMLUnmarshal(ctx, c.httpClient, u, &resp); err != nil {
 		return nil, fmt.Errorf("httputil.GetAndUnmarshal: %w", err)
@@ -170,7 +181,11 @@ func (c *Client) SearchItem(ctx context.Context, params *SearchItemParams) (*Sea
 		reqParams["price_to"] = strconv.Itoa(params.PriceTo)
 	}
 
-	u := urlutil.CopyWithQueries(c.itemSearchAPIURL, c.buildParams(reqParams))
+	reqParams, err := c.buildParams(ctx, reqParams)
+	if err != nil {
+		return nil, fmt.Errorf("c.buildParams: %w", err)
+	}
+	u := urlutil.CopyWithQueries(c.itemSearchAPIURL, reqParams)
 	var resp SearchItemResponse
 	if err := httputil.GetAndUnmarshal(ctx, c.httpClient, u, &resp); err != nil {
 		return nil, fmt.Errorf("httputil.GetAndUnmarshal: %w", err)
@@ -178,18 +193,39 @@ func (c *Client) SearchItem(ctx context.Context, params *SearchItemParams) (*Sea
 	return &resp, nil
 }
 
-func (c *Client) buildParams(params map[string]string) map[string]string {
+func (c *Client) buildParams(ctx context.Context, params map[string]string) (map[string]string, error) {
+	appID, err := c.getApplicationID(ctx)
+	if err != nil {
+		return nil, err
+	}
 	p := map[string]string{
-		"appid": c.getApplicationID(),
+		"appid": appID,
 	}
 	for k, v := range params {
 		p[k] = v
 	}
 
-	return p
+	return p, nil
+}
+
+// getApplicationID returns the next application id with the quota reserved
+// Application ids with the quota exhausted are skipped, and quota.ErrExhausted is returned when all of them are exhausted.
+func (c *Client) getApplicationID(ctx context.Context) (string, error) {
+	for range c.applicationIDs {
+		appID := c.nextApplicationID()
+		_, err := c.quotaLedger.Reserve(ctx, quota.CredentialID(appID), 1)
+		if errors.Is(err, quota.ErrExhausted) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("quotaLedger.Reserve: %w", err)
+		}
+		return appID, nil
+	}
+	return "", quota.ErrExhausted
 }
 
-func (c *Client) getApplicationID() string {
+func (c *Client) nextApplicationID() string {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/tracking"
+)
+
+// grades are decided by the click-through rate of the item displayed for the query
+const (
+	perfectGradeMinCTR = 0.3
+	goodGradeMinCTR    = 0.1
+)
+
+// querySearches is the aggregated searches and clicks of the query
+type querySearches struct {
+	searchIDs   map[string]bool
+	impressions map[string]int
+	clicks      map[string]int
+}
+
+// buildJudgmentsFromEvents builds judgments from the tracking events in newline delimited JSON
+// e.g. the events table exported from BigQuery. Only the queries searched minSearches times or more are judged
+// since the click-through rate of rare queries is too noisy.
+func buildJudgmentsFromEvents(r io.Reader, minSearches int) ([]*Judgment, error) {
+	searchIDQueryMap := make(map[string]string)
+	searchIDDisplayedItemIDsMap := make(map[string]map[string]bool)
+	searchIDClickedItemIDsMap := make(map[string]map[string]bool)
+
+	decoder := json.NewDecoder(r)
+	for {
+		var event tracking.Event
+		err := decoder.Decode(&event)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("json.Decode: %w", err)
+		}
+		if event.ID != gqlmodel.EventIDSearch.String() {
+			continue
+		}
+
+		switch event.Action {
+		case gqlmodel.ActionDisplay.String():
+			var params gqlmodel.SearchDisplayItemsActionParams
+			if err := json.Unmarshal([]byte(event.Params), &params); err != nil {
+				return nil, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			if params.SearchInput == nil {
+				continue
+			}
+			searchIDQueryMap[params.SearchID] = normalizeQuery(params.SearchInput.Query)
+			// display events are sent for each page
+			if searchIDDisplayedItemIDsMap[params.SearchID] == nil {
+				searchIDDisplayedItemIDsMap[params.SearchID] = make(map[string]bool)
+			}
+			for _, itemID := range params.ItemIds {
+				searchIDDisplayedItemIDsMap[params.SearchID][itemID] = true
+			}
+		case gqlmodel.ActionClickItem.String():
+			var params gqlmodel.SearchClickItemActionParams
+			if err := json.Unmarshal([]byte(event.Params), &params); err != nil {
+				return nil, fmt.Errorf("json.Unmarshal: %w", err)
+			}
+			// multiple clicks on the same item in the same search are counted once
+			if searchIDClickedItemIDsMap[params.SearchID] == nil {
+				searchIDClickedItemIDsMap[params.SearchID] = make(map[string]bool)
+			}
+			searchIDClickedItemIDsMap[params.SearchID][params.ItemID] = true
+		}
+	}
+
+	queries := make(map[string]*querySearches)
+	for searchID, query := range searchIDQueryMap {
+		if query == "" {
+			continue
+		}
+		searches, ok := queries[query]
+		if !ok {
+			searches = &querySearches{
+				searchIDs:   make(map[string]bool),
+				impressions: make(map[string]int),
+				clicks:      make(map[string]int),
+			}
+			queries[query] = searches
+		}
+		searches.searchIDs[searchID] = true
+		for itemID := range searchIDDisplayedItemIDsMap[searchID] {
+			searches.impressions[itemID]++
+		}
+		for itemID := range searchIDClickedItemIDsMap[searchID] {
+			searches.clicks[itemID]++
+		}
+	}
+
+	var judgments []*Judgment
+	for query, searches := range queries {
+		if len(searches.searchIDs) < minSearches || len(searches.clicks) == 0 {
+			continue
+		}
+		itemGrades := make(map[string]int, len(searches.clicks))
+		for itemID, clicks := range searches.clicks {
+			itemGrades[itemID] = calcClickGrade(clicks, searches.impressions[itemID])
+		}
+		judgments = append(judgments, &Judgment{Query: query, ItemGrades: itemGrades})
+	}
+	// frequent queries first
+	sort.Slice(judgments, func(i, j int) bool {
+		iSearches, jSearches := len(queries[judgments[i].Query].searchIDs), len(queries[judgments[j].Query].searchIDs)
+		if iSearches != jSearches {
+			return iSearches > jSearches
+		}
+		return judgments[i].Query < judgments[j].Query
+	})
+	return judgments, nil
+}
+
+// calcClickGrade returns the grade of the clicked item
+// impressions can be less than clicks when the display events are lost, then the item is graded by clicks only.
+func calcClickGrade(clicks int, impressions int) int {
+	if impressions < clicks {
+		impressions = clicks
+	}
+	ctr := float64(clicks) / float64(impressions)
+	switch {
+	case ctr >= perfectGradeMinCTR:
+		return 3
+	case ctr >= goodGradeMinCTR:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func normalizeQuery(query string) string {
+	return strings.ToLower(strings.TrimSpace(query))
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_buildJudgmentsFromEvents(t *testing.T) {
+	t.Parallel()
+
+	events := `
+{"id":"SEARCH","action":"DISPLAY","params":"{\"searchId\":\"s1\",\"searchInput\":{\"query\":\"Sofa \"},\"itemIds\":[\"a\",\"b\"]}"}
+{"id":"SEARCH","action":"DISPLAY","params":"{\"searchId\":\"s1\",\"searchInput\":{\"query\":\"Sofa \"},\"itemIds\":[\"c\"]}"}
+{"id":"SEARCH","action":"CLICK_ITEM","params":"{\"searchId\":\"s1\",\"itemId\":\"a\"}"}
+{"id":"SEARCH","action":"CLICK_ITEM","params":"{\"searchId\":\"s1\",\"itemId\":\"a\"}"}
+{"id":"SEARCH","action":"DISPLAY","params":"{\"searchId\":\"s2\",\"searchInput\":{\"query\":\"sofa\"},\"itemIds\":[\"a\",\"b\",\"c\"]}"}
+{"id":"SEARCH","action":"CLICK_ITEM","params":"{\"searchId\":\"s2\",\"itemId\":\"c\"}"}
+{"id":"SEARCH","action":"DISPLAY","params":"{\"searchId\":\"s3\",\"searchInput\":{\"query\":\"sofa\"},\"itemIds\":[\"a\",\"b\",\"c\"]}"}
+{"id":"SEARCH","action":"DISPLAY","params":"{\"searchId\":\"s4\",\"searchInput\":{\"query\":\"bed\"},\"itemIds\":[\"d\"]}"}
+{"id":"SEARCH","action":"CLICK_ITEM","params":"{\"searchId\":\"s4\",\"itemId\":\"d\"}"}
+{"id":"HOME","action":"CLICK_ITEM","params":"{\"componentId\":\"c1\",\"itemId\":\"a\"}"}
+`
+	got, err := buildJudgmentsFromEvents(strings.NewReader(events), 2)
+	if err != nil {
+		t.Fatalf("buildJudgmentsFromEvents() error = %v", err)
+	}
+	want := []*Judgment{
+		{Query: "sofa", ItemGrades: map[string]int{"a": 3, "c": 3}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("buildJudgmentsFromEvents(), (-want +got): %s", diff)
+	}
+}
+
+func Test_calcClickGrade(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		clicks      int
+		impressions int
+		want        int
+	}{
+		{name: "high ctr", clicks: 3, impressions: 10, want: 3},
+		{name: "middle ctr", clicks: 1, impressions: 10, want: 2},
+		{name: "low ctr", clicks: 1, impressions: 11, want: 1},
+		{name: "lost impressions", clicks: 2, impressions: 0, want: 3},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := calcClickGrade(tt.clicks, tt.impressions); got != tt.want {
+				t.Errorf("calcClickGrade() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
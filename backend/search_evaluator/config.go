@@ -0,0 +1,24 @@
+package main
+
+import "github.com/kelseyhightower/envconfig"
+
+type config struct {
+	GCPProjectID string `default:"local" envconfig:"GCP_PROJECT_ID"`
+
+	SpannerInstanceID string `envconfig:"SPANNER_INSTANCE_ID"`
+	SpannerDatabaseID string `envconfig:"SPANNER_DATABASE_ID"`
+
+	ElasticSearchUsername          string `envconfig:"ELASTICSEARCH_USERNAME"`
+	ElasticSearchPassword          string `envconfig:"ELASTICSEARCH_PASSWORD"`
+	ElasticSearchURL               string `default:"http://localhost:9200" envconfig:"ELASTICSEARCH_URL"`
+	ItemsIndexName                 string `default:"items" envconfig:"ITEMS_INDEX_NAME"`
+	ItemsQuerySuggestionsIndexName string `default:"items.query_suggestions" envconfig:"ITEMS_QUERY_SUGGESTIONS_INDEX_NAME"`
+}
+
+func newConfig() (*config, error) {
+	var cfg config
+	if err := envconfig.Process("", &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
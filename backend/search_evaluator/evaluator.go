@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/search"
+)
+
+// evaluator runs the judged queries through the search client and computes the metrics
+type evaluator struct {
+	searchClient search.Client
+	k            int
+}
+
+func newEvaluator(searchClient search.Client, k int) *evaluator {
+	return &evaluator{searchClient: searchClient, k: k}
+}
+
+// evaluate returns the metrics of each judgment searched with the profile
+func (e *evaluator) evaluate(ctx context.Context, judgments []*Judgment, profile *ranking.Profile) ([]*queryMetrics, error) {
+	metricsList := make([]*queryMetrics, 0, len(judgments))
+	for _, judgment := range judgments {
+		filter := judgment.Filter
+		if filter == nil {
+			filter = &gqlmodel.SearchFilter{}
+		}
+		input := &gqlmodel.SearchInput{
+			Query:    judgment.Query,
+			Filter:   filter,
+			PageSize: &e.k,
+		}
+		resp, err := e.searchClient.SearchItems(ctx, input, &search.SearchItemsOptions{
+			RankingProfile:      profile,
+			SkipQuerySuggestion: true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("searchClient.SearchItems: %w", err)
+		}
+
+		items := make([]rankedItem, 0, len(resp.Items))
+		for _, item := range resp.Items {
+			items = append(items, rankedItem{id: item.ID, groupID: item.GroupID})
+		}
+		metrics := evaluateRanking(judgment, items, e.k)
+		// relaxed results are counted as zero result in the same way as the zero result events
+		metrics.zeroResult = resp.TotalCount == 0 || resp.Relaxation != search.RelaxationNone
+		metricsList = append(metricsList, metrics)
+	}
+	return metricsList, nil
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/graph/gqlmodel"
+)
+
+// Judgment is the graded relevance of items for the query
+// Grades are 0 (irrelevant) to 3 (perfect), and the item grade is preferred over the group grade.
+type Judgment struct {
+	Query  string                 `json:"query"`
+	Filter *gqlmodel.SearchFilter `json:"filter,omitempty"`
+	// ItemGrades is the map of the item id to the grade
+	ItemGrades map[string]int `json:"itemGrades,omitempty"`
+	// GroupGrades is the map of the group id to the grade applied to all items in the group
+	GroupGrades map[string]int `json:"groupGrades,omitempty"`
+}
+
+func loadJudgments(path string) ([]*Judgment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	var judgments []*Judgment
+	if err := json.NewDecoder(f).Decode(&judgments); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+	for i, judgment := range judgments {
+		if judgment.Query == "" && judgment.Filter == nil {
+			return nil, fmt.Errorf("judgment[%d]: query or filter is required", i)
+		}
+	}
+	return judgments, nil
+}
+
+func writeJudgments(w io.Writer, judgments []*Judgment) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(judgments)
+}
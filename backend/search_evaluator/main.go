@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cloud.google.com/go/spanner"
+	"github.com/blendle/zapdriver"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/db"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/search"
+	"github.com/k-yomo/kagu-miru/backend/pkg/spannerutil"
+	"github.com/olivere/elastic/v7"
+	esconfig "github.com/olivere/elastic/v7/config"
+	"go.uber.org/zap"
+)
+
+// search_evaluator evaluates the search quality offline with the judgments of graded relevant items for queries.
+// Each query is searched with the real search client, and NDCG@k, MRR, recall@k and zero result rate are reported.
+// Two ranking profiles can be compared side by side with -candidate flag, where the profile is either the name of
+// the saved profile or the path to the profile config JSON file.
+// Judgments can be built from the click logs in the tracking event format with -build-judgments-from flag.
+func main() {
+	judgmentsPath := flag.String("judgments", "", "path to the judgments JSON file")
+	k := flag.Int("k", 10, "number of the top items to evaluate")
+	baseline := flag.String("baseline", ranking.DefaultProfileName, "ranking profile name or path to the profile config JSON file")
+	candidate := flag.String("candidate", "", "ranking profile name or path to the profile config JSON file to compare with the baseline")
+	eventsPath := flag.String("build-judgments-from", "", "path to the tracking events in newline delimited JSON to build judgments from")
+	minSearches := flag.Int("min-searches", 5, "min number of searches of the query to build judgments from the click logs")
+	flag.Parse()
+
+	logger, err := zapdriver.NewProduction()
+	if err != nil {
+		panic(err)
+	}
+
+	if *eventsPath != "" {
+		if err := buildJudgments(*eventsPath, *minSearches); err != nil {
+			logger.Fatal("failed to build judgments", zap.Error(err))
+		}
+		return
+	}
+	if *judgmentsPath == "" {
+		logger.Fatal("-judgments or -build-judgments-from is required")
+	}
+
+	cfg, err := newConfig()
+	if err != nil {
+		logger.Fatal("failed to initialize config", zap.Error(err))
+	}
+
+	ctx := context.Background()
+	spannerClient, err := spanner.NewClient(
+		ctx,
+		spannerutil.BuildSpannerDBPath(cfg.GCPProjectID, cfg.SpannerInstanceID, cfg.SpannerDatabaseID),
+	)
+	if err != nil {
+		logger.Fatal("failed to initialize spanner client", zap.Error(err))
+	}
+	defer spannerClient.Close()
+	dbClient := db.NewSpannerDBClient(spannerClient)
+
+	esClient, err := elastic.NewClientFromConfig(&esconfig.Config{
+		URL:      cfg.ElasticSearchURL,
+		Username: cfg.ElasticSearchUsername,
+		Password: cfg.ElasticSearchPassword,
+		Sniff:    func() *bool { f := false; return &f }(),
+	})
+	if err != nil {
+		logger.Fatal("failed to initialize elasticsearch client", zap.Error(err))
+	}
+
+	judgments, err := loadJudgments(*judgmentsPath)
+	if err != nil {
+		logger.Fatal("failed to load judgments", zap.Error(err))
+	}
+
+	rankingClient := ranking.NewRankingClient(dbClient)
+	profileNames := []string{*baseline}
+	if *candidate != "" {
+		profileNames = append(profileNames, *candidate)
+	}
+	e := newEvaluator(search.NewSearchClient(cfg.ItemsIndexName, cfg.ItemsQuerySuggestionsIndexName, esClient, dbClient), *k)
+	var results []*profileResult
+	for _, profileName := range profileNames {
+		profile, err := loadProfile(ctx, rankingClient, profileName)
+		if err != nil {
+			logger.Fatal("failed to load ranking profile", zap.String("profile", profileName), zap.Error(err))
+		}
+		metricsList, err := e.evaluate(ctx, judgments, profile)
+		if err != nil {
+			logger.Fatal("failed to evaluate", zap.String("profile", profileName), zap.Error(err))
+		}
+		results = append(results, &profileResult{profile: profile, metricsList: metricsList})
+	}
+
+	if err := writeReport(os.Stdout, *k, judgments, results); err != nil {
+		logger.Fatal("failed to write report", zap.Error(err))
+	}
+}
+
+func buildJudgments(eventsPath string, minSearches int) error {
+	f, err := os.Open(eventsPath)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	judgments, err := buildJudgmentsFromEvents(f, minSearches)
+	if err != nil {
+		return fmt.Errorf("buildJudgmentsFromEvents: %w", err)
+	}
+	return writeJudgments(os.Stdout, judgments)
+}
+
+// loadProfile loads the profile from the config file when the path to JSON file is given, otherwise the saved profile
+func loadProfile(ctx context.Context, rankingClient ranking.Client, nameOrPath string) (*ranking.Profile, error) {
+	if !strings.HasSuffix(nameOrPath, ".json") {
+		return rankingClient.GetProfile(ctx, nameOrPath)
+	}
+	config, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	name := strings.TrimSuffix(filepath.Base(nameOrPath), ".json")
+	return ranking.NewProfileFromConfig(name, string(config))
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+// rankedItem is the item in the search result in the ranked order
+type rankedItem struct {
+	id      string
+	groupID string
+}
+
+// queryMetrics is the metrics of the search result for the judgment
+type queryMetrics struct {
+	ndcg           float64
+	reciprocalRank float64
+	recall         float64
+	zeroResult     bool
+}
+
+// judgedUnit is the item or group graded in the judgment
+type judgedUnit struct {
+	isGroup bool
+	id      string
+}
+
+// gradeOf returns the judged unit and the grade of the item, the grade is 0 when not judged
+func (j *Judgment) gradeOf(item rankedItem) (judgedUnit, int) {
+	if grade, ok := j.ItemGrades[item.id]; ok {
+		return judgedUnit{id: item.id}, grade
+	}
+	if grade, ok := j.GroupGrades[item.groupID]; ok && item.groupID != "" {
+		return judgedUnit{isGroup: true, id: item.groupID}, grade
+	}
+	return judgedUnit{}, 0
+}
+
+// relevantGrades returns the grades of the relevant units in descending order
+func (j *Judgment) relevantGrades() []int {
+	var grades []int
+	for _, grade := range j.ItemGrades {
+		if grade > 0 {
+			grades = append(grades, grade)
+		}
+	}
+	for _, grade := range j.GroupGrades {
+		if grade > 0 {
+			grades = append(grades, grade)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(grades)))
+	return grades
+}
+
+// evaluateRanking computes the metrics of the top k items
+// Each judged unit is counted only once, so that the items in the same group don't inflate the metrics.
+func evaluateRanking(judgment *Judgment, items []rankedItem, k int) *queryMetrics {
+	if len(items) > k {
+		items = items[:k]
+	}
+
+	metrics := &queryMetrics{zeroResult: len(items) == 0}
+	seen := make(map[judgedUnit]bool)
+	var dcg float64
+	var retrieved int
+	for i, item := range items {
+		unit, grade := judgment.gradeOf(item)
+		if grade <= 0 || seen[unit] {
+			continue
+		}
+		seen[unit] = true
+		retrieved++
+		dcg += calcGain(grade, i)
+		if metrics.reciprocalRank == 0 {
+			metrics.reciprocalRank = 1 / float64(i+1)
+		}
+	}
+
+	relevantGrades := judgment.relevantGrades()
+	if len(relevantGrades) == 0 {
+		return metrics
+	}
+	var idealDCG float64
+	for i, grade := range relevantGrades {
+		if i >= k {
+			break
+		}
+		idealDCG += calcGain(grade, i)
+	}
+	metrics.ndcg = dcg / idealDCG
+	metrics.recall = float64(retrieved) / float64(len(relevantGrades))
+	return metrics
+}
+
+// calcGain returns the discounted gain of the grade at the position starting from 0
+func calcGain(grade int, position int) float64 {
+	return (math.Pow(2, float64(grade)) - 1) / math.Log2(float64(position+2))
+}
+
+// summary is the mean of the metrics over the queries
+type summary struct {
+	queryCount     int
+	ndcg           float64
+	mrr            float64
+	recall         float64
+	zeroResultRate float64
+}
+
+func summarize(metricsList []*queryMetrics) *summary {
+	s := &summary{queryCount: len(metricsList)}
+	if len(metricsList) == 0 {
+		return s
+	}
+	var zeroResultCount int
+	for _, metrics := range metricsList {
+		s.ndcg += metrics.ndcg
+		s.mrr += metrics.reciprocalRank
+		s.recall += metrics.recall
+		if metrics.zeroResult {
+			zeroResultCount++
+		}
+	}
+	n := float64(len(metricsList))
+	s.ndcg /= n
+	s.mrr /= n
+	s.recall /= n
+	s.zeroResultRate = float64(zeroResultCount) / n
+	return s
+}
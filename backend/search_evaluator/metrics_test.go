@@ -0,0 +1,83 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func Test_evaluateRanking(t *testing.T) {
+	t.Parallel()
+
+	judgment := &Judgment{
+		Query:       "sofa",
+		ItemGrades:  map[string]int{"a": 3, "b": 1, "c": 0},
+		GroupGrades: map[string]int{"g1": 2},
+	}
+	idealDCG := 7 + 3/math.Log2(3) + 1/math.Log2(4)
+
+	tests := []struct {
+		name  string
+		items []rankedItem
+		k     int
+		want  *queryMetrics
+	}{
+		{
+			name:  "ideal ranking",
+			items: []rankedItem{{id: "a"}, {id: "d", groupID: "g1"}, {id: "b"}},
+			k:     10,
+			want:  &queryMetrics{ndcg: 1, reciprocalRank: 1, recall: 1},
+		},
+		{
+			name:  "items in the same group are counted once",
+			items: []rankedItem{{id: "c"}, {id: "d", groupID: "g1"}, {id: "e", groupID: "g1"}, {id: "a"}},
+			k:     10,
+			want: &queryMetrics{
+				ndcg:           (3/math.Log2(3) + 7/math.Log2(5)) / idealDCG,
+				reciprocalRank: 0.5,
+				recall:         2.0 / 3.0,
+			},
+		},
+		{
+			name:  "items after k are ignored",
+			items: []rankedItem{{id: "x"}, {id: "a"}},
+			k:     1,
+			want:  &queryMetrics{},
+		},
+		{
+			name:  "zero result",
+			items: nil,
+			k:     10,
+			want:  &queryMetrics{zeroResult: true},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := evaluateRanking(judgment, tt.items, tt.k)
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(queryMetrics{}), cmp.Comparer(func(x, y float64) bool {
+				return math.Abs(x-y) < 1e-9
+			})); diff != "" {
+				t.Errorf("evaluateRanking(), (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func Test_summarize(t *testing.T) {
+	t.Parallel()
+
+	got := summarize([]*queryMetrics{
+		{ndcg: 1, reciprocalRank: 1, recall: 1},
+		{ndcg: 0.5, reciprocalRank: 0.5, recall: 0.5},
+		{zeroResult: true},
+		{ndcg: 0.5, reciprocalRank: 0.5, recall: 0.5},
+	})
+	want := &summary{queryCount: 4, ndcg: 0.5, mrr: 0.5, recall: 0.5, zeroResultRate: 0.25}
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(summary{})); diff != "" {
+		t.Errorf("summarize(), (-want +got): %s", diff)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/k-yomo/kagu-miru/backend/kagu_miru_api/ranking"
+)
+
+// profileResult is the metrics of the judgments searched with the profile
+type profileResult struct {
+	profile     *ranking.Profile
+	metricsList []*queryMetrics
+}
+
+// writeReport writes the metrics of the profiles side by side
+// The delta from the first profile is written when comparing two profiles.
+func writeReport(w io.Writer, k int, judgments []*Judgment, results []*profileResult) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	header := []string{"query"}
+	for _, result := range results {
+		header = append(header, fmt.Sprintf("ndcg@%d(%s)", k, profileLabel(result.profile)), fmt.Sprintf("rr(%s)", profileLabel(result.profile)))
+	}
+	if len(results) == 2 {
+		header = append(header, "ndcg_delta")
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for i, judgment := range judgments {
+		row := []string{judgment.Query}
+		for _, result := range results {
+			metrics := result.metricsList[i]
+			row = append(row, formatMetric(metrics.ndcg), formatMetric(metrics.reciprocalRank))
+		}
+		if len(results) == 2 {
+			row = append(row, formatDelta(results[1].metricsList[i].ndcg-results[0].metricsList[i].ndcg))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	fmt.Fprintln(tw)
+
+	summaryHeader := []string{"profile", "queries", fmt.Sprintf("ndcg@%d", k), "mrr", fmt.Sprintf("recall@%d", k), "zero_result_rate"}
+	fmt.Fprintln(tw, strings.Join(summaryHeader, "\t"))
+	for _, result := range results {
+		s := summarize(result.metricsList)
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\n",
+			profileLabel(result.profile), s.queryCount, formatMetric(s.ndcg), formatMetric(s.mrr), formatMetric(s.recall), formatMetric(s.zeroResultRate),
+		)
+	}
+	return tw.Flush()
+}
+
+func profileLabel(profile *ranking.Profile) string {
+	if profile.Version == 0 {
+		return profile.Name
+	}
+	return fmt.Sprintf("%s:v%d", profile.Name, profile.Version)
+}
+
+func formatMetric(v float64) string {
+	return fmt.Sprintf("%.4f", v)
+}
+
+func formatDelta(v float64) string {
+	return fmt.Sprintf("%+.4f", v)
+}
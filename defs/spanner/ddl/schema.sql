@@ -82,3 +82,74 @@ CREATE TABLE items (
 
 CREATE INDEX items_by_updated_at ON items (updated_at);
 CREATE INDEX items_by_group_id ON items (group_id);
+
+CREATE TABLE synonym_sets (
+    id STRING(256) NOT NULL,
+    name STRING(256) NOT NULL,
+    synonyms ARRAY<STRING(256)> NOT NULL,
+    is_active BOOL NOT NULL,
+    version INT64 NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+) PRIMARY KEY(id);
+
+CREATE TABLE synonym_set_histories (
+    synonym_set_id STRING(256) NOT NULL,
+    version INT64 NOT NULL,
+    name STRING(256) NOT NULL,
+    synonyms ARRAY<STRING(256)> NOT NULL,
+    is_active BOOL NOT NULL,
+    created_at TIMESTAMP NOT NULL
+) PRIMARY KEY(synonym_set_id, version),
+  INTERLEAVE IN PARENT synonym_sets ON DELETE CASCADE;
+
+CREATE TABLE merchandising_rules (
+    id STRING(256) NOT NULL,
+    name STRING(256) NOT NULL,
+    query STRING(256),
+    category_id STRING(256),
+    pinned_item_ids ARRAY<STRING(256)> NOT NULL,
+    pinned_group_ids ARRAY<STRING(256)> NOT NULL,
+    buried_item_ids ARRAY<STRING(256)> NOT NULL,
+    buried_group_ids ARRAY<STRING(256)> NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    is_active BOOL NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+) PRIMARY KEY(id);
+
+CREATE INDEX merchandising_rules_by_ends_at ON merchandising_rules (ends_at);
+
+CREATE TABLE ranking_profiles (
+    name STRING(256) NOT NULL,
+    version INT64 NOT NULL,
+    config STRING(MAX) NOT NULL,
+    created_at TIMESTAMP NOT NULL
+) PRIMARY KEY(name, version DESC);
+
+CREATE TABLE experiments (
+    id STRING(256) NOT NULL,
+    name STRING(256) NOT NULL,
+    variants STRING(MAX) NOT NULL,
+    traffic_allocation FLOAT64 NOT NULL,
+    starts_at TIMESTAMP NOT NULL,
+    ends_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+) PRIMARY KEY(id);
+
+CREATE INDEX experiments_by_ends_at ON experiments (ends_at);
+
+CREATE TABLE api_quota_usages (
+    marketplace STRING(256) NOT NULL,
+    credential_id STRING(256) NOT NULL,
+    date DATE NOT NULL,
+    call_count INT64 NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+) PRIMARY KEY(marketplace, credential_id, date DESC);
+
+CREATE TABLE item_fetcher_checkpoints (
+    fetcher_name STRING(256) NOT NULL,
+    unit_id STRING(256) NOT NULL,
+    run_id STRING(256) NOT NULL,
+    cursor_state STRING(MAX) NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+) PRIMARY KEY(fetcher_name, unit_id);
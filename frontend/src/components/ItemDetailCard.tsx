@@ -38,12 +38,14 @@ export default function ItemDetailCard({ itemId }: Props) {
           <div className="my-1 line-clamp-3 font-bold text-sm sm:text-lg">
             {item.name}
           </div>
-          <div className="my-1 flex items-center">
-            <Rating rating={item.averageRating} maxRating={5} />
-            <div className="ml-1 text-sm text-text-secondary dark:text-text-secondary-dark">
-              {item.reviewCount}
+          {item.averageRating != null && item.reviewCount != null && (
+            <div className="my-1 flex items-center">
+              <Rating rating={item.averageRating} maxRating={5} />
+              <div className="ml-1 text-sm text-text-secondary dark:text-text-secondary-dark">
+                {item.reviewCount}
+              </div>
             </div>
-          </div>
+          )}
           <div className="my-2 text-xl sm:text-2xl text-black dark:text-white font-bold">
             {item.price.toLocaleString()}円
           </div>
@@ -33,6 +33,7 @@ gql`
   query search($input: SearchInput!) {
     search(input: $input) {
       searchId
+      pinnedItemIds
       itemConnection {
         pageInfo {
           page
@@ -357,11 +358,15 @@ export const SearchProvider: FC<Props> = memo(function SearchProvider({
       if (isAdmin) {
         return;
       }
+      const itemIds = data.search.itemConnection.nodes.map((item) => item.id);
       const params: SearchDisplayItemsActionParams = {
         searchId: data.search.searchId,
         searchInput: searchState.searchInput,
         searchFrom: searchState.searchFrom,
-        itemIds: data.search.itemConnection.nodes.map((item) => item.id),
+        itemIds,
+        pinnedPositions: data.search.pinnedItemIds
+          .map((pinnedItemId) => itemIds.indexOf(pinnedItemId))
+          .filter((position) => position >= 0),
       };
       trackEvent({
         variables: {
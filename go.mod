@@ -3,7 +3,9 @@ module github.com/k-yomo/kagu-miru
 go 1.17
 
 require (
+	cloud.google.com/go v0.100.2
 	cloud.google.com/go/aiplatform v1.8.0
+	cloud.google.com/go/bigquery v1.24.0
 	cloud.google.com/go/profiler v0.2.0
 	cloud.google.com/go/pubsub v1.19.0
 	cloud.google.com/go/spanner v1.30.0
@@ -34,6 +36,7 @@ require (
 	go.uber.org/multierr v1.8.0
 	go.uber.org/zap v1.21.0
 	golang.org/x/sync v0.0.0-20210220032951-036812b2e83c
+	golang.org/x/text v0.3.7
 	golang.org/x/time v0.0.0-20211116232009-f0f3c7e86c11
 	google.golang.org/api v0.74.0
 	google.golang.org/genproto v0.0.0-20220328180837-c47567c462d1
@@ -43,7 +46,6 @@ require (
 )
 
 require (
-	cloud.google.com/go v0.100.2 // indirect
 	cloud.google.com/go/compute v1.5.0 // indirect
 	cloud.google.com/go/iam v0.1.1 // indirect
 	cloud.google.com/go/trace v1.0.0 // indirect
@@ -90,7 +92,6 @@ require (
 	golang.org/x/oauth2 v0.0.0-20220309155454-6242fa91716a // indirect
 	golang.org/x/sys v0.0.0-20220328115105-d36c6a25d886 // indirect
 	golang.org/x/term v0.0.0-20210927222741-03fcf44c2211 // indirect
-	golang.org/x/text v0.3.7 // indirect
 	golang.org/x/tools v0.1.9 // indirect
 	golang.org/x/xerrors v0.0.0-20200804184101-5ec99f83aff1 // indirect
 	google.golang.org/appengine v1.6.7 // indirect
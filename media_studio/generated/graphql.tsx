@@ -26,6 +26,7 @@ export type Scalars = {
 export enum Action {
   ClickItem = 'CLICK_ITEM',
   Display = 'DISPLAY',
+  ZeroResult = 'ZERO_RESULT',
 }
 
 export type AppliedMetadata = {
@@ -33,8 +34,15 @@ export type AppliedMetadata = {
   values: Array<Scalars['String']>;
 };
 
+export type DimensionsInput = {
+  depth?: InputMaybe<Scalars['Int']>;
+  height?: InputMaybe<Scalars['Int']>;
+  width?: InputMaybe<Scalars['Int']>;
+};
+
 export enum ErrorCode {
   Internal = 'INTERNAL',
+  InvalidArgument = 'INVALID_ARGUMENT',
   NotFound = 'NOT_FOUND',
 }
 
@@ -54,35 +62,60 @@ export enum EventId {
 
 export type Facet = {
   facetType: FacetType;
+  histogram?: Maybe<Array<HistogramBucket>>;
+  stats?: Maybe<FacetStats>;
   title: Scalars['String'];
   totalCount: Scalars['Int'];
   values: Array<FacetValue>;
 };
 
+export type FacetStats = {
+  avg: Scalars['Float'];
+  max: Scalars['Float'];
+  min: Scalars['Float'];
+  percentiles: Array<Percentile>;
+};
+
 export enum FacetType {
+  AverageRating = 'AVERAGE_RATING',
   BrandNames = 'BRAND_NAMES',
   CategoryIds = 'CATEGORY_IDS',
   Colors = 'COLORS',
   Metadata = 'METADATA',
+  Platforms = 'PLATFORMS',
+  Price = 'PRICE',
+  ReviewCount = 'REVIEW_COUNT',
+  ShopIds = 'SHOP_IDS',
 }
 
 export type FacetValue = {
+  children: Array<FacetValue>;
   count: Scalars['Int'];
   id: Scalars['ID'];
   name: Scalars['String'];
+  parentId?: Maybe<Scalars['ID']>;
 };
 
 export type GetSimilarItemsInput = {
+  cursor?: InputMaybe<Scalars['String']>;
   itemId: Scalars['ID'];
   page?: InputMaybe<Scalars['Int']>;
   pageSize?: InputMaybe<Scalars['Int']>;
+  rankingProfile?: InputMaybe<Scalars['String']>;
 };
 
 export type GetSimilarItemsResponse = {
   itemConnection: ItemConnection;
+  rankingProfile: RankingProfile;
   searchId: Scalars['String'];
 };
 
+export type HistogramBucket = {
+  count: Scalars['Int'];
+  max: Scalars['Float'];
+  min: Scalars['Float'];
+};
+
 export type HomeClickItemActionParams = {
   componentId: Scalars['ID'];
   itemId: Scalars['String'];
@@ -123,19 +156,32 @@ export type HomeResponse = {
   components: Array<HomeComponent>;
 };
 
+export type InterpretedQueryPart = {
+  text: Scalars['String'];
+  type: InterpretedQueryPartType;
+};
+
+export enum InterpretedQueryPartType {
+  Brand = 'BRAND',
+  Color = 'COLOR',
+  Dimension = 'DIMENSION',
+  Price = 'PRICE',
+}
+
 export type Item = {
   affiliateUrl: Scalars['String'];
-  averageRating: Scalars['Float'];
+  averageRating?: Maybe<Scalars['Float']>;
   categoryId: Scalars['ID'];
   colors: Array<ItemColor>;
   description: Scalars['String'];
   groupID: Scalars['ID'];
+  highlight?: Maybe<ItemHighlight>;
   id: Scalars['ID'];
   imageUrls: Array<Scalars['String']>;
   name: Scalars['String'];
   platform: ItemSellingPlatform;
   price: Scalars['Int'];
-  reviewCount: Scalars['Int'];
+  reviewCount?: Maybe<Scalars['Int']>;
   sameGroupItems: Array<Item>;
   status: ItemStatus;
   url: Scalars['String'];
@@ -177,7 +223,13 @@ export type ItemConnection = {
   pageInfo: PageInfo;
 };
 
+export type ItemHighlight = {
+  description?: Maybe<Scalars['String']>;
+  name?: Maybe<Scalars['String']>;
+};
+
 export enum ItemSellingPlatform {
+  Amazon = 'AMAZON',
   PaypayMall = 'PAYPAY_MALL',
   Rakuten = 'RAKUTEN',
   YahooShopping = 'YAHOO_SHOPPING',
@@ -211,11 +263,18 @@ export type MutationTrackEventArgs = {
 };
 
 export type PageInfo = {
+  endCursor?: Maybe<Scalars['String']>;
+  hasNextPage: Scalars['Boolean'];
   page: Scalars['Int'];
   totalCount: Scalars['Int'];
   totalPage: Scalars['Int'];
 };
 
+export type Percentile = {
+  percent: Scalars['Float'];
+  value: Scalars['Float'];
+};
+
 export type Query = {
   getAllItemCategories: Array<ItemCategory>;
   getItem: Item;
@@ -251,13 +310,33 @@ export type QuerySuggestionsResponse = {
   suggestedQueries: Array<Scalars['String']>;
 };
 
+export type RankingProfile = {
+  name: Scalars['String'];
+  version: Scalars['Int'];
+};
+
+export type RankingProfileInput = {
+  name: Scalars['String'];
+  version: Scalars['Int'];
+};
+
 export type SearchClickItemActionParams = {
   itemId: Scalars['String'];
   searchId: Scalars['String'];
 };
 
+export type SearchDebugInfo = {
+  aggregations: Scalars['Map'];
+  esRequest: Scalars['Map'];
+  hitExplanations: Array<SearchHitExplanation>;
+  metadataPostFilters: Scalars['Map'];
+  postFilters: Scalars['Map'];
+};
+
 export type SearchDisplayItemsActionParams = {
   itemIds: Array<Scalars['ID']>;
+  pinnedPositions?: InputMaybe<Array<Scalars['Int']>>;
+  rankingProfile?: InputMaybe<RankingProfileInput>;
   searchFrom: SearchFrom;
   searchId: Scalars['String'];
   searchInput: SearchInput;
@@ -267,11 +346,19 @@ export type SearchFilter = {
   brandNames?: InputMaybe<Array<Scalars['String']>>;
   categoryIds?: InputMaybe<Array<Scalars['ID']>>;
   colors?: InputMaybe<Array<ItemColor>>;
+  fitsWithin?: InputMaybe<DimensionsInput>;
+  maxDepth?: InputMaybe<Scalars['Int']>;
+  maxHeight?: InputMaybe<Scalars['Int']>;
   maxPrice?: InputMaybe<Scalars['Int']>;
+  maxWidth?: InputMaybe<Scalars['Int']>;
   metadata?: InputMaybe<Array<AppliedMetadata>>;
+  minDepth?: InputMaybe<Scalars['Int']>;
+  minHeight?: InputMaybe<Scalars['Int']>;
   minPrice?: InputMaybe<Scalars['Int']>;
   minRating?: InputMaybe<Scalars['Int']>;
+  minWidth?: InputMaybe<Scalars['Int']>;
   platforms?: InputMaybe<Array<ItemSellingPlatform>>;
+  shopIds?: InputMaybe<Array<Scalars['ID']>>;
 };
 
 export enum SearchFrom {
@@ -284,18 +371,41 @@ export enum SearchFrom {
   Url = 'URL',
 }
 
+export type SearchHitExplanation = {
+  explanation: Scalars['Map'];
+  itemId: Scalars['ID'];
+  score: Scalars['Float'];
+};
+
 export type SearchInput = {
+  cursor?: InputMaybe<Scalars['String']>;
+  debug?: InputMaybe<Scalars['Boolean']>;
+  disableAutoCorrect?: InputMaybe<Scalars['Boolean']>;
   filter?: InputMaybe<SearchFilter>;
   page?: InputMaybe<Scalars['Int']>;
   pageSize?: InputMaybe<Scalars['Int']>;
   query: Scalars['String'];
+  rankingProfile?: InputMaybe<Scalars['String']>;
   sortType?: InputMaybe<SearchSortType>;
 };
 
+export enum SearchRelaxation {
+  CategoryOnly = 'CATEGORY_ONLY',
+  DropFilters = 'DROP_FILTERS',
+  MinimumShouldMatch = 'MINIMUM_SHOULD_MATCH',
+}
+
 export type SearchResponse = {
+  autoCorrected: Scalars['Boolean'];
+  debug?: Maybe<SearchDebugInfo>;
   facets: Array<Facet>;
+  interpretedQueryParts: Array<InterpretedQueryPart>;
   itemConnection: ItemConnection;
+  pinnedItemIds: Array<Scalars['ID']>;
+  rankingProfile: RankingProfile;
+  relaxation?: Maybe<SearchRelaxation>;
   searchId: Scalars['String'];
+  spellingSuggestion?: Maybe<Scalars['String']>;
 };
 
 export enum SearchSortType {
@@ -306,9 +416,18 @@ export enum SearchSortType {
   ReviewCount = 'REVIEW_COUNT',
 }
 
+export type SearchZeroResultActionParams = {
+  rankingProfile?: InputMaybe<RankingProfileInput>;
+  recovered: Scalars['Boolean'];
+  relaxation?: InputMaybe<SearchRelaxation>;
+  searchId: Scalars['String'];
+  searchInput: SearchInput;
+};
+
 export type SimilarItemsDisplayItemsActionParams = {
   getSimilarItemsInput: GetSimilarItemsInput;
   itemIds: Array<Scalars['ID']>;
+  rankingProfile?: InputMaybe<RankingProfileInput>;
   searchId: Scalars['String'];
 };
 
@@ -363,8 +482,8 @@ export type ItemPreviewGetItemQuery = {
     affiliateUrl: string;
     price: number;
     imageUrls: Array<string>;
-    averageRating: number;
-    reviewCount: number;
+    averageRating?: number | null | undefined;
+    reviewCount?: number | null | undefined;
     categoryId: string;
     platform: ItemSellingPlatform;
   };